@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -26,6 +27,11 @@ import (
 	"github.com/vechain/thor/v2/xenv"
 )
 
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 1000
+)
+
 type Accounts struct {
 	repo              *chain.Repository
 	stater            *state.Stater
@@ -33,6 +39,7 @@ type Accounts struct {
 	forkConfig        thor.ForkConfig
 	bft               bft.Committer
 	enabledDeprecated bool
+	stateHistory      utils.StateHistory
 }
 
 func New(
@@ -42,6 +49,7 @@ func New(
 	forkConfig thor.ForkConfig,
 	bft bft.Committer,
 	enabledDeprecated bool,
+	stateHistory utils.StateHistory,
 ) *Accounts {
 	return &Accounts{
 		repo,
@@ -50,6 +58,7 @@ func New(
 		forkConfig,
 		bft,
 		enabledDeprecated,
+		stateHistory,
 	}
 }
 
@@ -72,16 +81,19 @@ func (a *Accounts) handleGetCode(w http.ResponseWriter, req *http.Request) error
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 
-	_, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater, a.forkConfig)
 	if err != nil {
 		if a.repo.IsNotFound(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
+	if err := utils.CheckStateHistory(summary.Header.Number(), a.stateHistory); err != nil {
+		return err
+	}
 	code, err := a.getCode(addr, st)
 	if err != nil {
-		return err
+		return utils.WrapStateError(err, summary.Header.Number(), a.stateHistory)
 	}
 
 	return utils.WriteJSON(w, &GetCodeResult{Code: hexutil.Encode(code)})
@@ -126,17 +138,20 @@ func (a *Accounts) handleGetAccount(w http.ResponseWriter, req *http.Request) er
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
 
-	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater, a.forkConfig)
 	if err != nil {
 		if a.repo.IsNotFound(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
+	if err := utils.CheckStateHistory(summary.Header.Number(), a.stateHistory); err != nil {
+		return err
+	}
 
 	acc, err := a.getAccount(addr, summary.Header, st)
 	if err != nil {
-		return err
+		return utils.WrapStateError(err, summary.Header.Number(), a.stateHistory)
 	}
 	return utils.WriteJSON(w, acc)
 }
@@ -154,20 +169,106 @@ func (a *Accounts) handleGetStorage(w http.ResponseWriter, req *http.Request) er
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
+	raw, err := utils.StringToBoolean(req.URL.Query().Get("raw"), false)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "raw"))
+	}
 
-	_, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater, a.forkConfig)
 	if err != nil {
 		if a.repo.IsNotFound(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
+	if err := utils.CheckStateHistory(summary.Header.Number(), a.stateHistory); err != nil {
+		return err
+	}
 
 	storage, err := a.getStorage(addr, key, st)
+	if err != nil {
+		return utils.WrapStateError(err, summary.Header.Number(), a.stateHistory)
+	}
+	result := &GetStorageResult{Value: storage.String()}
+	if raw {
+		rawStorage, err := st.GetRawStorage(addr, key)
+		if err != nil {
+			return utils.WrapStateError(err, summary.Header.Number(), a.stateHistory)
+		}
+		result.Raw = hexutil.Encode(rawStorage)
+	}
+	return utils.WriteJSON(w, result)
+}
+
+func (a *Accounts) handleGetAccountHistory(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	since, ok := a.stater.HistorySince()
+	if !ok {
+		return utils.Forbidden(errors.New("history index is not enabled"))
+	}
+	best := a.repo.BestBlockSummary().Header.Number()
+
+	// changes are listed from the first indexed block by default
+	from, err := parseUint32Query(req, "from", since)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "from"))
+	}
+	to, err := parseUint32Query(req, "to", best)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "to"))
+	}
+	limit, err := parseUint32Query(req, "limit", defaultHistoryLimit)
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "limit"))
+	}
+	if from > to && req.URL.Query().Get("from") != "" {
+		return utils.BadRequest(errors.New("from: greater than to"))
+	}
+	if from < since {
+		return utils.Forbidden(fmt.Errorf("from: account history is available from block #%d", since))
+	}
+	if limit > maxHistoryLimit {
+		return utils.Forbidden(fmt.Errorf("limit: exceeds %d", maxHistoryLimit))
+	}
+
+	chain := a.repo.NewBestChain()
+	records, err := a.stater.AccountHistory(addr, from, min(to, best), int(limit), chain.HasVersion)
 	if err != nil {
 		return err
 	}
-	return utils.WriteJSON(w, &GetStorageResult{Value: storage.String()})
+	items := make([]*AccountHistoryItem, 0, len(records))
+	for _, r := range records {
+		summary, err := chain.GetBlockSummary(r.Version.Major)
+		if err != nil {
+			return err
+		}
+		header := summary.Header
+		items = append(items, &AccountHistoryItem{
+			BlockID:        header.ID(),
+			BlockNumber:    header.Number(),
+			BlockTimestamp: header.Timestamp(),
+			Balance:        math.HexOrDecimal256(*r.Account.Balance),
+			Energy:         math.HexOrDecimal256(*r.Account.CalcEnergy(header.Timestamp())),
+			HasCode:        len(r.Account.CodeHash) > 0,
+		})
+	}
+	return utils.WriteJSON(w, items)
+}
+
+// parseUint32Query parses the uint32 query parameter, or returns the default value if absent.
+func parseUint32Query(req *http.Request, name string, def uint32) (uint32, error) {
+	str := req.URL.Query().Get(name)
+	if str == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseUint(str, 0, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(n), nil
 }
 
 func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request) error {
@@ -179,13 +280,16 @@ func (a *Accounts) handleCallContract(w http.ResponseWriter, req *http.Request)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
-	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater, a.forkConfig)
 	if err != nil {
 		if a.repo.IsNotFound(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
+	if err := utils.CheckStateHistory(summary.Header.Number(), a.stateHistory); err != nil {
+		return err
+	}
 	var addr *thor.Address
 	if mux.Vars(req)["address"] != "" {
 		address, err := thor.ParseAddress(mux.Vars(req)["address"])
@@ -222,13 +326,16 @@ func (a *Accounts) handleCallBatchCode(w http.ResponseWriter, req *http.Request)
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
-	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater)
+	summary, st, err := utils.GetSummaryAndState(revision, a.repo, a.bft, a.stater, a.forkConfig)
 	if err != nil {
 		if a.repo.IsNotFound(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
+	if err := utils.CheckStateHistory(summary.Header.Number(), a.stateHistory); err != nil {
+		return err
+	}
 	results, err := a.batchCall(req.Context(), batchCallData, summary.Header, st)
 	if err != nil {
 		return err
@@ -278,7 +385,7 @@ func (a *Accounts) batchCall(
 			case error:
 				return nil, v
 			case *runtime.Output:
-				results = append(results, convertCallResultWithInputGas(v, gas))
+				results = append(results, ConvertCallResult(v, gas))
 				if v.VMErr != nil {
 					return results, nil
 				}
@@ -371,6 +478,10 @@ func (a *Accounts) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodGet).
 		Name("GET /accounts/{address}/code").
 		HandlerFunc(utils.WrapHandlerFunc(a.handleGetCode))
+	sub.Path("/{address}/history").
+		Methods(http.MethodGet).
+		Name("GET /accounts/{address}/history").
+		HandlerFunc(utils.WrapHandlerFunc(a.handleGetAccountHistory))
 	sub.Path("/{address}/storage/{key}").
 		Methods("GET").
 		Name("GET /accounts/{address}/storage").
@@ -112,6 +112,7 @@ func TestAccount(t *testing.T) {
 		"getAccountWithNonExistingRevision":   getAccountWithNonExistingRevision,
 		"getAccountWithGenesisRevision":       getAccountWithGenesisRevision,
 		"getAccountWithFinalizedRevision":     getAccountWithFinalizedRevision,
+		"getAccountHistory":                   getAccountHistory,
 		"getCode":                             getCode,
 		"getCodeWithNonExistingRevision":      getCodeWithNonExistingRevision,
 		"getStorage":                          getStorage,
@@ -207,6 +208,45 @@ func getAccountWithFinalizedRevision(t *testing.T) {
 	assert.Equal(t, genesisEnergy, finalizedEnergy, "finalized energy should equal genesis energy")
 }
 
+func getAccountHistory(t *testing.T) {
+	_, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/accounts/" + invalidAddr + "/history")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode, "bad address")
+
+	res, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/accounts/" + addr.String() + "/history")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, "OK")
+	var items []*accounts.AccountHistoryItem
+	if err := json.Unmarshal(res, &items); err != nil {
+		t.Fatal(err)
+	}
+	require.Equal(t, 1, len(items))
+	assert.Equal(t, uint32(1), items[0].BlockNumber)
+	assert.Equal(t, math.HexOrDecimal256(*value), items[0].Balance)
+	assert.False(t, items[0].HasCode)
+
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + contractAddr.String() + "/history?from=1&to=1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, "OK")
+	if err := json.Unmarshal(res, &items); err != nil {
+		t.Fatal(err)
+	}
+	require.Equal(t, 1, len(items))
+	assert.True(t, items[0].HasCode)
+
+	_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + addr.String() + "/history?from=2&to=1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode, "bad range")
+
+	_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + addr.String() + "/history?from=0")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode, "not indexed")
+
+	_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + addr.String() + "/history?limit=1001")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode, "limit exceeded")
+}
+
 func getCode(t *testing.T) {
 	_, statusCode, err := tclient.RawHTTPClient().RawHTTPGet("/accounts/" + invalidAddr + "/code")
 	require.NoError(t, err)
@@ -267,6 +307,19 @@ func getStorage(t *testing.T) {
 	}
 	assert.Equal(t, thor.BytesToBytes32([]byte{storageValue}), h, "storage should be equal")
 	assert.Equal(t, http.StatusOK, statusCode, "OK")
+	assert.NotContains(t, value, "raw")
+
+	_, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + contractAddr.String() + "/storage/" + storageKey.String() + "?raw=yes")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode, "bad raw")
+
+	res, statusCode, err = tclient.RawHTTPClient().RawHTTPGet("/accounts/" + contractAddr.String() + "/storage/" + storageKey.String() + "?raw=true")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, "OK")
+	var rawValue accounts.GetStorageResult
+	require.NoError(t, json.Unmarshal(res, &rawValue))
+	assert.Equal(t, value["value"], rawValue.Value)
+	assert.Equal(t, hexutil.Encode([]byte{storageValue}), rawValue.Raw)
 }
 
 func getStorageWithNonExistingRevision(t *testing.T) {
@@ -282,6 +335,8 @@ func getStorageWithNonExistingRevision(t *testing.T) {
 func initAccountServer(t *testing.T, enabledDeprecated bool) {
 	thorChain, err := testchain.NewIntegrationTestChain()
 	require.NoError(t, err)
+	_, err = thorChain.Database().EnableHistory(1)
+	require.NoError(t, err)
 
 	genesisBlock = thorChain.GenesisBlock()
 	claTransfer := tx.NewClause(&addr).WithValue(value)
@@ -306,7 +361,7 @@ func initAccountServer(t *testing.T, enabledDeprecated bool) {
 	)
 
 	router := mux.NewRouter()
-	accounts.New(thorChain.Repo(), thorChain.Stater(), uint64(gasLimit), thor.NoFork, thorChain.Engine(), enabledDeprecated).
+	accounts.New(thorChain.Repo(), thorChain.Stater(), uint64(gasLimit), thor.NoFork, thorChain.Engine(), enabledDeprecated, nil).
 		Mount(router, "/accounts")
 
 	ts = httptest.NewServer(router)
@@ -20,6 +20,16 @@ type Account struct {
 	HasCode bool                 `json:"hasCode"`
 }
 
+// AccountHistoryItem is the account after a change made in a block
+type AccountHistoryItem struct {
+	BlockID        thor.Bytes32         `json:"blockID"`
+	BlockNumber    uint32               `json:"blockNumber"`
+	BlockTimestamp uint64               `json:"blockTimestamp"`
+	Balance        math.HexOrDecimal256 `json:"balance"`
+	Energy         math.HexOrDecimal256 `json:"energy"`
+	HasCode        bool                 `json:"hasCode"`
+}
+
 // CallData represents contract-call body
 type CallData struct {
 	Value    *math.HexOrDecimal256 `json:"value"`
@@ -35,6 +45,7 @@ type GetCodeResult struct {
 
 type GetStorageResult struct {
 	Value string `json:"value"`
+	Raw   string `json:"raw,omitempty"` // rlp raw value, only if requested
 }
 
 type CallResult struct {
@@ -46,7 +57,8 @@ type CallResult struct {
 	VMError   string                   `json:"vmError"`
 }
 
-func convertCallResultWithInputGas(vo *runtime.Output, inputGas uint64) *CallResult {
+// ConvertCallResult converts the output of a clause executed with the given gas.
+func ConvertCallResult(vo *runtime.Output, inputGas uint64) *CallResult {
 	gasUsed := inputGas - vo.LeftOverGas
 	var (
 		vmError  string
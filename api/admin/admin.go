@@ -14,17 +14,20 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/vechain/thor/v2/api/admin/apilogs"
 	"github.com/vechain/thor/v2/api/admin/loglevel"
+	"github.com/vechain/thor/v2/api/admin/solo"
 
 	healthAPI "github.com/vechain/thor/v2/api/admin/health"
 )
 
-func New(logLevel *slog.LevelVar, health *healthAPI.Health, apiLogsToggle *atomic.Bool) http.HandlerFunc {
+// New returns the admin handler, the solo APIs are refused if soloCtl is nil.
+func New(logLevel *slog.LevelVar, health *healthAPI.Health, apiLogsToggle *atomic.Bool, soloCtl solo.Controller) http.HandlerFunc {
 	router := mux.NewRouter()
 	subRouter := router.PathPrefix("/admin").Subrouter()
 
 	loglevel.New(logLevel).Mount(subRouter, "/loglevel")
 	healthAPI.NewAPI(health).Mount(subRouter, "/health")
 	apilogs.New(apiLogsToggle).Mount(subRouter, "/apilogs")
+	solo.New(soloCtl).Mount(subRouter, "/solo")
 
 	handler := handlers.CompressHandler(router)
 
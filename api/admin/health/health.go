@@ -26,22 +26,30 @@ type Status struct {
 }
 
 type Health struct {
-	repo *chain.Repository
-	p2p  *comm.Communicator
+	repo          *chain.Repository
+	p2p           *comm.Communicator
+	blockInterval uint64
 }
 
 const (
-	defaultBlockTolerance = time.Duration(2*thor.BlockInterval) * time.Second // 2 blocks tolerance
-	defaultMinPeerCount   = 2
+	defaultToleratedBlocks = 2
+	defaultMinPeerCount    = 2
 )
 
-func New(repo *chain.Repository, p2p *comm.Communicator) *Health {
+// New creates the health status of the node, whose blocks are produced every blockInterval seconds.
+func New(repo *chain.Repository, p2p *comm.Communicator, blockInterval uint64) *Health {
 	return &Health{
-		repo: repo,
-		p2p:  p2p,
+		repo:          repo,
+		p2p:           p2p,
+		blockInterval: blockInterval,
 	}
 }
 
+// defaultBlockTolerance returns the duration of the tolerated blocks.
+func (h *Health) defaultBlockTolerance() time.Duration {
+	return time.Duration(defaultToleratedBlocks*h.blockInterval) * time.Second
+}
+
 // isNetworkProgressing checks if the network is producing new blocks within the allowed interval.
 func (h *Health) isNetworkProgressing(now time.Time, bestBlockTimestamp time.Time, blockTolerance time.Duration) bool {
 	return now.Sub(bestBlockTimestamp) <= blockTolerance
@@ -29,7 +29,7 @@ func (h *API) handleGetHealth(w http.ResponseWriter, r *http.Request) error {
 	query := r.URL.Query()
 
 	// Default to constants if query parameters are not provided
-	blockTolerance := defaultBlockTolerance
+	blockTolerance := h.healthStatus.defaultBlockTolerance()
 	minPeerCount := defaultMinPeerCount
 
 	// Override with query parameters if they exist
@@ -10,10 +10,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/thor"
 )
 
 func TestHealth_isNetworkProgressing(t *testing.T) {
-	h := &Health{}
+	h := &Health{blockInterval: thor.BlockInterval}
 
 	now := time.Now()
 
@@ -36,7 +37,7 @@ func TestHealth_isNetworkProgressing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			isProgressing := h.isNetworkProgressing(now, tt.bestBlockTimestamp, defaultBlockTolerance)
+			isProgressing := h.isNetworkProgressing(now, tt.bestBlockTimestamp, h.defaultBlockTolerance())
 			assert.Equal(t, tt.expectedProgressing, isProgressing, "isNetworkProgressing result mismatch")
 		})
 	}
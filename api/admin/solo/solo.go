@@ -0,0 +1,276 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/api/utils"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	// maxEmptyBlocks limits the number of empty blocks packed by one request.
+	maxEmptyBlocks = 1000
+	// defaultImpersonateGas is the gas of impersonated clauses if not specified, which fits in a solo block.
+	defaultImpersonateGas = 10_000_000
+)
+
+// Controller controls the block production and the state of a solo node.
+type Controller interface {
+	Mine() (*block.Block, error)
+	MineEmpty(n int) ([]*block.Block, error)
+	IncreaseTime(seconds uint64) uint64
+	Snapshot(name string) (*chain.BlockSummary, error)
+	Revert(name string) (*chain.BlockSummary, error)
+
+	SetBalance(addr thor.Address, balance *big.Int)
+	SetEnergy(addr thor.Address, energy *big.Int)
+	SetCode(addr thor.Address, code []byte)
+	SetStorage(addr thor.Address, key, value thor.Bytes32)
+	Impersonate(ctx context.Context, origin thor.Address, clauses []*tx.Clause, gas uint64) ([]*runtime.Output, error)
+
+	Traces(blocks int) []json.RawMessage
+}
+
+type Solo struct {
+	ctl Controller
+}
+
+// New returns the solo API, all requests are refused if ctl is nil, i.e. not in solo mode.
+func New(ctl Controller) *Solo {
+	return &Solo{
+		ctl: ctl,
+	}
+}
+
+func (s *Solo) Mount(root *mux.Router, pathPrefix string) {
+	sub := root.PathPrefix(pathPrefix).Subrouter()
+	sub.Use(s.soloOnly)
+	sub.Path("/mine").
+		Methods(http.MethodPost).
+		Name("post-solo-mine").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleMine))
+	sub.Path("/mine/empty").
+		Methods(http.MethodPost).
+		Name("post-solo-mine-empty").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleMineEmpty))
+	sub.Path("/time").
+		Methods(http.MethodPost).
+		Name("post-solo-time").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleIncreaseTime))
+	sub.Path("/snapshots").
+		Methods(http.MethodPost).
+		Name("post-solo-snapshot").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleSnapshot))
+	sub.Path("/snapshots/{name}/revert").
+		Methods(http.MethodPost).
+		Name("post-solo-revert").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleRevert))
+	sub.Path("/accounts/{address}").
+		Methods(http.MethodPost).
+		Name("post-solo-account").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleSetAccount))
+	sub.Path("/impersonate").
+		Methods(http.MethodPost).
+		Name("post-solo-impersonate").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleImpersonate))
+	sub.Path("/traces").
+		Methods(http.MethodGet).
+		Name("get-solo-traces").
+		HandlerFunc(utils.WrapHandlerFunc(s.handleGetTraces))
+}
+
+// soloOnly refuses all requests if not in solo mode.
+func (s *Solo) soloOnly(next http.Handler) http.Handler {
+	return utils.WrapHandlerFunc(func(w http.ResponseWriter, req *http.Request) error {
+		if s.ctl == nil {
+			return utils.Forbidden(errors.New("only available in solo mode"))
+		}
+		next.ServeHTTP(w, req)
+		return nil
+	})
+}
+
+func (s *Solo) handleMine(w http.ResponseWriter, _ *http.Request) error {
+	b, err := s.ctl.Mine()
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, convertBlock(b))
+}
+
+func (s *Solo) handleMineEmpty(w http.ResponseWriter, req *http.Request) error {
+	var body MineEmptyRequest
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if body.Count < 1 || body.Count > maxEmptyBlocks {
+		return utils.BadRequest(errors.Errorf("count: should be in range [1, %d]", maxEmptyBlocks))
+	}
+
+	blocks, err := s.ctl.MineEmpty(body.Count)
+	if err != nil {
+		return err
+	}
+	res := make([]*Block, 0, len(blocks))
+	for _, b := range blocks {
+		res = append(res, convertBlock(b))
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (s *Solo) handleIncreaseTime(w http.ResponseWriter, req *http.Request) error {
+	var body TimeRequest
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	return utils.WriteJSON(w, TimeResponse{
+		Offset: s.ctl.IncreaseTime(body.Seconds),
+	})
+}
+
+func (s *Solo) handleSnapshot(w http.ResponseWriter, req *http.Request) error {
+	var body SnapshotRequest
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if body.Name == "" {
+		return utils.BadRequest(errors.New("name: empty"))
+	}
+
+	summary, err := s.ctl.Snapshot(body.Name)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, &Snapshot{
+		Name:  body.Name,
+		Block: newBlock(summary.Header, summary.Txs),
+	})
+}
+
+func (s *Solo) handleRevert(w http.ResponseWriter, req *http.Request) error {
+	name := mux.Vars(req)["name"]
+
+	// reverting fails mostly because of unknown or stale snapshots
+	summary, err := s.ctl.Revert(name)
+	if err != nil {
+		return utils.BadRequest(err)
+	}
+	return utils.WriteJSON(w, &Snapshot{
+		Name:  name,
+		Block: newBlock(summary.Header, summary.Txs),
+	})
+}
+
+func (s *Solo) handleSetAccount(w http.ResponseWriter, req *http.Request) error {
+	addr, err := thor.ParseAddress(mux.Vars(req)["address"])
+	if err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "address"))
+	}
+	var body AccountRequest
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+
+	storage := make(map[thor.Bytes32]thor.Bytes32, len(body.Storage))
+	for k, v := range body.Storage {
+		key, err := thor.ParseBytes32(k)
+		if err != nil {
+			return utils.BadRequest(errors.WithMessage(err, "storage key"))
+		}
+		storage[key] = v
+	}
+
+	if body.Balance != nil {
+		if (*big.Int)(body.Balance).Sign() < 0 {
+			return utils.BadRequest(errors.New("balance: negative"))
+		}
+		s.ctl.SetBalance(addr, (*big.Int)(body.Balance))
+	}
+	if body.Energy != nil {
+		if (*big.Int)(body.Energy).Sign() < 0 {
+			return utils.BadRequest(errors.New("energy: negative"))
+		}
+		s.ctl.SetEnergy(addr, (*big.Int)(body.Energy))
+	}
+	if body.Code != nil {
+		s.ctl.SetCode(addr, *body.Code)
+	}
+	for k, v := range storage {
+		s.ctl.SetStorage(addr, k, v)
+	}
+	return utils.WriteJSON(w, &body)
+}
+
+func (s *Solo) handleImpersonate(w http.ResponseWriter, req *http.Request) error {
+	var body ImpersonateRequest
+	if err := utils.ParseJSON(req.Body, &body); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+	if body.Origin == nil {
+		return utils.BadRequest(errors.New("origin: empty"))
+	}
+	gas := body.Gas
+	if gas == 0 {
+		gas = defaultImpersonateGas
+	}
+
+	clauses := make([]*tx.Clause, 0, len(body.Clauses))
+	for i, c := range body.Clauses {
+		value := new(big.Int)
+		if c.Value != nil {
+			value = (*big.Int)(c.Value)
+		}
+		var data []byte
+		if c.Data != "" {
+			var err error
+			if data, err = hexutil.Decode(c.Data); err != nil {
+				return utils.BadRequest(errors.WithMessage(err, fmt.Sprintf("data[%d]", i)))
+			}
+		}
+		clauses = append(clauses, tx.NewClause(c.To).WithData(data).WithValue(value))
+	}
+
+	outputs, err := s.ctl.Impersonate(req.Context(), *body.Origin, clauses, gas)
+	if err != nil {
+		return err
+	}
+	results := make(accounts.BatchCallResults, 0, len(outputs))
+	for _, output := range outputs {
+		results = append(results, accounts.ConvertCallResult(output, gas))
+		gas = output.LeftOverGas
+	}
+	return utils.WriteJSON(w, results)
+}
+
+func (s *Solo) handleGetTraces(w http.ResponseWriter, req *http.Request) error {
+	blocks := 0
+	if v := req.URL.Query().Get("blocks"); v != "" {
+		var err error
+		if blocks, err = strconv.Atoi(v); err != nil || blocks < 1 {
+			return utils.BadRequest(errors.New("blocks: should be a positive integer"))
+		}
+	}
+	traces := s.ctl.Traces(blocks)
+	if traces == nil {
+		traces = []json.RawMessage{}
+	}
+	return utils.WriteJSON(w, traces)
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+type fakeController struct {
+	best      *block.Block
+	offset    uint64
+	snapshots map[string]*block.Block
+	balances  map[thor.Address]*big.Int
+	energies  map[thor.Address]*big.Int
+	codes     map[thor.Address][]byte
+	storage   map[thor.Bytes32]thor.Bytes32
+	traces    []json.RawMessage
+}
+
+func (c *fakeController) Mine() (*block.Block, error) {
+	c.best = new(block.Builder).ParentID(c.best.Header().ID()).Timestamp(c.best.Header().Timestamp() + 10 + c.offset).Build()
+	return c.best, nil
+}
+
+func (c *fakeController) MineEmpty(n int) ([]*block.Block, error) {
+	var blocks []*block.Block
+	for range n {
+		b, _ := c.Mine()
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+func (c *fakeController) IncreaseTime(seconds uint64) uint64 {
+	c.offset += seconds
+	return c.offset
+}
+
+func (c *fakeController) Snapshot(name string) (*chain.BlockSummary, error) {
+	c.snapshots[name] = c.best
+	return &chain.BlockSummary{Header: c.best.Header()}, nil
+}
+
+func (c *fakeController) Revert(name string) (*chain.BlockSummary, error) {
+	b, ok := c.snapshots[name]
+	if !ok {
+		return nil, errors.New("snapshot not found")
+	}
+	c.best = b
+	return &chain.BlockSummary{Header: b.Header()}, nil
+}
+
+func (c *fakeController) SetBalance(addr thor.Address, balance *big.Int) {
+	c.balances[addr] = balance
+}
+
+func (c *fakeController) SetEnergy(addr thor.Address, energy *big.Int) {
+	c.energies[addr] = energy
+}
+
+func (c *fakeController) SetCode(addr thor.Address, code []byte) {
+	c.codes[addr] = code
+}
+
+func (c *fakeController) SetStorage(_ thor.Address, key, value thor.Bytes32) {
+	c.storage[key] = value
+}
+
+func (c *fakeController) Impersonate(_ context.Context, origin thor.Address, clauses []*tx.Clause, gas uint64) ([]*runtime.Output, error) {
+	outputs := make([]*runtime.Output, 0, len(clauses))
+	for _, clause := range clauses {
+		gas -= 1000
+		outputs = append(outputs, &runtime.Output{
+			Data:        origin.Bytes(),
+			LeftOverGas: gas,
+			Transfers:   tx.Transfers{{Sender: origin, Recipient: *clause.To(), Amount: clause.Value()}},
+		})
+	}
+	return outputs, nil
+}
+
+func (c *fakeController) Traces(n int) []json.RawMessage {
+	if n > 0 && n < len(c.traces) {
+		return c.traces[len(c.traces)-n:]
+	}
+	return c.traces
+}
+
+func newTestRouter(ctl Controller) func(path string, body any) (int, string) {
+	router := mux.NewRouter()
+	New(ctl).Mount(router, "/admin/solo")
+
+	return func(path string, body any) (int, string) {
+		var buf []byte
+		if body != nil {
+			buf, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(buf))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code, strings.TrimSpace(rr.Body.String())
+	}
+}
+
+func newFakeController() *fakeController {
+	return &fakeController{
+		best:      new(block.Builder).ParentID(thor.Bytes32{0xff, 0xff, 0xff, 0xff}).Build(),
+		snapshots: make(map[string]*block.Block),
+		balances:  make(map[thor.Address]*big.Int),
+		energies:  make(map[thor.Address]*big.Int),
+		codes:     make(map[thor.Address][]byte),
+		storage:   make(map[thor.Bytes32]thor.Bytes32),
+	}
+}
+
+func TestSolo(t *testing.T) {
+	ctl := newFakeController()
+	post := newTestRouter(ctl)
+
+	code, body := post("/admin/solo/mine", nil)
+	assert.Equal(t, http.StatusOK, code)
+	var mined Block
+	assert.Nil(t, json.Unmarshal([]byte(body), &mined))
+	assert.Equal(t, uint32(1), mined.Number)
+	assert.Equal(t, ctl.best.Header().ID(), mined.ID)
+	assert.Empty(t, mined.Txs)
+
+	code, body = post("/admin/solo/snapshots", SnapshotRequest{Name: "s1"})
+	assert.Equal(t, http.StatusOK, code)
+	var snap Snapshot
+	assert.Nil(t, json.Unmarshal([]byte(body), &snap))
+	assert.Equal(t, "s1", snap.Name)
+	assert.Equal(t, mined.ID, snap.Block.ID)
+
+	code, body = post("/admin/solo/time", TimeRequest{Seconds: 100})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"offset":100}`, body)
+
+	code, body = post("/admin/solo/mine/empty", MineEmptyRequest{Count: 3})
+	assert.Equal(t, http.StatusOK, code)
+	var blocks []*Block
+	assert.Nil(t, json.Unmarshal([]byte(body), &blocks))
+	assert.Len(t, blocks, 3)
+	assert.Equal(t, uint32(4), blocks[2].Number)
+
+	code, body = post("/admin/solo/snapshots/s1/revert", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Nil(t, json.Unmarshal([]byte(body), &snap))
+	assert.Equal(t, mined.ID, snap.Block.ID)
+	assert.Equal(t, mined.ID, ctl.best.Header().ID())
+
+	// bad requests
+	code, body = post("/admin/solo/snapshots/unknown/revert", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "snapshot not found", body)
+
+	code, body = post("/admin/solo/mine/empty", MineEmptyRequest{Count: 0})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "count: should be in range [1, 1000]", body)
+
+	code, _ = post("/admin/solo/mine/empty", map[string]any{"blocks": 1})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body = post("/admin/solo/snapshots", SnapshotRequest{})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "name: empty", body)
+}
+
+func TestCheats(t *testing.T) {
+	ctl := newFakeController()
+	post := newTestRouter(ctl)
+
+	addr := thor.MustParseAddress("0x7567d83b7b8d80addcb281a71d54fc7b3364ffed")
+	code, body := post("/admin/solo/accounts/"+addr.String(), map[string]any{
+		"balance": "0x64",
+		"energy":  "1000",
+		"code":    "0x6001",
+		"storage": map[string]string{
+			"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002",
+		},
+	})
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, big.NewInt(100), ctl.balances[addr])
+	assert.Equal(t, big.NewInt(1000), ctl.energies[addr])
+	assert.Equal(t, []byte{0x60, 0x01}, ctl.codes[addr])
+	assert.Equal(t, thor.BytesToBytes32([]byte{2}), ctl.storage[thor.BytesToBytes32([]byte{1})])
+
+	// only given fields are set
+	other := thor.BytesToAddress([]byte("other"))
+	code, _ = post("/admin/solo/accounts/"+other.String(), map[string]any{"balance": "1"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, big.NewInt(1), ctl.balances[other])
+	assert.NotContains(t, ctl.energies, other)
+	assert.NotContains(t, ctl.codes, other)
+
+	code, _ = post("/admin/solo/accounts/0xinvalid", map[string]any{"balance": "1"})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, body = post("/admin/solo/accounts/"+addr.String(), map[string]any{"balance": "-1"})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "balance: negative", body)
+	code, _ = post("/admin/solo/accounts/"+addr.String(), map[string]any{"storage": map[string]string{"0x01": "0x00"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// impersonate
+	to := thor.BytesToAddress([]byte("to"))
+	code, body = post("/admin/solo/impersonate", map[string]any{
+		"origin":  addr.String(),
+		"clauses": []map[string]any{{"to": to.String(), "value": "10"}, {"to": to.String(), "value": "20"}},
+		"gas":     10000,
+	})
+	assert.Equal(t, http.StatusOK, code, body)
+	var results accounts.BatchCallResults
+	assert.Nil(t, json.Unmarshal([]byte(body), &results))
+	assert.Len(t, results, 2)
+	for _, res := range results {
+		assert.Equal(t, hexutil.Encode(addr.Bytes()), res.Data)
+		assert.Equal(t, uint64(1000), res.GasUsed)
+		assert.Equal(t, addr, res.Transfers[0].Sender)
+		assert.Equal(t, to, res.Transfers[0].Recipient)
+	}
+
+	code, body = post("/admin/solo/impersonate", map[string]any{"clauses": []any{}})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "origin: empty", body)
+}
+
+func TestNotSolo(t *testing.T) {
+	post := newTestRouter(nil)
+
+	for _, path := range []string{
+		"/admin/solo/mine",
+		"/admin/solo/accounts/0x7567d83b7b8d80addcb281a71d54fc7b3364ffed",
+		"/admin/solo/impersonate",
+	} {
+		code, body := post(path, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Equal(t, "only available in solo mode", body)
+	}
+}
+
+func TestTraces(t *testing.T) {
+	ctl := newFakeController()
+	router := mux.NewRouter()
+	New(ctl).Mount(router, "/admin/solo")
+	get := func(path string) (int, string) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Code, strings.TrimSpace(rr.Body.String())
+	}
+
+	code, body := get("/admin/solo/traces")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "[]", body)
+
+	ctl.traces = []json.RawMessage{[]byte(`{"number":1}`), []byte(`{"number":2}`)}
+	code, body = get("/admin/solo/traces")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `[{"number":1},{"number":2}]`, body)
+
+	code, body = get("/admin/solo/traces?blocks=1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `[{"number":2}]`, body)
+
+	code, body = get("/admin/solo/traces?blocks=0")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "blocks: should be a positive integer", body)
+}
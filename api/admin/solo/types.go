@@ -0,0 +1,77 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+)
+
+type Block struct {
+	ID        thor.Bytes32   `json:"id"`
+	Number    uint32         `json:"number"`
+	Timestamp uint64         `json:"timestamp"`
+	Txs       []thor.Bytes32 `json:"txs"`
+}
+
+type MineEmptyRequest struct {
+	Count int `json:"count"`
+}
+
+type TimeRequest struct {
+	Seconds uint64 `json:"seconds"`
+}
+
+type TimeResponse struct {
+	Offset uint64 `json:"offset"`
+}
+
+type SnapshotRequest struct {
+	Name string `json:"name"`
+}
+
+type Snapshot struct {
+	Name  string `json:"name"`
+	Block *Block `json:"block"`
+}
+
+// AccountRequest sets fields of an account, nil fields are left unchanged.
+type AccountRequest struct {
+	Balance *math.HexOrDecimal256   `json:"balance"`
+	Energy  *math.HexOrDecimal256   `json:"energy"`
+	Code    *hexutil.Bytes          `json:"code"`
+	Storage map[string]thor.Bytes32 `json:"storage"`
+}
+
+type ImpersonateRequest struct {
+	Origin  *thor.Address    `json:"origin"`
+	Clauses accounts.Clauses `json:"clauses"`
+	Gas     uint64           `json:"gas"`
+}
+
+func newBlock(header *block.Header, txs []thor.Bytes32) *Block {
+	if txs == nil {
+		txs = []thor.Bytes32{}
+	}
+	return &Block{
+		ID:        header.ID(),
+		Number:    header.Number(),
+		Timestamp: header.Timestamp(),
+		Txs:       txs,
+	}
+}
+
+func convertBlock(b *block.Block) *Block {
+	txs := b.Transactions()
+	ids := make([]thor.Bytes32, 0, len(txs))
+	for _, tx := range txs {
+		ids = append(ids, tx.ID())
+	}
+	return newBlock(b.Header(), ids)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api/admin"
 	"github.com/vechain/thor/v2/api/admin/health"
+	"github.com/vechain/thor/v2/api/admin/solo"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm"
@@ -25,14 +26,16 @@ func StartAdminServer(
 	logLevel *slog.LevelVar,
 	repo *chain.Repository,
 	p2p *comm.Communicator,
+	blockInterval uint64,
 	apiLogs *atomic.Bool,
+	soloCtl solo.Controller,
 ) (string, func(), error) {
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return "", nil, errors.Wrapf(err, "listen admin API addr [%v]", addr)
 	}
 
-	adminHandler := admin.New(logLevel, health.New(repo, p2p), apiLogs)
+	adminHandler := admin.New(logLevel, health.New(repo, p2p, blockInterval), apiLogs, soloCtl)
 
 	srv := &http.Server{Handler: adminHandler, ReadHeaderTimeout: time.Second, ReadTimeout: 5 * time.Second}
 	var goes co.Goes
@@ -22,30 +22,34 @@ import (
 	"github.com/vechain/thor/v2/api/subscriptions"
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/api/transfers"
+	"github.com/vechain/thor/v2/api/utils"
 	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tracers/js"
 	"github.com/vechain/thor/v2/txpool"
 )
 
 var logger = log.WithContext("pkg", "api")
 
 type Config struct {
-	AllowedOrigins    string
-	BacktraceLimit    uint32
-	CallGasLimit      uint64
-	PprofOn           bool
-	SkipLogs          bool
-	AllowCustomTracer bool
-	EnableReqLogger   *atomic.Bool
-	EnableMetrics     bool
-	LogsLimit         uint64
-	AllowedTracers    []string
-	SoloMode          bool
-	EnableDeprecated  bool
+	AllowedOrigins     string
+	BacktraceLimit     uint32
+	CallGasLimit       uint64
+	PprofOn            bool
+	SkipLogs           bool
+	AllowCustomTracer  bool
+	CustomTracerLimits js.Limits
+	EnableReqLogger    *atomic.Bool
+	EnableMetrics      bool
+	LogsLimit          uint64
+	AllowedTracers     []string
+	SoloMode           bool
+	EnableDeprecated   bool
+	StateHistory       utils.StateHistory // nil if historical states are never pruned
 }
 
 // New return api router
@@ -77,7 +81,7 @@ func New(
 			http.Redirect(w, req, "doc/stoplight-ui/", http.StatusTemporaryRedirect)
 		})
 
-	accounts.New(repo, stater, config.CallGasLimit, forkConfig, bft, config.EnableDeprecated).
+	accounts.New(repo, stater, config.CallGasLimit, forkConfig, bft, config.EnableDeprecated, config.StateHistory).
 		Mount(router, "/accounts")
 
 	if !config.SkipLogs {
@@ -90,11 +94,11 @@ func New(
 		Mount(router, "/blocks")
 	transactions.New(repo, txPool).
 		Mount(router, "/transactions")
-	debug.New(repo, stater, forkConfig, config.CallGasLimit, config.AllowCustomTracer, bft, config.AllowedTracers, config.SoloMode).
+	debug.New(repo, stater, forkConfig, config.CallGasLimit, config.AllowCustomTracer, config.CustomTracerLimits, bft, config.AllowedTracers, config.SoloMode, config.StateHistory).
 		Mount(router, "/debug")
 	node.New(nw).
 		Mount(router, "/node")
-	subs := subscriptions.New(repo, origins, config.BacktraceLimit, txPool, config.EnableDeprecated)
+	subs := subscriptions.New(repo, origins, config.BacktraceLimit, txPool, forkConfig.BlockInterval(), config.EnableDeprecated)
 	subs.Mount(router, "/subscriptions")
 
 	if config.PprofOn {
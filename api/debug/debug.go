@@ -29,6 +29,8 @@ import (
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tracers"
+	"github.com/vechain/thor/v2/tracers/js"
+	"github.com/vechain/thor/v2/tracers/native"
 	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/vm"
@@ -43,9 +45,12 @@ type Debug struct {
 	forkConfig        thor.ForkConfig
 	callGasLimit      uint64
 	allowCustomTracer bool
+	tracerLimits      js.Limits
 	bft               bft.Committer
 	allowedTracers    map[string]struct{}
 	skipPoA           bool
+	impersonation     bool
+	stateHistory      utils.StateHistory
 }
 
 func New(
@@ -54,9 +59,11 @@ func New(
 	forkConfig thor.ForkConfig,
 	callGaslimit uint64,
 	allowCustomTracer bool,
+	tracerLimits js.Limits,
 	bft bft.Committer,
 	allowedTracers []string,
 	soloMode bool,
+	stateHistory utils.StateHistory,
 ) *Debug {
 	allowedMap := make(map[string]struct{})
 	for _, t := range allowedTracers {
@@ -69,19 +76,32 @@ func New(
 		forkConfig,
 		callGaslimit,
 		allowCustomTracer,
+		tracerLimits,
 		bft,
 		allowedMap,
 		soloMode,
+		soloMode,
+		stateHistory,
 	}
 }
 
-// prepareClauseEnv prepares the runtime environment for the specified clause.
-func (d *Debug) prepareClauseEnv(ctx context.Context, block *block.Block, txID thor.Bytes32, clauseIndex uint32) (*runtime.Runtime, *runtime.TransactionExecutor, thor.Bytes32, error) {
+// newRuntimeForReplay creates the runtime to replay the block on its parent state.
+// Blocks packed by solo might contain impersonated txs.
+func (d *Debug) newRuntimeForReplay(header *block.Header) (*runtime.Runtime, error) {
 	rt, err := consensus.New(
 		d.repo,
 		d.stater,
 		d.forkConfig,
-	).NewRuntimeForReplay(block.Header(), d.skipPoA)
+	).NewRuntimeForReplay(header, d.skipPoA)
+	if err != nil {
+		return nil, err
+	}
+	return rt.SetImpersonation(d.impersonation), nil
+}
+
+// prepareClauseEnv prepares the runtime environment for the specified clause.
+func (d *Debug) prepareClauseEnv(ctx context.Context, block *block.Block, txID thor.Bytes32, clauseIndex uint32) (*runtime.Runtime, *runtime.TransactionExecutor, thor.Bytes32, error) {
+	rt, err := d.newRuntimeForReplay(block.Header())
 	if err != nil {
 		return nil, nil, thor.Bytes32{}, err
 	}
@@ -134,6 +154,7 @@ func (d *Debug) prepareClauseEnv(ctx context.Context, block *block.Block, txID t
 func (d *Debug) traceClause(ctx context.Context, tracer tracers.Tracer, block *block.Block, txID thor.Bytes32, clauseIndex uint32) (any, error) {
 	rt, txExec, txID, err := d.prepareClauseEnv(ctx, block, txID, clauseIndex)
 	if err != nil {
+		js.Release(tracer)
 		return nil, err
 	}
 
@@ -168,6 +189,7 @@ func (d *Debug) traceClause(ctx context.Context, tracer tracers.Tracer, block *b
 		return nil, err
 	case err := <-errCh:
 		if err != nil {
+			js.Release(tracer)
 			return nil, err
 		}
 	}
@@ -187,11 +209,13 @@ func (d *Debug) handleTraceClause(w http.ResponseWriter, req *http.Request) erro
 
 	block, txID, clauseIndex, err := d.parseTarget(opt.Target)
 	if err != nil {
+		// stop the watchdog of custom tracers
+		js.Release(tracer)
 		return err
 	}
 	res, err := d.traceClause(req.Context(), tracer, block, txID, clauseIndex)
 	if err != nil {
-		return err
+		return wrapTracerError(err)
 	}
 	return utils.WriteJSON(w, res)
 }
@@ -205,27 +229,30 @@ func (d *Debug) handleTraceCall(w http.ResponseWriter, req *http.Request) error
 	if err != nil {
 		return utils.BadRequest(errors.WithMessage(err, "revision"))
 	}
-	summary, st, err := utils.GetSummaryAndState(revision, d.repo, d.bft, d.stater)
+	summary, st, err := utils.GetSummaryAndState(revision, d.repo, d.bft, d.stater, d.forkConfig)
 	if err != nil {
 		if d.repo.IsNotFound(err) {
 			return utils.BadRequest(errors.WithMessage(err, "revision"))
 		}
 		return err
 	}
-
-	tracer, err := d.createTracer(opt.Name, opt.Config)
-	if err != nil {
-		return utils.Forbidden(err)
+	if err := utils.CheckStateHistory(summary.Header.Number(), d.stateHistory); err != nil {
+		return err
 	}
 
 	txCtx, gas, clause, err := d.handleTraceCallOption(&opt)
 	if err != nil {
 		return err
 	}
+	// the tracer is created after validation, custom tracers run a watchdog until stopped
+	tracer, err := d.createTracer(opt.Name, opt.Config)
+	if err != nil {
+		return utils.Forbidden(err)
+	}
 
 	res, err := d.traceCall(req.Context(), tracer, summary.Header, st, txCtx, gas, clause)
 	if err != nil {
-		return err
+		return wrapTracerError(err)
 	}
 
 	return utils.WriteJSON(w, res)
@@ -240,21 +267,50 @@ func (d *Debug) createTracer(name string, config json.RawMessage) (tracers.Trace
 
 	// if it's builtin tracers
 	if tracers.DefaultDirectory.Lookup(tracerName) {
-		_, allowAll := d.allowedTracers["all"]
-		// fail if the requested tracer is not allowed OR "all" not set
-		if _, allowed := d.allowedTracers[tracerName]; !allowAll && !allowed {
-			return nil, fmt.Errorf("creating tracer is not allowed: %s", name)
-		}
-		return tracers.DefaultDirectory.New(tracerName, config, false)
+		return d.createBuiltinTracer(tracerName, config)
 	}
 
 	if d.allowCustomTracer {
-		return tracers.DefaultDirectory.New(tracerName, config, true)
+		tracer, err := js.NewTracer(tracerName, config, d.tracerLimits)
+		if err != nil {
+			if _, ok := js.AsLimitError(err); ok {
+				return nil, err
+			}
+			return nil, errors.Wrap(err, "unable to create custom tracer")
+		}
+		return tracer, nil
 	}
 
 	return nil, errors.New("tracer is not defined")
 }
 
+// createBuiltinTracer creates the builtin tracer if allowed.
+// Sub-tracers of the mux tracer must be builtin and allowed as well.
+func (d *Debug) createBuiltinTracer(name string, config json.RawMessage) (tracers.Tracer, error) {
+	_, allowAll := d.allowedTracers["all"]
+	// fail if the requested tracer is not allowed OR "all" not set
+	if _, allowed := d.allowedTracers[name]; !allowAll && !allowed {
+		return nil, fmt.Errorf("creating tracer is not allowed: %s", name)
+	}
+	if strings.TrimSuffix(name, "Tracer") == "mux" {
+		return native.NewMuxTracer(config, func(name string, cfg json.RawMessage) (tracers.Tracer, error) {
+			if !tracers.DefaultDirectory.Lookup(name) {
+				return nil, errors.New("tracer is not defined")
+			}
+			return d.createBuiltinTracer(name, cfg)
+		})
+	}
+	return tracers.DefaultDirectory.New(name, config, false)
+}
+
+// wrapTracerError turns the error of a tracer aborted by its limits into a forbidden error.
+func wrapTracerError(err error) error {
+	if _, ok := js.AsLimitError(err); ok {
+		return utils.Forbidden(err)
+	}
+	return err
+}
+
 func (d *Debug) traceCall(ctx context.Context, tracer tracers.Tracer, header *block.Header, st *state.State, txCtx *xenv.TransactionContext, gas uint64, clause *tx.Clause) (any, error) {
 	signer, _ := header.Signer()
 
@@ -292,6 +348,7 @@ func (d *Debug) traceCall(ctx context.Context, tracer tracers.Tracer, header *bl
 		return nil, err
 	case err := <-errCh:
 		if err != nil {
+			js.Release(tracer)
 			return nil, err
 		}
 	}
@@ -364,6 +421,119 @@ func (d *Debug) handleDebugStorage(w http.ResponseWriter, req *http.Request) err
 	return utils.WriteJSON(w, res)
 }
 
+// stateDiff replays the block and collects the state changes made by each transaction.
+// If txID is not zero, only the diff of the specified transaction is returned.
+func (d *Debug) stateDiff(ctx context.Context, blk *block.Block, txID thor.Bytes32) ([]*TxStateDiff, error) {
+	header := blk.Header()
+	rt, err := d.newRuntimeForReplay(header)
+	if err != nil {
+		return nil, d.wrapStateError(err, header)
+	}
+
+	st := rt.State()
+	// touch the parent state to detect pruned history before replaying
+	if _, err := st.Exists(header.Beneficiary()); err != nil {
+		return nil, d.wrapStateError(err, header)
+	}
+
+	var (
+		diffs   = make([]*TxStateDiff, 0, len(blk.Transactions()))
+		tracker *state.ChangeTracker
+	)
+	for i, tx := range blk.Transactions() {
+		target := txID.IsZero() || tx.ID() == txID
+		if target && tracker == nil {
+			tracker = st.NewChangeTracker()
+		}
+		if _, err := rt.ExecuteTransaction(tx); err != nil {
+			return nil, d.wrapStateError(err, header)
+		}
+		if target {
+			changes, err := tracker.Changes(ctx, header.Timestamp())
+			if err != nil {
+				return nil, d.wrapStateError(err, header)
+			}
+			diffs = append(diffs, newTxStateDiff(tx.ID(), uint64(i), changes))
+			if tx.ID() == txID {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+	return diffs, nil
+}
+
+// wrapStateError converts the error of missing trie node into a readable error.
+// The block is replayed on the parent state, and the genesis block on its own state.
+func (d *Debug) wrapStateError(err error, header *block.Header) error {
+	num := header.Number()
+	if num > 0 {
+		num--
+	}
+	return utils.WrapStateError(err, num, d.stateHistory)
+}
+
+func (d *Debug) handleStateDiff(w http.ResponseWriter, req *http.Request) error {
+	var opt StateDiffOption
+	if err := utils.ParseJSON(req.Body, &opt); err != nil {
+		return utils.BadRequest(errors.WithMessage(err, "body"))
+	}
+
+	blk, txID, err := d.parseStateDiffTarget(opt.Target)
+	if err != nil {
+		return err
+	}
+	res, err := d.stateDiff(req.Context(), blk, txID)
+	if err != nil {
+		return err
+	}
+	return utils.WriteJSON(w, res)
+}
+
+func (d *Debug) parseStateDiffTarget(target string) (*block.Block, thor.Bytes32, error) {
+	// target can be `${blockID}`, `${txID}` or `${blockID}/${txID|txIndex}`
+	parts := strings.Split(target, "/")
+	if len(parts) == 2 {
+		// reuse parser of clause target
+		blk, txID, _, err := d.parseTarget(target + "/0")
+		return blk, txID, err
+	}
+	if len(parts) != 1 {
+		return nil, thor.Bytes32{}, utils.BadRequest(errors.New("target:" + target + " unsupported"))
+	}
+
+	id, err := thor.ParseBytes32(parts[0])
+	if err != nil {
+		return nil, thor.Bytes32{}, utils.BadRequest(errors.WithMessage(err, "target"))
+	}
+	// try as block ID first
+	blk, err := d.repo.GetBlock(id)
+	if err == nil {
+		return blk, thor.Bytes32{}, nil
+	}
+	if !d.repo.IsNotFound(err) {
+		return nil, thor.Bytes32{}, err
+	}
+
+	bestChain := d.repo.NewBestChain()
+	txMeta, err := bestChain.GetTransactionMeta(id)
+	if err != nil {
+		if d.repo.IsNotFound(err) {
+			return nil, thor.Bytes32{}, utils.Forbidden(errors.New("block or transaction not found"))
+		}
+		return nil, thor.Bytes32{}, err
+	}
+	blk, err = bestChain.GetBlock(txMeta.BlockNum)
+	if err != nil {
+		return nil, thor.Bytes32{}, err
+	}
+	return blk, id, nil
+}
+
 func (d *Debug) parseTarget(target string) (block *block.Block, txID thor.Bytes32, clauseIndex uint32, err error) {
 	// target can be `${blockID}/${txID|txIndex}/${clauseIndex}` or `${txID}/${clauseIndex}`
 	parts := strings.Split(target, "/")
@@ -514,4 +684,8 @@ func (d *Debug) Mount(root *mux.Router, pathPrefix string) {
 		Methods(http.MethodPost).
 		Name("POST /debug/storage-range").
 		HandlerFunc(utils.WrapHandlerFunc(d.handleDebugStorage))
+	sub.Path("/state-diff").
+		Methods(http.MethodPost).
+		Name("POST /debug/state-diff").
+		HandlerFunc(utils.WrapHandlerFunc(d.handleStateDiff))
 }
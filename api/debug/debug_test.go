@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -16,26 +17,32 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/metrics"
 	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/test/datagen"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tracers/js"
 	"github.com/vechain/thor/v2/tracers/logger"
 	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 
 	// Force-load the tracer native engines to trigger registration
-	_ "github.com/vechain/thor/v2/tracers/js"
 	_ "github.com/vechain/thor/v2/tracers/native"
 )
 
+func init() {
+	metrics.InitializePrometheusMetrics()
+}
+
 var (
 	ts          *httptest.Server
 	blk         *block.Block
@@ -61,6 +68,7 @@ func TestDebug(t *testing.T) {
 		"testTraceClauseWithTxIndexOutOfBound":     testTraceClauseWithTxIndexOutOfBound,
 		"testTraceClauseWithClauseIndexOutOfBound": testTraceClauseWithClauseIndexOutOfBound,
 		"testTraceClauseWithCustomTracer":          testTraceClauseWithCustomTracer,
+		"testTraceClauseWithCustomTracerBadTarget": testTraceClauseWithCustomTracerBadTarget,
 		"testTraceClause":                          testTraceClause,
 		"testTraceClauseWithoutBlockID":            testTraceClauseWithoutBlockID,
 	} {
@@ -80,6 +88,7 @@ func TestDebug(t *testing.T) {
 		"testHandleTraceCallWithBadBlockRef":                 testHandleTraceCallWithBadBlockRef,
 		"testHandleTraceCallWithInvalidLengthBlockRef":       testHandleTraceCallWithInvalidLengthBlockRef,
 		"testTraceCallNextBlock":                             testTraceCallNextBlock,
+		"testHandleTraceCallWithTracerLimits":                testHandleTraceCallWithTracerLimits,
 	} {
 		t.Run(name, tt)
 	}
@@ -92,6 +101,17 @@ func TestDebug(t *testing.T) {
 	} {
 		t.Run(name, tt)
 	}
+
+	// /state-diff endpoint
+	for name, tt := range map[string]func(*testing.T){
+		"testStateDiffWithBadTarget":      testStateDiffWithBadTarget,
+		"testStateDiffWithNonExistingID":  testStateDiffWithNonExistingID,
+		"testStateDiffOfBlock":            testStateDiffOfBlock,
+		"testStateDiffOfTransaction":      testStateDiffOfTransaction,
+		"testStateDiffOfTransactionIndex": testStateDiffOfTransactionIndex,
+	} {
+		t.Run(name, tt)
+	}
 }
 
 func TestStorageRangeFunc(t *testing.T) {
@@ -246,6 +266,40 @@ func testTraceClauseWithCustomTracer(t *testing.T) {
 	assert.Equal(t, expectedExecutionResult, parsedExecutionRes)
 }
 
+func testTraceClauseWithCustomTracerBadTarget(t *testing.T) {
+	before := tracerAbortCount(t, "interrupted")
+
+	traceClauseOption := &TraceClauseOption{
+		Name:   "{result: function() { return 1; }, fault: function() {}}",
+		Target: "badBlockId/x/x",
+	}
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers", traceClauseOption, 400)
+	assert.Equal(t, "target[0]: invalid length", strings.TrimSpace(res))
+
+	// the tracer is released without being interrupted
+	assert.Equal(t, before, tracerAbortCount(t, "interrupted"))
+}
+
+// tracerAbortCount returns the count of custom tracers aborted for the given reason.
+func tracerAbortCount(t *testing.T, reason string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, mf := range families {
+		if mf.GetName() != "thor_metrics_tracer_js_abort_count" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "reason" && label.GetValue() == reason {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
 func testTraceClause(t *testing.T) {
 	traceClauseOption := &TraceClauseOption{
 		Name:   "structLogger",
@@ -336,6 +390,20 @@ func testTraceCallNextBlock(t *testing.T) {
 	httpPostAndCheckResponseStatus(t, "/debug/tracers/call?revision=next", traceCallOption, 200)
 }
 
+func testHandleTraceCallWithTracerLimits(t *testing.T) {
+	debug.tracerLimits = js.Limits{MaxOutputSize: 10}
+	defer func() { debug.tracerLimits = js.Limits{} }()
+
+	addr := datagen.RandAddress()
+	traceCallOption := &TraceCallOption{
+		Name: "{result: function() { return 'x'.repeat(100); }, fault: function() {}}",
+		To:   &addr,
+		Gas:  21000,
+	}
+	res := httpPostAndCheckResponseStatus(t, "/debug/tracers/call", traceCallOption, 403)
+	assert.Equal(t, "tracer aborted: output limit exceeded (10 bytes)", strings.TrimSpace(res))
+}
+
 func testHandleTraceCall(t *testing.T) {
 	addr := datagen.RandAddress()
 	provedWork := math.HexOrDecimal256(*big.NewInt(1000))
@@ -532,6 +600,69 @@ func testStorageRangeDefaultOption(t *testing.T) {
 	assert.NotZero(t, len(storageRangeRes.Storage))
 }
 
+func testStateDiffWithBadTarget(t *testing.T) {
+	httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: "0x01"}, 400)
+	httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: "a/b/c"}, 400)
+	httpPostAndCheckResponseStatus(t, "/debug/state-diff", "badBody", 400)
+}
+
+func testStateDiffWithNonExistingID(t *testing.T) {
+	res := httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: datagen.RandomHash().String()}, 403)
+	assert.Equal(t, "block or transaction not found", strings.TrimSpace(res))
+}
+
+func testStateDiffOfBlock(t *testing.T) {
+	res := httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: blk.Header().ID().String()}, 200)
+
+	var diffs []*TxStateDiff
+	if err := json.Unmarshal([]byte(res), &diffs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(blk.Transactions()), len(diffs))
+	for i, diff := range diffs {
+		assert.Equal(t, blk.Transactions()[i].ID(), diff.TxID)
+		assert.Equal(t, uint64(i), diff.TxIndex)
+		// gas payer and the beneficiary are always changed
+		assert.Contains(t, diff.Post, genesis.DevAccounts()[0].Address.String())
+		assert.Contains(t, diff.Post, blk.Header().Beneficiary().String())
+	}
+}
+
+func testStateDiffOfTransaction(t *testing.T) {
+	res := httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: transaction.ID().String()}, 200)
+
+	var diffs []*TxStateDiff
+	if err := json.Unmarshal([]byte(res), &diffs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(diffs))
+	assert.Equal(t, transaction.ID(), diffs[0].TxID)
+
+	to := thor.BytesToAddress([]byte("to")).String()
+	assert.Equal(t, 0, (*big.Int)(diffs[0].Pre[to].Balance).Sign())
+	assert.Equal(t, big.NewInt(20000), (*big.Int)(diffs[0].Post[to].Balance))
+
+	sender := genesis.DevAccounts()[0].Address.String()
+	preBalance := (*big.Int)(diffs[0].Pre[sender].Balance)
+	postBalance := (*big.Int)(diffs[0].Post[sender].Balance)
+	assert.Equal(t, big.NewInt(20000), new(big.Int).Sub(preBalance, postBalance))
+	assert.Equal(t, 1, (*big.Int)(diffs[0].Pre[sender].Energy).Cmp((*big.Int)(diffs[0].Post[sender].Energy)))
+}
+
+func testStateDiffOfTransactionIndex(t *testing.T) {
+	target := fmt.Sprintf("%s/%d", blk.Header().ID(), 0)
+	res := httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: target}, 200)
+
+	var diffs []*TxStateDiff
+	if err := json.Unmarshal([]byte(res), &diffs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(diffs))
+	assert.Equal(t, blk.Transactions()[0].ID(), diffs[0].TxID)
+
+	httpPostAndCheckResponseStatus(t, "/debug/state-diff", &StateDiffOption{Target: fmt.Sprintf("%s/%d", blk.Header().ID(), 5)}, 403)
+}
+
 func initDebugServer(t *testing.T) {
 	thorChain, err := testchain.NewIntegrationTestChain()
 	require.NoError(t, err)
@@ -570,7 +701,7 @@ func initDebugServer(t *testing.T) {
 
 	forkConfig := thor.GetForkConfig(blk.Header().ID())
 	router := mux.NewRouter()
-	debug = New(thorChain.Repo(), thorChain.Stater(), forkConfig, 21000, true, thorChain.Engine(), []string{"all"}, false)
+	debug = New(thorChain.Repo(), thorChain.Stater(), forkConfig, 21000, true, js.Limits{}, thorChain.Engine(), []string{"all"}, false, nil)
 	debug.Mount(router, "/debug")
 	ts = httptest.NewServer(router)
 }
@@ -606,4 +737,46 @@ func TestCreateTracer(t *testing.T) {
 	debug.allowCustomTracer = true
 	_, err = debug.createTracer("{result:()=>{}, fault:()=>{}}", nil)
 	assert.Nil(t, err)
+
+	// sub-tracers of the mux tracer
+	debug.allowedTracers = map[string]struct{}{"muxTracer": {}, "callTracer": {}}
+	_, err = debug.createTracer("muxTracer", json.RawMessage(`{"callTracer":{}}`))
+	assert.Nil(t, err)
+	_, err = debug.createTracer("muxTracer", json.RawMessage(`{"callTracer":{},"prestateTracer":{}}`))
+	assert.ErrorContains(t, err, "creating tracer is not allowed: prestateTracer")
+	_, err = debug.createTracer("muxTracer", json.RawMessage(`{"{result:()=>{}, fault:()=>{}}":{}}`))
+	assert.ErrorContains(t, err, "tracer is not defined")
+}
+
+type oldestState uint32
+
+func (o oldestState) OldestState() uint32 { return uint32(o) }
+
+func TestStateDiffOfPrunedHistory(t *testing.T) {
+	thorChain, err := testchain.NewIntegrationTestChain()
+	require.NoError(t, err)
+	require.NoError(t, thorChain.MintTransactions(genesis.DevAccounts()[0]))
+	best := thorChain.Repo().BestBlockSummary().Header
+
+	// states are missing in the empty database, as if pruned
+	router := mux.NewRouter()
+	New(
+		thorChain.Repo(),
+		state.NewStater(muxdb.NewMem()),
+		thor.NoFork,
+		21000,
+		false,
+		js.Limits{},
+		thorChain.Engine(),
+		[]string{"all"},
+		false,
+		oldestState(best.Number()+1),
+	).Mount(router, "/debug")
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	body, status, err := thorclient.New(server.URL).RawHTTPClient().RawHTTPPost("/debug/state-diff", &StateDiffOption{Target: best.ID().String()})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Contains(t, string(body), "is pruned")
 }
@@ -6,9 +6,13 @@
 package debug
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -51,3 +55,85 @@ type StorageEntry struct {
 	Key   *thor.Bytes32 `json:"key"`
 	Value *thor.Bytes32 `json:"value"`
 }
+
+type StateDiffOption struct {
+	Target string `json:"target"`
+}
+
+type AccountState struct {
+	Balance        *math.HexOrDecimal256   `json:"balance,omitempty"`
+	Energy         *math.HexOrDecimal256   `json:"energy,omitempty"`
+	Code           hexutil.Bytes           `json:"code,omitempty"`
+	Storage        map[string]thor.Bytes32 `json:"storage,omitempty"`
+	StorageCleared string                  `json:"storageCleared,omitempty"`
+}
+
+type TxStateDiff struct {
+	TxID    thor.Bytes32            `json:"txID"`
+	TxIndex uint64                  `json:"txIndex"`
+	Pre     map[string]AccountState `json:"pre"`
+	Post    map[string]AccountState `json:"post"`
+}
+
+// newTxStateDiff converts the state changes into the prestate tracer's diff
+// format, the post state omits unchanged fields and untouched accounts are
+// omitted from both.
+func newTxStateDiff(txID thor.Bytes32, txIndex uint64, changes map[thor.Address]*state.AccountChange) *TxStateDiff {
+	diff := &TxStateDiff{
+		TxID:    txID,
+		TxIndex: txIndex,
+		Pre:     make(map[string]AccountState),
+		Post:    make(map[string]AccountState),
+	}
+	for addr, c := range changes {
+		var (
+			pre      AccountState
+			post     AccountState
+			modified bool
+		)
+		pre.Balance = (*math.HexOrDecimal256)(c.Pre.Balance)
+		pre.Energy = (*math.HexOrDecimal256)(c.Pre.Energy)
+		pre.Code = c.Pre.Code
+
+		if c.Pre.Balance.Cmp(c.Post.Balance) != 0 {
+			modified = true
+			post.Balance = (*math.HexOrDecimal256)(c.Post.Balance)
+		}
+		if c.Pre.Energy.Cmp(c.Post.Energy) != 0 {
+			modified = true
+			post.Energy = (*math.HexOrDecimal256)(c.Post.Energy)
+		}
+		if !bytes.Equal(c.Pre.Code, c.Post.Code) {
+			modified = true
+			post.Code = c.Post.Code
+		}
+		for key, val := range c.Post.Storage {
+			// don't include the empty slot
+			if val == c.Pre.Storage[key] {
+				continue
+			}
+			modified = true
+			if post.Storage == nil {
+				post.Storage = make(map[string]thor.Bytes32)
+				pre.Storage = make(map[string]thor.Bytes32)
+			}
+			if !val.IsZero() {
+				post.Storage[key.String()] = val
+			}
+			if preVal := c.Pre.Storage[key]; !preVal.IsZero() {
+				pre.Storage[key.String()] = preVal
+			}
+		}
+		// too many slots cleared to be listed
+		if c.StorageCleared {
+			modified = true
+			post.StorageCleared = fmt.Sprintf("%d+ keys", state.MaxClearedKeys)
+		}
+		if !modified {
+			continue
+		}
+		diff.Pre[addr.String()] = pre
+		diff.Post[addr.String()] = post
+	}
+	return diff
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/vechain/thor/v2/api/node"
 	"github.com/vechain/thor/v2/comm"
 	"github.com/vechain/thor/v2/test/testchain"
+	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
 	"github.com/vechain/thor/v2/txpool"
 )
@@ -41,6 +42,9 @@ func initCommServer(t *testing.T) {
 			LimitPerAccount: 16,
 			MaxLifetime:     10 * time.Minute,
 		}),
+		thorChain.Database(),
+		thorChain.Engine(),
+		thor.BlockInterval,
 	)
 
 	router := mux.NewRouter()
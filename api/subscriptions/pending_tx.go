@@ -9,21 +9,22 @@ import (
 	"time"
 
 	"github.com/hashicorp/golang-lru/simplelru"
-	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 )
 
 type pendingTx struct {
-	txPool    *txpool.TxPool
-	listeners map[chan *tx.Transaction]struct{}
-	mu        sync.Mutex
+	txPool        *txpool.TxPool
+	blockInterval uint64
+	listeners     map[chan *tx.Transaction]struct{}
+	mu            sync.Mutex
 }
 
-func newPendingTx(txPool *txpool.TxPool) *pendingTx {
+func newPendingTx(txPool *txpool.TxPool, blockInterval uint64) *pendingTx {
 	p := &pendingTx{
-		txPool:    txPool,
-		listeners: make(map[chan *tx.Transaction]struct{}),
+		txPool:        txPool,
+		blockInterval: blockInterval,
+		listeners:     make(map[chan *tx.Transaction]struct{}),
 	}
 
 	return p
@@ -58,7 +59,7 @@ func (p *pendingTx) DispatchLoop(done <-chan struct{}) {
 			}
 			now := time.Now().Unix()
 			// ignored if seen within half block interval
-			if seen, ok := knownTx.Get(txEv.Tx.ID()); ok && now-seen.(int64) <= int64(thor.BlockInterval/2) {
+			if seen, ok := knownTx.Get(txEv.Tx.ID()); ok && now-seen.(int64) <= int64(p.blockInterval/2) {
 				continue
 			}
 			knownTx.Add(txEv.Tx.ID(), now)
@@ -37,7 +37,7 @@ func TestPendingTx_Subscribe(t *testing.T) {
 		MaxLifetime:     time.Hour,
 	})
 
-	p := newPendingTx(txPool)
+	p := newPendingTx(txPool, thor.BlockInterval)
 
 	// When initialized, there should be no listeners
 	assert.Empty(t, p.listeners, "There should be no listeners when initialized")
@@ -56,7 +56,7 @@ func TestPendingTx_Unsubscribe(t *testing.T) {
 		LimitPerAccount: 16,
 		MaxLifetime:     time.Hour,
 	})
-	p := newPendingTx(txPool)
+	p := newPendingTx(txPool, thor.BlockInterval)
 
 	ch := make(chan *tx.Transaction)
 	ch2 := make(chan *tx.Transaction)
@@ -81,7 +81,7 @@ func TestPendingTx_DispatchLoop(t *testing.T) {
 		LimitPerAccount: 16,
 		MaxLifetime:     time.Hour,
 	})
-	p := newPendingTx(txPool)
+	p := newPendingTx(txPool, thor.BlockInterval)
 
 	// Add new block to be in a sync state
 	addNewBlock(repo, stater, b0, t)
@@ -171,7 +171,7 @@ func TestPendingTx_NoWriteAfterUnsubscribe(t *testing.T) {
 		MaxLifetime:     time.Hour,
 	})
 
-	p := newPendingTx(txPool)
+	p := newPendingTx(txPool, thor.BlockInterval)
 	txCh := make(chan *tx.Transaction, txQueueSize)
 
 	// Subscribe and then unsubscribe
@@ -203,7 +203,7 @@ func TestPendingTx_UnsubscribeOnWebSocketClose(t *testing.T) {
 	})
 
 	// Subscriptions setup
-	sub := New(thorChain.Repo(), []string{"*"}, 100, txPool, false)
+	sub := New(thorChain.Repo(), []string{"*"}, 100, txPool, thor.BlockInterval, false)
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		utils.WrapHandlerFunc(sub.handlePendingTransactions)(w, r)
 	}))
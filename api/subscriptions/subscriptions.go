@@ -52,7 +52,8 @@ const (
 	pingPeriod = (pongWait * 7) / 10
 )
 
-func New(repo *chain.Repository, allowedOrigins []string, backtraceLimit uint32, txpool *txpool.TxPool, enabledDeprecated bool) *Subscriptions {
+// New creates the subscriptions API, the block interval in seconds is used to dedup pending txs.
+func New(repo *chain.Repository, allowedOrigins []string, backtraceLimit uint32, txpool *txpool.TxPool, blockInterval uint64, enabledDeprecated bool) *Subscriptions {
 	sub := &Subscriptions{
 		backtraceLimit:    backtraceLimit,
 		repo:              repo,
@@ -72,7 +73,7 @@ func New(repo *chain.Repository, allowedOrigins []string, backtraceLimit uint32,
 				return false
 			},
 		},
-		pendingTx:  newPendingTx(txpool),
+		pendingTx:  newPendingTx(txpool, blockInterval),
 		done:       make(chan struct{}),
 		beat2Cache: newMessageCache[Beat2Message](backtraceLimit),
 		beatCache:  newMessageCache[BeatMessage](backtraceLimit),
@@ -274,7 +274,7 @@ func initSubscriptionsServer(t *testing.T, enabledDeprecated bool) {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	New(thorChain.Repo(), []string{}, 5, txPool, enabledDeprecated).
+	New(thorChain.Repo(), []string{}, 5, txPool, thor.BlockInterval, enabledDeprecated).
 		Mount(router, "/subscriptions")
 	ts = httptest.NewServer(router)
 }
@@ -330,7 +330,7 @@ func TestSubscriptionsBacktrace(t *testing.T) {
 	require.NoError(t, err)
 
 	router := mux.NewRouter()
-	New(thorChain.Repo(), []string{}, 5, txPool, true).Mount(router, "/subscriptions")
+	New(thorChain.Repo(), []string{}, 5, txPool, thor.BlockInterval, true).Mount(router, "/subscriptions")
 	ts = httptest.NewServer(router)
 
 	defer ts.Close()
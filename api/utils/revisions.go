@@ -7,6 +7,7 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"strconv"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -25,6 +27,10 @@ const (
 	revJustified int64 = -4
 )
 
+// recentStates is the count of the latest blocks whose states are read from tries directly,
+// since their trie nodes are mostly cached, and the history index would add lookups instead.
+const recentStates = 360
+
 type Revision struct {
 	val any
 }
@@ -107,8 +113,8 @@ func GetSummary(rev *Revision, repo *chain.Repository, bft bft.Committer) (sum *
 }
 
 // GetSummaryAndState returns the block summary and state for the given revision,
-// this function supports the "next" revision.
-func GetSummaryAndState(rev *Revision, repo *chain.Repository, bft bft.Committer, stater *state.Stater) (*chain.BlockSummary, *state.State, error) {
+// this function supports the "next" revision, whose timestamp is one block interval of the fork config after the best block.
+func GetSummaryAndState(rev *Revision, repo *chain.Repository, bft bft.Committer, stater *state.Stater, forkConfig thor.ForkConfig) (*chain.BlockSummary, *state.State, error) {
 	if rev.IsNext() {
 		best := repo.BestBlockSummary()
 
@@ -119,7 +125,7 @@ func GetSummaryAndState(rev *Revision, repo *chain.Repository, bft bft.Committer
 		// call to header.Signer(), the error should be ignored.
 		builder := new(block.Builder).
 			ParentID(best.Header.ID()).
-			Timestamp(best.Header.Timestamp() + thor.BlockInterval).
+			Timestamp(best.Header.Timestamp() + forkConfig.BlockInterval()).
 			TotalScore(best.Header.TotalScore()).
 			GasLimit(best.Header.GasLimit()).
 			Beneficiary(best.Header.Beneficiary()).
@@ -151,6 +157,38 @@ func GetSummaryAndState(rev *Revision, repo *chain.Repository, bft bft.Committer
 		return nil, nil, err
 	}
 
-	st := stater.NewState(sum.Root())
+	if best := repo.BestBlockSummary().Header.Number(); sum.Header.Number()+recentStates > best {
+		return sum, stater.NewState(sum.Root()), nil
+	}
+	// the history index, if enabled, is consulted for accounts and storage of older blocks
+	st := stater.NewHistoricalState(sum.Root(), repo.NewChain(sum.Header.ID()).HasVersion)
 	return sum, st, nil
 }
+
+// StateHistory reports the oldest block whose state is available, when historical states are pruned.
+type StateHistory interface {
+	OldestState() uint32
+}
+
+// CheckStateHistory returns a forbidden error if the state of the block is already pruned.
+// The history is nil if states are never pruned.
+func CheckStateHistory(blockNum uint32, history StateHistory) error {
+	if history == nil {
+		return nil
+	}
+	if oldest := history.OldestState(); blockNum < oldest {
+		return Forbidden(fmt.Errorf("state of block #%d is pruned, the oldest available state is at block #%d", blockNum, oldest))
+	}
+	return nil
+}
+
+// WrapStateError converts the error of missing trie node into a readable forbidden error.
+func WrapStateError(err error, blockNum uint32, history StateHistory) error {
+	if !trie.IsMissingNodeError(err) {
+		return err
+	}
+	if err := CheckStateHistory(blockNum, history); err != nil {
+		return err
+	}
+	return Forbidden(fmt.Errorf("state of block #%d is not available, the historical state might be pruned", blockNum))
+}
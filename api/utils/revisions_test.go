@@ -8,6 +8,7 @@ package utils
 import (
 	"fmt"
 	"math"
+	"net/http"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vechain/thor/v2/test/testchain"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -159,15 +161,22 @@ func TestGetSummaryAndState(t *testing.T) {
 
 	b := thorChain.GenesisBlock()
 
-	summary, _, err := GetSummaryAndState(&Revision{revBest}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
+	summary, _, err := GetSummaryAndState(&Revision{revBest}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater(), thor.NoFork)
 	assert.Nil(t, err)
 	assert.Equal(t, summary.Header.Number(), b.Header().Number())
 	assert.Equal(t, summary.Header.Timestamp(), b.Header().Timestamp())
 
-	summary, _, err = GetSummaryAndState(&Revision{revNext}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater())
+	summary, _, err = GetSummaryAndState(&Revision{revNext}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater(), thor.NoFork)
 	assert.Nil(t, err)
 	assert.Equal(t, summary.Header.Number(), b.Header().Number()+1)
 	assert.Equal(t, summary.Header.Timestamp(), b.Header().Timestamp()+thor.BlockInterval)
+
+	// the block interval of the fork config
+	forkConfig := thor.NoFork
+	forkConfig.Timing.BlockInterval = 3
+	summary, _, err = GetSummaryAndState(&Revision{revNext}, thorChain.Repo(), thorChain.Engine(), thorChain.Stater(), forkConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, summary.Header.Timestamp(), b.Header().Timestamp()+3)
 	assert.Equal(t, summary.Header.GasUsed(), uint64(0))
 	assert.Equal(t, summary.Header.ReceiptsRoot(), tx.Receipts{}.RootHash())
 	assert.Equal(t, len(summary.Txs), 0)
@@ -176,3 +185,29 @@ func TestGetSummaryAndState(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.True(t, signer.IsZero())
 }
+
+type oldestState uint32
+
+func (o oldestState) OldestState() uint32 { return uint32(o) }
+
+func TestCheckStateHistory(t *testing.T) {
+	assert.Nil(t, CheckStateHistory(10, nil))
+	assert.Nil(t, CheckStateHistory(10, oldestState(10)))
+
+	err := CheckStateHistory(9, oldestState(10))
+	assert.EqualError(t, err, "state of block #9 is pruned, the oldest available state is at block #10")
+	assert.Equal(t, http.StatusForbidden, err.(*httpError).status)
+}
+
+func TestWrapStateError(t *testing.T) {
+	other := errors.New("other")
+	assert.Equal(t, other, WrapStateError(other, 9, oldestState(10)))
+
+	missing := &trie.MissingNodeError{Err: errors.New("not found")}
+	assert.EqualError(t, WrapStateError(missing, 9, oldestState(10)),
+		"state of block #9 is pruned, the oldest available state is at block #10")
+	assert.EqualError(t, WrapStateError(missing, 10, oldestState(10)),
+		"state of block #10 is not available, the historical state might be pruned")
+	assert.EqualError(t, WrapStateError(missing, 10, nil),
+		"state of block #10 is not available, the historical state might be pruned")
+}
@@ -42,7 +42,7 @@ func (engine *Engine) newCasts() error {
 					return err
 				}
 
-				checkpoint, err := chain.GetBlockID(getCheckPoint(header.Number()))
+				checkpoint, err := chain.GetBlockID(engine.getCheckPoint(header.Number()))
 				if err != nil {
 					return err
 				}
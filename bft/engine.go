@@ -91,20 +91,20 @@ func (engine *Engine) Justified() (thor.Bytes32, error) {
 	finalized := engine.Finalized()
 
 	// if head is in the first epoch and not concluded yet
-	if head.Number() < getCheckPoint(engine.forkConfig.FINALITY)+thor.CheckpointInterval-1 {
+	if head.Number() < engine.getCheckPoint(engine.forkConfig.FINALITY)+engine.forkConfig.CheckpointInterval()-1 {
 		return finalized, nil
 	}
 
 	// find the recent concluded checkpoint
-	concluded := getCheckPoint(head.Number())
-	if head.Number() < getStorePoint(head.Number()) {
-		concluded -= thor.CheckpointInterval
+	concluded := engine.getCheckPoint(head.Number())
+	if head.Number() < engine.getStorePoint(head.Number()) {
+		concluded -= engine.forkConfig.CheckpointInterval()
 	}
 
 	headChain := engine.repo.NewChain(head.ID())
 
 	// storeID is the block id where an epoch concluded
-	storeID, err := headChain.GetBlockID(getStorePoint(concluded))
+	storeID, err := headChain.GetBlockID(engine.getStorePoint(concluded))
 	if err != nil {
 		return thor.Bytes32{}, err
 	}
@@ -167,7 +167,7 @@ func (engine *Engine) Select(header *block.Header) (bool, error) {
 // CommitBlock commits bft state to storage.
 func (engine *Engine) CommitBlock(header *block.Header, isPacking bool) error {
 	// save quality and finalized at the end of each round
-	if getStorePoint(header.Number()) == header.Number() {
+	if engine.getStorePoint(header.Number()) == header.Number() {
 		state, err := engine.computeState(header)
 		if err != nil {
 			return err
@@ -199,7 +199,7 @@ func (engine *Engine) CommitBlock(header *block.Header, isPacking bool) error {
 			return err
 		}
 
-		checkpoint, err := engine.repo.NewChain(header.ID()).GetBlockID(getCheckPoint(header.Number()))
+		checkpoint, err := engine.repo.NewChain(header.ID()).GetBlockID(engine.getCheckPoint(header.Number()))
 		if err != nil {
 			return err
 		}
@@ -209,6 +209,53 @@ func (engine *Engine) CommitBlock(header *block.Header, isPacking bool) error {
 	return nil
 }
 
+// ResetFinalized recomputes the finalized checkpoint after the chain is rewound, if the saved one is not
+// on the best chain any more. Store points since the given lowest block are searched backward for the latest
+// committed one, so states of these blocks are required. It falls back to genesis if none found.
+func (engine *Engine) ResetFinalized(lowest uint32) error {
+	var (
+		head      = engine.repo.BestBlockSummary().Header
+		c         = engine.repo.NewChain(head.ID())
+		interval  = engine.forkConfig.CheckpointInterval()
+		finalized = engine.repo.GenesisBlock().Header().ID()
+	)
+	if current := engine.Finalized(); block.Number(current) <= head.Number() {
+		if has, err := c.HasBlock(current); err != nil {
+			return err
+		} else if has {
+			return nil
+		}
+	}
+
+	lowest = max(lowest, engine.forkConfig.FINALITY)
+	for sp := int64(engine.getStorePoint(head.Number())); sp >= int64(lowest); sp -= int64(interval) {
+		if sp > int64(head.Number()) {
+			continue
+		}
+		sum, err := c.GetBlockSummary(uint32(sp))
+		if err != nil {
+			return err
+		}
+		st, err := engine.computeState(sum.Header)
+		if err != nil {
+			return err
+		}
+		if st.Committed && st.Quality > 1 {
+			if finalized, err = engine.findCheckpointByQuality(st.Quality-1, finalized, sum.Header.ID()); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	if err := engine.data.Put(finalizedKey, finalized[:]); err != nil {
+		return err
+	}
+	engine.finalized.Store(finalized)
+	engine.justified.Store(justified{})
+	return nil
+}
+
 // ShouldVote decides if vote COM for a given parent block ID.
 // Packer only.
 func (engine *Engine) ShouldVote(parentID thor.Bytes32) (bool, error) {
@@ -220,7 +267,8 @@ func (engine *Engine) ShouldVote(parentID thor.Bytes32) (bool, error) {
 	}
 
 	// do not vote COM at the first round
-	if absRound := (block.Number(parentID)+1)/thor.CheckpointInterval - engine.forkConfig.FINALITY/thor.CheckpointInterval; absRound == 0 {
+	interval := engine.forkConfig.CheckpointInterval()
+	if absRound := (block.Number(parentID)+1)/interval - engine.forkConfig.FINALITY/interval; absRound == 0 {
 		return false, nil
 	}
 
@@ -243,14 +291,14 @@ func (engine *Engine) ShouldVote(parentID thor.Bytes32) (bool, error) {
 	var recentJC thor.Bytes32
 	if st.Justified {
 		// if justified in this round, use this round's checkpoint
-		checkpoint, err := chain.GetBlockID(getCheckPoint(block.Number(parentID)))
+		checkpoint, err := chain.GetBlockID(engine.getCheckPoint(block.Number(parentID)))
 		if err != nil {
 			return false, err
 		}
 		recentJC = checkpoint
 	} else {
 		// if current round is not justified, find the most recent justified checkpoint
-		prev, err := chain.GetBlockID(getStorePoint(block.Number(parentID) - thor.CheckpointInterval))
+		prev, err := chain.GetBlockID(engine.getStorePoint(block.Number(parentID) - interval))
 		if err != nil {
 			return false, err
 		}
@@ -300,7 +348,7 @@ func (engine *Engine) computeState(header *block.Header) (*bftState, error) {
 		end uint32
 	)
 
-	if entry := engine.caches.justifier.Remove(header.ParentID()); !isCheckPoint(header.Number()) && entry != nil {
+	if entry := engine.caches.justifier.Remove(header.ParentID()); !engine.isCheckPoint(header.Number()) && entry != nil {
 		js = (entry.Entry.Value).(*justifier)
 		end = header.Number()
 	} else {
@@ -351,12 +399,12 @@ func (engine *Engine) findCheckpointByQuality(target uint32, finalized, headID t
 
 	searchStart := block.Number(finalized)
 	if searchStart == 0 {
-		searchStart = getCheckPoint(engine.forkConfig.FINALITY)
+		searchStart = engine.getCheckPoint(engine.forkConfig.FINALITY)
 	}
 
 	c := engine.repo.NewChain(headID)
 	get := func(i int) (uint32, error) {
-		id, err := c.GetBlockID(getStorePoint(searchStart + uint32(i)*thor.CheckpointInterval))
+		id, err := c.GetBlockID(engine.getStorePoint(searchStart + uint32(i)*engine.forkConfig.CheckpointInterval()))
 		if err != nil {
 			return 0, err
 		}
@@ -364,7 +412,7 @@ func (engine *Engine) findCheckpointByQuality(target uint32, finalized, headID t
 	}
 
 	// sort.Search searches from [0, n)
-	n := int((block.Number(headID)-searchStart)/thor.CheckpointInterval) + 1
+	n := int((block.Number(headID)-searchStart)/engine.forkConfig.CheckpointInterval()) + 1
 	num := sort.Search(n, func(i int) bool {
 		quality, err := get(i)
 		if err != nil {
@@ -388,7 +436,7 @@ func (engine *Engine) findCheckpointByQuality(target uint32, finalized, headID t
 		return thor.Bytes32{}, errors.New("failed to find the block by quality")
 	}
 
-	return c.GetBlockID(searchStart + uint32(num)*thor.CheckpointInterval)
+	return c.GetBlockID(searchStart + uint32(num)*engine.forkConfig.CheckpointInterval())
 }
 
 func (engine *Engine) getMaxBlockProposers(sum *chain.BlockSummary) (uint64, error) {
@@ -424,15 +472,16 @@ func (engine *Engine) getQuality(id thor.Bytes32) (quality uint32, err error) {
 	return
 }
 
-func getCheckPoint(blockNum uint32) uint32 {
-	return blockNum / thor.CheckpointInterval * thor.CheckpointInterval
+func (engine *Engine) getCheckPoint(blockNum uint32) uint32 {
+	interval := engine.forkConfig.CheckpointInterval()
+	return blockNum / interval * interval
 }
 
-func isCheckPoint(blockNum uint32) bool {
-	return getCheckPoint(blockNum) == blockNum
+func (engine *Engine) isCheckPoint(blockNum uint32) bool {
+	return engine.getCheckPoint(blockNum) == blockNum
 }
 
 // save quality at the end of round
-func getStorePoint(blockNum uint32) uint32 {
-	return getCheckPoint(blockNum) + thor.CheckpointInterval - 1
+func (engine *Engine) getStorePoint(blockNum uint32) uint32 {
+	return engine.getCheckPoint(blockNum) + engine.forkConfig.CheckpointInterval() - 1
 }
@@ -5,6 +5,7 @@
 package bft
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -115,7 +116,7 @@ func (test *TestBFT) reCreateEngine() error {
 
 func (test *TestBFT) newBlock(parentSummary *chain.BlockSummary, master genesis.DevAccount, shouldVote bool, asBest bool) (*chain.BlockSummary, error) {
 	packer := packer.New(test.repo, test.stater, master.Address, &thor.Address{}, test.fc)
-	flow, err := packer.Mock(parentSummary, parentSummary.Header.Timestamp()+thor.BlockInterval, parentSummary.Header.GasLimit())
+	flow, err := packer.Mock(parentSummary, parentSummary.Header.Timestamp()+test.fc.BlockInterval(), parentSummary.Header.GasLimit())
 	if err != nil {
 		return nil, err
 	}
@@ -393,6 +394,66 @@ func TestFinalized(t *testing.T) {
 	assert.Equal(t, jc, testBFT.engine.justified.Load().(justified).value)
 }
 
+func TestResetFinalized(t *testing.T) {
+	testBFT, err := newTestBft(defaultFC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testBFT.fastForward(thor.CheckpointInterval*5 - 1); err != nil {
+		t.Fatal(err)
+	}
+	c := testBFT.repo.NewBestChain()
+	finalized, err := c.GetBlockID(thor.CheckpointInterval * 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, finalized, testBFT.engine.Finalized())
+
+	// kept if still on the chain
+	head, err := c.GetBlockID(thor.CheckpointInterval*3 + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, testBFT.repo.Rewind(context.Background(), head))
+	assert.NoError(t, testBFT.engine.ResetFinalized(0))
+	assert.Equal(t, finalized, testBFT.engine.Finalized())
+
+	// finalized by the store point of the third round
+	head, err = c.GetBlockID(thor.CheckpointInterval*3 - 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, testBFT.repo.Rewind(context.Background(), head))
+	assert.NoError(t, testBFT.engine.ResetFinalized(0))
+	finalized, err = c.GetBlockID(thor.CheckpointInterval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, finalized, testBFT.engine.Finalized())
+
+	// persisted
+	if err := testBFT.reCreateEngine(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, finalized, testBFT.engine.Finalized())
+
+	// falls back to genesis if store points are not searched
+	head, err = c.GetBlockID(thor.CheckpointInterval - 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, testBFT.repo.Rewind(context.Background(), head))
+	assert.NoError(t, testBFT.engine.ResetFinalized(0))
+	assert.Equal(t, testBFT.repo.GenesisBlock().Header().ID(), testBFT.engine.Finalized())
+
+	// blocks can be imported again
+	if err = testBFT.fastForward(thor.CheckpointInterval * 2); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, finalized, testBFT.engine.Finalized())
+}
+
 func TestAccepts(t *testing.T) {
 	testBFT, err := newTestBft(defaultFC)
 	if err != nil {
@@ -1031,3 +1092,28 @@ func TestJustified(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomTiming(t *testing.T) {
+	const interval = 30
+
+	fc := defaultFC
+	fc.Timing = thor.Timing{BlockInterval: 2, CheckpointInterval: interval}
+	testBFT, err := newTestBft(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testBFT.fastForward(3*interval - 1); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint32(interval), block.Number(testBFT.engine.Finalized()))
+
+	justified, err := testBFT.engine.Justified()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2*interval), block.Number(justified))
+
+	best := testBFT.repo.BestBlockSummary().Header
+	parent, err := testBFT.repo.GetBlockSummary(best.ParentID())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), best.Timestamp()-parent.Header.Timestamp())
+}
@@ -30,7 +30,7 @@ func (engine *Engine) newJustifier(parentID thor.Bytes32) (*justifier, error) {
 	blockNum := block.Number(parentID) + 1
 
 	var lastOfParentRound uint32
-	checkpoint := getCheckPoint(blockNum)
+	checkpoint := engine.getCheckPoint(blockNum)
 	if checkpoint > 0 {
 		lastOfParentRound = checkpoint - 1
 	} else {
@@ -48,7 +48,8 @@ func (engine *Engine) newJustifier(parentID thor.Bytes32) (*justifier, error) {
 	threshold := mbp * 2 / 3
 
 	var parentQuality uint32 // quality of last round
-	if absRound := blockNum/thor.CheckpointInterval - engine.forkConfig.FINALITY/thor.CheckpointInterval; absRound == 0 {
+	interval := engine.forkConfig.CheckpointInterval()
+	if absRound := blockNum/interval - engine.forkConfig.FINALITY/interval; absRound == 0 {
 		parentQuality = 0
 	} else {
 		var err error
@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 
 	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/thor"
 )
 
@@ -26,3 +27,15 @@ func loadQuality(getter kv.Getter, id thor.Bytes32) (uint32, error) {
 
 	return binary.BigEndian.Uint32(b), nil
 }
+
+// LoadQuality loads the quality saved at the end of the round, the block must be a store point.
+// It's used to export bft data along with the chain.
+func LoadQuality(db *muxdb.MuxDB, id thor.Bytes32) (uint32, error) {
+	return loadQuality(db.NewStore(dataStoreName), id)
+}
+
+// SaveQuality saves the quality of the round ended at the given block.
+// It's used to restore bft data into a database without replaying blocks.
+func SaveQuality(db *muxdb.MuxDB, id thor.Bytes32, quality uint32) error {
+	return saveQuality(db.NewStore(dataStoreName), id, quality)
+}
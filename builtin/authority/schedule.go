@@ -0,0 +1,158 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package authority
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Node is an authority node to be added.
+type Node struct {
+	MasterAddress   thor.Address `json:"masterAddress"`
+	EndorsorAddress thor.Address `json:"endorsorAddress"`
+	Identity        thor.Bytes32 `json:"identity"`
+}
+
+// ScheduledChange is the changes of authority nodes at the block.
+type ScheduledChange struct {
+	BlockNumber uint32         `json:"blockNumber"`
+	Revoke      []thor.Address `json:"revoke"`
+	Add         []Node         `json:"add"`
+}
+
+// SignedChanges is the authority changes of a permissioned network, signed by the executor.
+type SignedChanges struct {
+	Changes   []ScheduledChange `json:"changes"`
+	Signature hexutil.Bytes     `json:"signature"`
+}
+
+// SigningHash returns the hash to be signed.
+func (sc *SignedChanges) SigningHash() (thor.Bytes32, error) {
+	data, err := rlp.EncodeToBytes(sc.Changes)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	return thor.Blake2b(data), nil
+}
+
+// Sign signs the changes with the private key.
+func (sc *SignedChanges) Sign(key *ecdsa.PrivateKey) error {
+	hash, err := sc.SigningHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		return err
+	}
+	sc.Signature = sig
+	return nil
+}
+
+// Signer recovers the signer of the changes.
+func (sc *SignedChanges) Signer() (thor.Address, error) {
+	if len(sc.Signature) != 65 {
+		return thor.Address{}, errors.New("invalid signature length")
+	}
+	hash, err := sc.SigningHash()
+	if err != nil {
+		return thor.Address{}, err
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), sc.Signature)
+	if err != nil {
+		return thor.Address{}, err
+	}
+	return thor.Address(crypto.PubkeyToAddress(*pub)), nil
+}
+
+// build validates the changes and indexes them by block number.
+func (sc *SignedChanges) build() (map[uint32]*thor.AuthorityChanges, error) {
+	changes := make(map[uint32]*thor.AuthorityChanges, len(sc.Changes))
+	for _, c := range sc.Changes {
+		if c.BlockNumber == 0 {
+			return nil, errors.New("authority of genesis block should be set in genesis")
+		}
+		if _, ok := changes[c.BlockNumber]; ok {
+			return nil, fmt.Errorf("#%d: duplicated block number", c.BlockNumber)
+		}
+		if len(c.Revoke) == 0 && len(c.Add) == 0 {
+			return nil, fmt.Errorf("#%d: no change specified", c.BlockNumber)
+		}
+
+		ac := &thor.AuthorityChanges{Revoke: c.Revoke}
+		for _, node := range c.Add {
+			if node.MasterAddress.IsZero() || node.EndorsorAddress.IsZero() || node.Identity.IsZero() {
+				return nil, fmt.Errorf("#%d: node %v: empty master, endorsor or identity", c.BlockNumber, node.MasterAddress)
+			}
+			ac.Add = append(ac.Add, thor.AuthorityNode{
+				Master:   node.MasterAddress,
+				Endorsor: node.EndorsorAddress,
+				Identity: node.Identity,
+			})
+		}
+		changes[c.BlockNumber] = ac
+	}
+	return changes, nil
+}
+
+// Schedule is the schedule of authority changes, which is frozen once created.
+type Schedule struct {
+	changes map[uint32]*thor.AuthorityChanges
+}
+
+var _ thor.AuthoritySchedule = (*Schedule)(nil)
+
+// NewSchedule creates the schedule of the signed changes.
+// The signature should be verified by the caller.
+func NewSchedule(sc *SignedChanges) (*Schedule, error) {
+	changes, err := sc.build()
+	if err != nil {
+		return nil, err
+	}
+	return &Schedule{changes}, nil
+}
+
+// AuthorityChanges implements thor.AuthoritySchedule.
+func (s *Schedule) AuthorityChanges(blockNum uint32) *thor.AuthorityChanges {
+	return s.changes[blockNum]
+}
+
+// CheckUnaltered checks that the changes at or before the given block number are the same as
+// the ones of the previous schedule, which is nil if no schedule was used before. Such changes
+// are already applied, or about to be applied by peers, so altering them splits the network.
+func (s *Schedule) CheckUnaltered(prev *Schedule, until uint32) error {
+	nums := make(map[uint32]struct{}, len(s.changes))
+	for num := range s.changes {
+		nums[num] = struct{}{}
+	}
+	var prevChanges map[uint32]*thor.AuthorityChanges
+	if prev != nil {
+		prevChanges = prev.changes
+		for num := range prevChanges {
+			nums[num] = struct{}{}
+		}
+	}
+
+	for _, num := range slices.Sorted(maps.Keys(nums)) {
+		if num > until {
+			break
+		}
+		if !reflect.DeepEqual(prevChanges[num], s.changes[num]) {
+			return fmt.Errorf("#%d: changes at or before #%d can not be altered", num, until)
+		}
+	}
+	return nil
+}
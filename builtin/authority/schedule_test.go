@@ -0,0 +1,95 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package authority
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestSignedChanges(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sc := &SignedChanges{
+		Changes: []ScheduledChange{{
+			BlockNumber: 10,
+			Revoke:      []thor.Address{thor.BytesToAddress([]byte("m1"))},
+		}},
+	}
+
+	_, err := sc.Signer()
+	assert.EqualError(t, err, "invalid signature length")
+
+	assert.Nil(t, sc.Sign(key))
+	signer, err := sc.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), signer)
+
+	// tampered
+	sc.Changes[0].BlockNumber = 11
+	signer, err = sc.Signer()
+	assert.Nil(t, err)
+	assert.NotEqual(t, thor.Address(crypto.PubkeyToAddress(key.PublicKey)), signer)
+}
+
+func TestSchedule(t *testing.T) {
+	var (
+		m1   = thor.BytesToAddress([]byte("m1"))
+		m2   = thor.BytesToAddress([]byte("m2"))
+		node = Node{m2, thor.BytesToAddress([]byte("e2")), thor.BytesToBytes32([]byte("i2"))}
+	)
+
+	sc := &SignedChanges{Changes: []ScheduledChange{
+		{BlockNumber: 10, Revoke: []thor.Address{m1}},
+		{BlockNumber: 20, Add: []Node{node}},
+	}}
+	s, err := NewSchedule(sc)
+	assert.Nil(t, err)
+	assert.Equal(t, &thor.AuthorityChanges{Revoke: []thor.Address{m1}}, s.AuthorityChanges(10))
+	assert.Equal(t, &thor.AuthorityChanges{Add: []thor.AuthorityNode{{Master: m2, Endorsor: node.EndorsorAddress, Identity: node.Identity}}}, s.AuthorityChanges(20))
+	assert.Nil(t, s.AuthorityChanges(15))
+
+	// without a previous schedule, no change can be near
+	assert.Nil(t, s.CheckUnaltered(nil, 9))
+	assert.EqualError(t, s.CheckUnaltered(nil, 10), "#10: changes at or before #10 can not be altered")
+
+	newSchedule := func(changes ...ScheduledChange) *Schedule {
+		s, err := NewSchedule(&SignedChanges{Changes: changes})
+		assert.Nil(t, err)
+		return s
+	}
+	// past or near changes can not be altered, added or removed
+	altered := newSchedule(ScheduledChange{BlockNumber: 10, Revoke: []thor.Address{m2}}, ScheduledChange{BlockNumber: 20, Add: []Node{node}})
+	assert.EqualError(t, altered.CheckUnaltered(s, 10), "#10: changes at or before #10 can not be altered")
+	added := newSchedule(ScheduledChange{BlockNumber: 10, Revoke: []thor.Address{m1}}, ScheduledChange{BlockNumber: 12, Revoke: []thor.Address{m2}})
+	assert.EqualError(t, added.CheckUnaltered(s, 15), "#12: changes at or before #15 can not be altered")
+	removed := newSchedule(ScheduledChange{BlockNumber: 20, Add: []Node{node}})
+	assert.EqualError(t, removed.CheckUnaltered(s, 15), "#10: changes at or before #15 can not be altered")
+
+	// far changes can be replaced
+	replaced := newSchedule(ScheduledChange{BlockNumber: 10, Revoke: []thor.Address{m1}}, ScheduledChange{BlockNumber: 30, Add: []Node{node}})
+	assert.Nil(t, replaced.CheckUnaltered(s, 15))
+	assert.Nil(t, s.CheckUnaltered(s, 100))
+
+	tests := []struct {
+		changes []ScheduledChange
+		err     string
+	}{
+		{[]ScheduledChange{{BlockNumber: 0, Revoke: []thor.Address{m1}}}, "authority of genesis block should be set in genesis"},
+		{[]ScheduledChange{{BlockNumber: 1}}, "#1: no change specified"},
+		{[]ScheduledChange{{BlockNumber: 1, Add: []Node{{MasterAddress: m2}}}}, "#1: node " + m2.String() + ": empty master, endorsor or identity"},
+		{[]ScheduledChange{
+			{BlockNumber: 1, Revoke: []thor.Address{m1}},
+			{BlockNumber: 1, Revoke: []thor.Address{m2}},
+		}, "#1: duplicated block number"},
+	}
+	for _, tt := range tests {
+		_, err := NewSchedule(&SignedChanges{Changes: tt.changes})
+		assert.EqualError(t, err, tt.err)
+	}
+}
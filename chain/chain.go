@@ -271,6 +271,19 @@ func (c *Chain) HasBlock(id thor.Bytes32) (bool, error) {
 	return id == foundID, nil
 }
 
+// HasVersion check if the block with given version, i.e. (number, conflicts), belongs to the chain.
+// It's used to look up indexes keyed by versions.
+func (c *Chain) HasVersion(ver trie.Version) (bool, error) {
+	summary, err := c.GetBlockSummary(ver.Major)
+	if err != nil {
+		if c.repo.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return summary.Conflicts == ver.Minor, nil
+}
+
 // Exclude returns ids of blocks belongs to this chain, but not belongs to other.
 //
 // The returned ids are in ascending order.
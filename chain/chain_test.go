@@ -14,6 +14,7 @@ import (
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/test/datagen"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -122,3 +123,25 @@ func TestHasTransaction(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, has)
 }
+
+func TestHasVersion(t *testing.T) {
+	_, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	b1 := newBlock(b0, 10)
+	b1x := newBlock(b0, 11)
+	b2 := newBlock(b1, 20)
+	assert.Nil(t, repo.AddBlock(b1, nil, 0, false))
+	assert.Nil(t, repo.AddBlock(b1x, nil, 1, false))
+	assert.Nil(t, repo.AddBlock(b2, nil, 0, true))
+
+	c := repo.NewBestChain()
+	assert.Equal(t, M(true, nil), M(c.HasVersion(trie.Version{Major: 1})))
+	assert.Equal(t, M(false, nil), M(c.HasVersion(trie.Version{Major: 1, Minor: 1})))
+	assert.Equal(t, M(true, nil), M(c.HasVersion(trie.Version{Major: 2})))
+	assert.Equal(t, M(false, nil), M(c.HasVersion(trie.Version{Major: 3})))
+
+	c = repo.NewChain(b1x.Header().ID())
+	assert.Equal(t, M(true, nil), M(c.HasVersion(trie.Version{Major: 1, Minor: 1})))
+	assert.Equal(t, M(false, nil), M(c.HasVersion(trie.Version{Major: 1})))
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	freezerDataFile  = "bodies.dat"
+	freezerIndexFile = "bodies.idx"
+	freezerIndexSize = 8 // end offset of each entry in the data file
+)
+
+// frozenBlock is the entry of a block in the freezer.
+type frozenBlock struct {
+	Conflicts uint32
+	Txs       []rlp.RawValue
+	Receipts  []rlp.RawValue
+}
+
+// Freezer stores transactions and receipts of ancient canonical blocks in append-only flat files,
+// to keep them out of the database. Blocks are frozen in order from genesis, and indexed by block number.
+//
+// It's thread-safe.
+type Freezer struct {
+	data     *os.File
+	index    *os.File
+	dataSize int64
+	frozen   atomic.Uint32
+	lock     sync.Mutex // for appending
+	cache    *cache
+	cleaned  atomic.Bool // whether leftovers of the last append are deleted from the database
+}
+
+// OpenFreezer opens the freezer in the given dir, which is created if not exists.
+// Entries partially written by an interrupted append are discarded.
+func OpenFreezer(dir string) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	data, err := os.OpenFile(filepath.Join(dir, freezerDataFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	index, err := os.OpenFile(filepath.Join(dir, freezerIndexFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		data.Close()
+		return nil, err
+	}
+	f := &Freezer{data: data, index: index, cache: newCache(32)}
+	if err := f.repair(); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "repair freezer")
+	}
+	return f, nil
+}
+
+// repair truncates the files to the last complete entry.
+func (f *Freezer) repair() error {
+	indexStat, err := f.index.Stat()
+	if err != nil {
+		return err
+	}
+	dataStat, err := f.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	count := min(indexStat.Size()/freezerIndexSize, math.MaxUint32)
+	var end int64
+	for ; count > 0; count-- {
+		if end, err = f.entryEnd(uint32(count - 1)); err != nil {
+			return err
+		}
+		// the data is written before the index
+		if end <= dataStat.Size() {
+			break
+		}
+	}
+	if count == 0 {
+		end = 0
+	}
+	if err := f.index.Truncate(count * freezerIndexSize); err != nil {
+		return err
+	}
+	if err := f.data.Truncate(end); err != nil {
+		return err
+	}
+	f.dataSize = end
+	f.frozen.Store(uint32(count))
+	return nil
+}
+
+// Frozen returns the count of frozen blocks, which are blocks numbered below it.
+func (f *Freezer) Frozen() uint32 {
+	return f.frozen.Load()
+}
+
+// Close closes the freezer.
+func (f *Freezer) Close() error {
+	err1 := f.data.Close()
+	err2 := f.index.Close()
+	if err1 != nil {
+		return err1
+	}
+	return err2
+}
+
+func (f *Freezer) entryEnd(num uint32) (int64, error) {
+	var b [freezerIndexSize]byte
+	if _, err := f.index.ReadAt(b[:], int64(num)*freezerIndexSize); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
+
+// getBlock reads the entry of the given frozen block.
+func (f *Freezer) getBlock(num uint32) (*frozenBlock, error) {
+	entry, _, err := f.cache.GetOrLoad(num, func() (any, error) {
+		end, err := f.entryEnd(num)
+		if err != nil {
+			return nil, err
+		}
+		var start int64
+		if num > 0 {
+			if start, err = f.entryEnd(num - 1); err != nil {
+				return nil, err
+			}
+		}
+		if start > end {
+			return nil, fmt.Errorf("corrupted index of block #%d", num)
+		}
+		buf := make([]byte, end-start)
+		if _, err := f.data.ReadAt(buf, start); err != nil {
+			return nil, err
+		}
+		var entry frozenBlock
+		if err := rlp.DecodeBytes(buf, &entry); err != nil {
+			return nil, errors.Wrapf(err, "decode frozen block #%d", num)
+		}
+		return &entry, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.(*frozenBlock), nil
+}
+
+// get reads the blob of a tx or receipt by its key in the body store.
+// It returns false if the block is not frozen, e.g. a block not in the canonical chain.
+func (f *Freezer) get(key []byte) ([]byte, bool, error) {
+	if len(key) < 4 {
+		return nil, false, nil
+	}
+	num := binary.BigEndian.Uint32(key)
+	if num >= f.Frozen() {
+		return nil, false, nil
+	}
+	conflicts, n := binary.Uvarint(key[4:])
+	if n <= 0 || len(key) < 4+n+1 {
+		return nil, false, nil
+	}
+	flag := key[4+n]
+	index, m := binary.Uvarint(key[4+n+1:])
+	if m <= 0 {
+		return nil, false, nil
+	}
+
+	entry, err := f.getBlock(num)
+	if err != nil {
+		return nil, false, err
+	}
+	if entry.Conflicts != uint32(conflicts) {
+		return nil, false, nil
+	}
+	blobs := entry.Txs
+	if flag == receiptFlag {
+		blobs = entry.Receipts
+	}
+	if index >= uint64(len(blobs)) {
+		return nil, false, nil
+	}
+	return blobs[index], true, nil
+}
+
+// append appends entries of blocks following the frozen ones.
+func (f *Freezer) append(blocks []*frozenBlock) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var (
+		w     = bufio.NewWriter(io.NewOffsetWriter(f.data, f.dataSize))
+		index = make([]byte, 0, len(blocks)*freezerIndexSize)
+		size  = f.dataSize
+	)
+	for _, b := range blocks {
+		data, err := rlp.EncodeToBytes(b)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		size += int64(len(data))
+		index = binary.BigEndian.AppendUint64(index, uint64(size))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.data.Sync(); err != nil {
+		return err
+	}
+	if _, err := f.index.WriteAt(index, int64(f.Frozen())*freezerIndexSize); err != nil {
+		return err
+	}
+	if err := f.index.Sync(); err != nil {
+		return err
+	}
+	f.dataSize = size
+	f.frozen.Add(uint32(len(blocks)))
+	return nil
+}
+
+// freezeBatchSize is the max count of blocks frozen in one append.
+const freezeBatchSize = 256
+
+// Freeze moves transactions and receipts of blocks below the limit, in the chain of the given head,
+// from the database into the attached freezer. The head is usually the finalized block.
+// It should not be called concurrently.
+func (r *Repository) Freeze(ctx context.Context, headID thor.Bytes32, limit uint32) error {
+	if r.freezer == nil {
+		return errors.New("no freezer attached")
+	}
+	if limit > block.Number(headID)+1 {
+		return errors.New("limit exceeds the head")
+	}
+	c := r.NewChain(headID)
+
+	// bodies of blocks frozen by an interrupted call may remain in the database
+	frozen := r.freezer.Frozen()
+	if !r.freezer.cleaned.Load() {
+		if err := r.deleteBodies(c, frozen-min(frozen, freezeBatchSize), frozen); err != nil {
+			return err
+		}
+		r.freezer.cleaned.Store(true)
+	}
+
+	for frozen < limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := min(limit, frozen+freezeBatchSize)
+		blocks := make([]*frozenBlock, 0, end-frozen)
+		for num := frozen; num < end; num++ {
+			summary, err := c.GetBlockSummary(num)
+			if err != nil {
+				return err
+			}
+			b := &frozenBlock{Conflicts: summary.Conflicts}
+			var key []byte
+			for i := range summary.Txs {
+				key = appendTxKey(key[:0], num, summary.Conflicts, uint64(i), txFlag)
+				data, err := r.bodyStore.Get(key)
+				if err != nil {
+					return errors.Wrapf(err, "get tx of block #%d", num)
+				}
+				b.Txs = append(b.Txs, data)
+
+				key = appendTxKey(key[:0], num, summary.Conflicts, uint64(i), receiptFlag)
+				if data, err = r.bodyStore.Get(key); err != nil {
+					return errors.Wrapf(err, "get receipt of block #%d", num)
+				}
+				b.Receipts = append(b.Receipts, data)
+			}
+			blocks = append(blocks, b)
+		}
+		if err := r.freezer.append(blocks); err != nil {
+			return errors.Wrap(err, "append to freezer")
+		}
+		if err := r.deleteBodies(c, frozen, end); err != nil {
+			return err
+		}
+		frozen = end
+	}
+	return nil
+}
+
+// deleteBodies deletes transactions and receipts of blocks in [from, to) from the database.
+func (r *Repository) deleteBodies(c *Chain, from, to uint32) error {
+	var (
+		bulk = r.bodyStore.Bulk()
+		key  []byte
+	)
+	for num := from; num < to; num++ {
+		summary, err := c.GetBlockSummary(num)
+		if err != nil {
+			return err
+		}
+		for i := range summary.Txs {
+			key = appendTxKey(key[:0], num, summary.Conflicts, uint64(i), txFlag)
+			if err := bulk.Delete(key); err != nil {
+				return err
+			}
+			key = appendTxKey(key[:0], num, summary.Conflicts, uint64(i), receiptFlag)
+			if err := bulk.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	return bulk.Write()
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package chain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func TestFreezer(t *testing.T) {
+	db, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	var (
+		blocks = []*block.Block{b0}
+		parent = b0
+	)
+	for i := 1; i <= 5; i++ {
+		b := newBlock(parent, uint64(i*10), newTx(), newTx())
+		assert.Nil(t, repo.AddBlock(b, tx.Receipts{{GasUsed: uint64(i)}, {GasUsed: uint64(i) + 100}}, 0, true))
+		blocks = append(blocks, b)
+		parent = b
+	}
+	fork := newBlock(blocks[2], 31, newTx())
+	assert.Nil(t, repo.AddBlock(fork, tx.Receipts{{GasUsed: 1000}}, 1, false))
+
+	assert.Error(t, repo.Freeze(context.Background(), blocks[4].Header().ID(), 3), "no freezer attached")
+
+	dir := t.TempDir()
+	freezer, err := OpenFreezer(dir)
+	assert.Nil(t, err)
+	repo.AttachFreezer(freezer)
+
+	assert.Error(t, repo.Freeze(context.Background(), blocks[4].Header().ID(), 6), "limit exceeds the head")
+	assert.Nil(t, repo.Freeze(context.Background(), blocks[4].Header().ID(), 4))
+	assert.Equal(t, uint32(4), freezer.Frozen())
+
+	// frozen bodies are removed from the database
+	for _, b := range blocks[1:4] {
+		key := appendTxKey(nil, b.Header().Number(), 0, 0, txFlag)
+		has, err := repo.bodyStore.Has(key)
+		assert.Nil(t, err)
+		assert.False(t, has)
+	}
+
+	check := func(repo *Repository) {
+		for i, b := range blocks {
+			got, err := repo.GetBlock(b.Header().ID())
+			assert.Nil(t, err)
+			assert.Equal(t, b.Header().ID(), got.Header().ID())
+			assert.Equal(t, b.Transactions().RootHash(), got.Transactions().RootHash())
+
+			receipts, err := repo.GetBlockReceipts(b.Header().ID())
+			assert.Nil(t, err)
+			assert.Equal(t, len(b.Transactions()), len(receipts))
+			if i > 0 {
+				assert.Equal(t, uint64(i)+100, receipts[1].GasUsed)
+			}
+		}
+		for _, trx := range blocks[2].Transactions() {
+			got, meta, err := repo.NewBestChain().GetTransaction(trx.ID())
+			assert.Nil(t, err)
+			assert.Equal(t, trx.ID(), got.ID())
+			assert.Equal(t, uint32(2), meta.BlockNum)
+		}
+		// blocks not in the frozen chain are still read from the database
+		got, err := repo.GetBlock(fork.Header().ID())
+		assert.Nil(t, err)
+		assert.Equal(t, fork.Transactions().RootHash(), got.Transactions().RootHash())
+		receipts, err := repo.GetBlockReceipts(fork.Header().ID())
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1000), receipts[0].GasUsed)
+	}
+
+	repo2, err := NewRepository(db, b0)
+	assert.Nil(t, err)
+	repo2.AttachFreezer(freezer)
+	check(repo2)
+
+	// frozen blocks can't be rewound
+	assert.Error(t, repo2.Rewind(context.Background(), blocks[1].Header().ID()), "blocks below #4 are frozen")
+
+	// continue and reopen
+	assert.Nil(t, repo2.Freeze(context.Background(), blocks[5].Header().ID(), 6))
+	assert.Nil(t, freezer.Close())
+
+	freezer, err = OpenFreezer(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(6), freezer.Frozen())
+	repo3, err := NewRepository(db, b0)
+	assert.Nil(t, err)
+	repo3.AttachFreezer(freezer)
+	check(repo3)
+	assert.Nil(t, freezer.Close())
+
+	// partially written entry is discarded
+	data := filepath.Join(dir, freezerDataFile)
+	info, err := os.Stat(data)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(data, info.Size()-1))
+
+	freezer, err = OpenFreezer(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(5), freezer.Frozen())
+	assert.Nil(t, freezer.Close())
+}
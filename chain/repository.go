@@ -6,9 +6,13 @@
 package chain
 
 import (
+	"context"
 	"encoding/binary"
+	"fmt"
+	"math"
 	"sync/atomic"
 
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/vechain/thor/v2/block"
@@ -48,6 +52,7 @@ type Repository struct {
 	propStore kv.Store
 	headStore kv.Store
 	txIndexer kv.Store
+	freezer   *Freezer
 
 	genesis *block.Block
 	tag     byte
@@ -124,6 +129,12 @@ func NewRepository(db *muxdb.MuxDB, genesis *block.Block) (*Repository, error) {
 	return repo, nil
 }
 
+// AttachFreezer attaches the freezer, which is read before the database for transactions and receipts.
+// It must be called before the repository is in use.
+func (r *Repository) AttachFreezer(f *Freezer) {
+	r.freezer = f
+}
+
 // ChainTag returns chain tag, which is the last byte of genesis id.
 func (r *Repository) ChainTag() byte {
 	return r.tag
@@ -239,6 +250,139 @@ func (r *Repository) AddBlock(newBlock *block.Block, receipts tx.Receipts, confl
 	return nil
 }
 
+// SetBestBlockID sets the saved block with the given id as the best block.
+// It's used to rewind the canonical chain, e.g. reverting to a snapshot in solo mode.
+func (r *Repository) SetBestBlockID(id thor.Bytes32) error {
+	summary, err := r.GetBlockSummary(id)
+	if err != nil {
+		return err
+	}
+	if err := r.propStore.Put(bestBlockIDKey, id[:]); err != nil {
+		return err
+	}
+	r.bestSummary.Store(summary)
+	r.tick.Broadcast()
+	return nil
+}
+
+// Rewind sets the given block of the canonical chain as the best block, and removes all saved blocks
+// numbered above it, including blocks of other branches, so that they can be imported again.
+// It must not be called while the repository is in use. It's safe to call again if interrupted.
+// Nodes of the index trie committed by removed blocks are left in history space, they are either
+// overwritten once blocks imported again, or deleted by the pruner along with other trie history.
+func (r *Repository) Rewind(ctx context.Context, id thor.Bytes32) error {
+	if r.freezer != nil && block.Number(id)+1 < r.freezer.Frozen() {
+		return fmt.Errorf("blocks below #%d are frozen", r.freezer.Frozen())
+	}
+	best := r.BestBlockSummary().Header
+	if has, err := r.NewChain(best.ID()).HasBlock(id); err != nil {
+		return err
+	} else if !has {
+		return errors.New("block not in the canonical chain")
+	}
+	// set the best block first, then the removal can be continued by calling again
+	if err := r.SetBestBlockID(id); err != nil {
+		return err
+	}
+	if block.Number(id) == math.MaxUint32 {
+		return nil
+	}
+
+	var (
+		rng           = kv.Range{Start: binary.BigEndian.AppendUint32(nil, block.Number(id)+1)}
+		bulk          = r.db.NewStore("").Bulk()
+		headPutter    = kv.Bucket(headStoreName).NewPutter(bulk)
+		txIndexPutter = kv.Bucket(txIndexStoreName).NewPutter(bulk)
+		txIDs         []thor.Bytes32
+		keyBuf        []byte
+	)
+	bulk.EnableAutoFlush()
+
+	// remove tx metadata of removed blocks
+	iter := r.hdrStore.Iterate(rng)
+	for iter.Next() {
+		var summary BlockSummary
+		if err := rlp.DecodeBytes(iter.Value(), &summary); err != nil {
+			iter.Release()
+			return err
+		}
+		for _, txid := range summary.Txs {
+			keyBuf = append(keyBuf[:0], txid[:]...)
+			keyBuf = binary.AppendUvarint(keyBuf, uint64(summary.Header.Number()))
+			keyBuf = binary.AppendUvarint(keyBuf, uint64(summary.Conflicts))
+			if err := txIndexPutter.Delete(keyBuf); err != nil {
+				iter.Release()
+				return err
+			}
+			txIDs = append(txIDs, txid)
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	// the ancestors of removed heads become heads
+	heads, err := r.ScanHeads(block.Number(id) + 1)
+	if err != nil {
+		return err
+	}
+	for _, head := range heads {
+		ancestor := head
+		for block.Number(ancestor) > block.Number(id) {
+			summary, err := loadBlockSummary(r.hdrStore, ancestor)
+			if err != nil {
+				return err
+			}
+			ancestor = summary.Header.ParentID()
+		}
+		if err := headPutter.Delete(head[:]); err != nil {
+			return err
+		}
+		if err := headPutter.Put(ancestor[:], nil); err != nil {
+			return err
+		}
+	}
+	if err := bulk.Write(); err != nil {
+		return err
+	}
+
+	if err := r.bodyStore.DeleteRange(ctx, rng); err != nil {
+		return err
+	}
+	if err := r.hdrStore.DeleteRange(ctx, rng); err != nil {
+		return err
+	}
+	r.caches.summaries.Purge()
+	r.caches.txs.Purge()
+	r.caches.receipts.Purge()
+
+	// remove filter keys of txs no longer indexed
+	for _, txid := range txIDs {
+		if has, err := r.hasTxMeta(txid[:txFilterKeyLen]); err != nil {
+			return err
+		} else if !has {
+			if err := r.txIndexer.Delete(txid[:txFilterKeyLen]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasTxMeta checks if there is any tx metadata with the given key prefix.
+func (r *Repository) hasTxMeta(prefix []byte) (bool, error) {
+	iter := r.txIndexer.Iterate(kv.Range(*util.BytesPrefix(prefix)))
+	defer iter.Release()
+	for iter.Next() {
+		// skip the filter key
+		if len(iter.Key()) > txFilterKeyLen {
+			return true, nil
+		}
+	}
+	return false, iter.Error()
+}
+
 // ScanConflicts returns the count of saved blocks with the given blockNum.
 func (r *Repository) ScanConflicts(blockNum uint32) (uint32, error) {
 	prefix := binary.BigEndian.AppendUint32(nil, blockNum)
@@ -312,7 +456,7 @@ func (r *Repository) GetBlockSummary(id thor.Bytes32) (*BlockSummary, error) {
 
 func (r *Repository) getTransaction(key []byte) (*tx.Transaction, error) {
 	trx, cached, err := r.caches.txs.GetOrLoad(string(key), func() (any, error) {
-		return loadTransaction(r.bodyStore, key)
+		return loadTransaction(r.getBody, key)
 	})
 	if err != nil {
 		return nil, err
@@ -330,9 +474,13 @@ func (r *Repository) getTransaction(key []byte) (*tx.Transaction, error) {
 	return trx.(*tx.Transaction), nil
 }
 
-func loadTransaction(r kv.Getter, key []byte) (*tx.Transaction, error) {
+func loadTransaction(get func([]byte) ([]byte, error), key []byte) (*tx.Transaction, error) {
+	data, err := get(key)
+	if err != nil {
+		return nil, err
+	}
 	var tx tx.Transaction
-	if err := loadRLP(r, key[:], &tx); err != nil {
+	if err := rlp.DecodeBytes(data, &tx); err != nil {
 		return nil, err
 	}
 	return &tx, nil
@@ -375,7 +523,7 @@ func (r *Repository) GetBlock(id thor.Bytes32) (*block.Block, error) {
 
 func (r *Repository) getReceipt(key []byte) (*tx.Receipt, error) {
 	receipt, cached, err := r.caches.receipts.GetOrLoad(string(key), func() (any, error) {
-		return loadReceipt(r.bodyStore, key)
+		return loadReceipt(r.getBody, key)
 	})
 	if err != nil {
 		return nil, err
@@ -392,14 +540,30 @@ func (r *Repository) getReceipt(key []byte) (*tx.Receipt, error) {
 	return receipt.(*tx.Receipt), nil
 }
 
-func loadReceipt(r kv.Getter, key []byte) (*tx.Receipt, error) {
+func loadReceipt(get func([]byte) ([]byte, error), key []byte) (*tx.Receipt, error) {
+	data, err := get(key)
+	if err != nil {
+		return nil, err
+	}
 	var receipt tx.Receipt
-	if err := loadRLP(r, key[:], &receipt); err != nil {
+	if err := rlp.DecodeBytes(data, &receipt); err != nil {
 		return nil, err
 	}
 	return &receipt, nil
 }
 
+// getBody reads the blob of a tx or receipt, from the freezer first if attached.
+func (r *Repository) getBody(key []byte) ([]byte, error) {
+	if r.freezer != nil {
+		if blob, ok, err := r.freezer.get(key); err != nil {
+			return nil, err
+		} else if ok {
+			return blob, nil
+		}
+	}
+	return r.bodyStore.Get(key)
+}
+
 // GetBlockReceipts get all tx receipts of the block for given block id.
 func (r *Repository) GetBlockReceipts(id thor.Bytes32) (tx.Receipts, error) {
 	summary, err := r.GetBlockSummary(id)
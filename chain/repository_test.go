@@ -6,6 +6,7 @@
 package chain
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -99,6 +100,80 @@ func TestAddBlock(t *testing.T) {
 	assert.Nil(t, repo.AddBlock(b1, nil, 0, false))
 }
 
+func TestSetBestBlockID(t *testing.T) {
+	db, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	b1 := newBlock(b0, 10)
+	assert.Nil(t, repo.AddBlock(b1, nil, 0, true))
+	assert.Equal(t, b1.Header().ID(), repo.BestBlockSummary().Header.ID())
+
+	assert.Nil(t, repo.SetBestBlockID(b0.Header().ID()))
+	assert.Equal(t, b0.Header().ID(), repo.BestBlockSummary().Header.ID())
+
+	// persisted
+	repo2, _ := NewRepository(db, b0)
+	assert.Equal(t, b0.Header().ID(), repo2.BestBlockSummary().Header.ID())
+
+	assert.True(t, repo.IsNotFound(repo.SetBestBlockID(thor.Bytes32{})))
+	assert.Equal(t, b0.Header().ID(), repo.BestBlockSummary().Header.ID())
+}
+
+func TestRewind(t *testing.T) {
+	db, repo := newTestRepo()
+	b0 := repo.GenesisBlock()
+
+	tx1, tx2 := newTx(), newTx()
+	b1 := newBlock(b0, 10, tx1)
+	b2 := newBlock(b1, 20, tx2)
+	b3 := newBlock(b2, 30)
+	b2x := newBlock(b1, 21, tx2)
+	b1x := newBlock(b0, 11)
+	assert.Nil(t, repo.AddBlock(b1, tx.Receipts{{}}, 0, false))
+	assert.Nil(t, repo.AddBlock(b2, tx.Receipts{{}}, 0, false))
+	assert.Nil(t, repo.AddBlock(b3, nil, 0, true))
+	assert.Nil(t, repo.AddBlock(b2x, tx.Receipts{{}}, 1, false))
+	assert.Nil(t, repo.AddBlock(b1x, nil, 1, false))
+
+	assert.Error(t, repo.Rewind(context.Background(), b2x.Header().ID()), "not in the canonical chain")
+	assert.Nil(t, repo.Rewind(context.Background(), b1.Header().ID()))
+	assert.Equal(t, b1.Header().ID(), repo.BestBlockSummary().Header.ID())
+
+	for _, b := range []*block.Block{b2, b3, b2x} {
+		_, err := repo.GetBlockSummary(b.Header().ID())
+		assert.True(t, repo.IsNotFound(err))
+	}
+	assert.Equal(t, []any{uint32(0), nil}, M(repo.ScanConflicts(2)))
+	assert.Equal(t, []any{uint32(1), nil}, M(repo.GetMaxBlockNum()))
+
+	heads, err := repo.ScanHeads(0)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []thor.Bytes32{b1.Header().ID(), b1x.Header().ID()}, heads)
+
+	// txs of removed blocks are not found
+	has, err := repo.txIndexer.Has(tx2.ID().Bytes()[:txFilterKeyLen])
+	assert.Nil(t, err)
+	assert.False(t, has)
+	_, err = repo.NewBestChain().GetTransactionMeta(tx2.ID())
+	assert.True(t, repo.IsNotFound(err))
+	_, err = repo.NewBestChain().GetTransactionMeta(tx1.ID())
+	assert.Nil(t, err)
+
+	// persisted
+	repo2, _ := NewRepository(db, b0)
+	assert.Equal(t, b1.Header().ID(), repo2.BestBlockSummary().Header.ID())
+
+	// blocks can be imported again
+	b2y := newBlock(b1, 22)
+	assert.Nil(t, repo.AddBlock(b2y, nil, 0, true))
+	_, err = repo.NewBestChain().GetTransactionMeta(tx2.ID())
+	assert.True(t, repo.IsNotFound(err))
+	assert.Nil(t, repo.AddBlock(b2, tx.Receipts{{}}, 1, true))
+	meta, err := repo.NewBestChain().GetTransactionMeta(tx2.ID())
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), meta.BlockNum)
+}
+
 func TestConflicts(t *testing.T) {
 	_, repo := newTestRepo()
 	b0 := repo.GenesisBlock()
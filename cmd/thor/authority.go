@@ -0,0 +1,117 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/builtin/authority"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+)
+
+const (
+	authorityStoreName    = "authority.props"
+	authorityChangesKey   = "changes"
+	authorityFreezeBlocks = 360 // about 1 hour
+)
+
+func readAuthorityChanges(path string) (*authority.SignedChanges, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sc authority.SignedChanges
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, errors.Wrap(err, "decode authority changes")
+	}
+	return &sc, nil
+}
+
+// loadAuthorityChanges reads and verifies the signed authority changes, and checks them against the
+// changes loaded last time. The returned schedule stays the same until the node restarts.
+func loadAuthorityChanges(path string, db *muxdb.MuxDB, repo *chain.Repository) (*authority.Schedule, error) {
+	sc, err := readAuthorityChanges(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := consensus.VerifyAuthorityChanges(repo, state.NewStater(db), sc); err != nil {
+		return nil, err
+	}
+	schedule, err := authority.NewSchedule(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	store := db.NewStore(authorityStoreName)
+	var prev *authority.Schedule
+	if data, err := store.Get([]byte(authorityChangesKey)); err != nil {
+		if !store.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		var prevSC authority.SignedChanges
+		if err := json.Unmarshal(data, &prevSC); err != nil {
+			return nil, errors.Wrap(err, "decode loaded authority changes")
+		}
+		if prev, err = authority.NewSchedule(&prevSC); err != nil {
+			return nil, errors.Wrap(err, "loaded authority changes")
+		}
+	}
+
+	// changes of past blocks are in the chain state, and the ones of near blocks might be
+	// applied by peers before they restart, so both are frozen
+	best := repo.BestBlockSummary().Header.Number()
+	if err := schedule.CheckUnaltered(prev, best+authorityFreezeBlocks); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Put([]byte(authorityChangesKey), data); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func signAuthorityChangesAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("authority changes file not specified")
+	}
+	path := ctx.Args().First()
+	sc, err := readAuthorityChanges(path)
+	if err != nil {
+		return err
+	}
+
+	key, err := readPrivateKeyFromStdin("Enter executor key: ")
+	if err != nil {
+		return errors.Wrap(err, "read executor key")
+	}
+	if err := sc.Sign(key); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(sc, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	fmt.Println("Signed by", thor.Address(crypto.PubkeyToAddress(key.PublicKey)))
+	return nil
+}
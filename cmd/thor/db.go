@@ -0,0 +1,76 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func migrateDBAction(ctx *cli.Context) error {
+	initLogger(log.LegacyLevelInfo, false)
+
+	target := ctx.String(migrateToFlag.Name)
+	if target != muxdb.EngineLevelDB && target != muxdb.EnginePebble {
+		return fmt.Errorf("unsupported engine %q, use --%s to specify (leveldb|pebble)", target, migrateToFlag.Name)
+	}
+
+	gene, _, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	var (
+		srcPath    = filepath.Join(instanceDir, "main.db")
+		dstPath    = srcPath + ".migrating"
+		backupPath = srcPath + ".bak"
+	)
+	if _, err := os.Stat(backupPath); err == nil {
+		return fmt.Errorf("backup of previous migration [%v] exists, remove it first", backupPath)
+	}
+
+	opts := makeMainDBOptions(ctx)
+	opts.Engine = target
+
+	log.Info("migrating main database", "path", srcPath, "to", target)
+	start := time.Now()
+	lastLog := start
+	count, err := muxdb.Migrate(handleExitSignal(), srcPath, dstPath, opts, func(p *muxdb.MigrateProgress) {
+		if time.Since(lastLog) < 10*time.Second {
+			return
+		}
+		lastLog = time.Now()
+		if p.Verifying {
+			log.Info("verifying", "keys", p.Count, "key", fmt.Sprintf("%x", p.Key))
+		} else {
+			log.Info("copying", "keys", p.Count, "key", fmt.Sprintf("%x", p.Key))
+		}
+	})
+	if err != nil {
+		return errors.WithMessage(err, "migrate main database")
+	}
+
+	if err := os.Rename(srcPath, backupPath); err != nil {
+		return err
+	}
+	if err := os.Rename(dstPath, srcPath); err != nil {
+		return err
+	}
+	log.Info("main database migrated", "keys", count, "elapsed", time.Since(start).Round(time.Second))
+	fmt.Printf("The original database is kept at %v, remove it once the node runs well with --%s %s\n",
+		backupPath, dbEngineFlag.Name, target)
+	return nil
+}
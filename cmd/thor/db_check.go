@@ -0,0 +1,334 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"github.com/vechain/thor/v2/tx"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// dbInconsistency is an inconsistency found in databases, along with the suggested repair.
+type dbInconsistency struct {
+	what   string
+	repair string
+}
+
+func (e *dbInconsistency) Error() string { return e.what }
+
+func checkDBAction(ctx *cli.Context) error {
+	initLogger(log.LegacyLevelInfo, false)
+
+	gene, _, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(instanceDir, "main.db")); err != nil {
+		return fmt.Errorf("main database not found in %v", instanceDir)
+	}
+
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+
+	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(state.NewStater(mainDB))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	repo, err := chain.NewRepository(mainDB, genesisBlock)
+	if err != nil {
+		return reportInconsistency(&dbInconsistency{
+			what:   fmt.Sprintf("load chain: %v", err),
+			repair: resyncRepair,
+		})
+	}
+	freezer, err := openFreezer(instanceDir, repo, false)
+	if err != nil {
+		return err
+	}
+	if freezer != nil {
+		defer freezer.Close()
+	}
+
+	var (
+		exitSignal = handleExitSignal()
+		start      = time.Now()
+	)
+	if err := checkChain(exitSignal, repo); err != nil {
+		return reportInconsistency(err)
+	}
+
+	summary, err := resolveRevision(repo, ctx.String(revisionFlag.Name))
+	if err != nil {
+		if repo.IsNotFound(err) {
+			return fmt.Errorf("block %q not found", ctx.String(revisionFlag.Name))
+		}
+		return errors.WithMessage(err, revisionFlag.Name)
+	}
+	oldest, err := pruner.LoadOldestState(mainDB)
+	if err != nil {
+		return err
+	}
+	if num := summary.Header.Number(); num < oldest {
+		return fmt.Errorf("state of block #%d is pruned, the oldest available is #%d", num, oldest)
+	}
+	if err := checkState(exitSignal, mainDB, summary); err != nil {
+		return reportInconsistency(err)
+	}
+
+	if !ctx.Bool(skipLogsFlag.Name) {
+		if _, err := os.Stat(filepath.Join(instanceDir, "logs.db")); err != nil {
+			log.Info("log database not found, skipped")
+		} else {
+			logDB, err := openLogDB(instanceDir)
+			if err != nil {
+				return err
+			}
+			defer logDB.Close()
+			if err := checkLogDB(exitSignal, repo, logDB, genesisReceipts(genesisEvents, genesisTransfers)); err != nil {
+				return reportInconsistency(err)
+			}
+		}
+	}
+	log.Info("no inconsistency found", "elapsed", time.Since(start).Round(time.Second))
+	return nil
+}
+
+const resyncRepair = "remove main.db and logs.db of the instance to sync from scratch, or import a snapshot"
+
+// reportInconsistency prints the inconsistency with the suggested repair, other errors are returned as is.
+func reportInconsistency(err error) error {
+	var inc *dbInconsistency
+	if !errors.As(err, &inc) {
+		return err
+	}
+	fmt.Println("Inconsistency found:", inc.what)
+	fmt.Println("Suggested repair:", inc.repair)
+	return errors.New("database check failed")
+}
+
+// checkChain walks block summaries of the best chain, and verifies stored transactions and receipts
+// against roots in block headers.
+func checkChain(ctx context.Context, repo *chain.Repository) error {
+	var (
+		best         = repo.BestBlockSummary().Header
+		c            = repo.NewChain(best.ID())
+		prevID       = repo.GenesisBlock().Header().ID()
+		correctRoots = thor.LoadCorrectReceiptsRoots()
+		lastLog      = time.Now()
+	)
+	log.Info("checking blocks", "best", best.Number())
+
+	for num := uint32(1); num <= best.Number(); num++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		inconsistent := func(format string, args ...any) error {
+			return &dbInconsistency{
+				what: fmt.Sprintf("block #%d: ", num) + fmt.Sprintf(format, args...),
+				repair: fmt.Sprintf("rewind the chain by `thor db rewind --to %d`, "+
+					"or if the state is pruned, %s", num-1, resyncRepair),
+			}
+		}
+
+		id, err := c.GetBlockID(num)
+		if err != nil {
+			return inconsistent("read chain index: %v", err)
+		}
+		summary, err := repo.GetBlockSummary(id)
+		if err != nil {
+			return inconsistent("read summary: %v", err)
+		}
+		header := summary.Header
+		if header.ID() != id {
+			return inconsistent("summary of another block %v", header.ID())
+		}
+		if header.ParentID() != prevID {
+			return inconsistent("parent %v is not the previous block %v", header.ParentID(), prevID)
+		}
+
+		txs, err := repo.GetBlockTransactions(id)
+		if err != nil {
+			return inconsistent("read transactions: %v", err)
+		}
+		if root := txs.RootHash(); root != header.TxsRoot() {
+			return inconsistent("txs root mismatch: want %v, have %v", header.TxsRoot(), root)
+		}
+		receipts, err := repo.GetBlockReceipts(id)
+		if err != nil {
+			return inconsistent("read receipts: %v", err)
+		}
+		if len(receipts) != len(txs) {
+			return inconsistent("%d receipts for %d transactions", len(receipts), len(txs))
+		}
+		if root := receipts.RootHash(); root != header.ReceiptsRoot() && correctRoots[id.String()] != root.String() {
+			return inconsistent("receipts root mismatch: want %v, have %v", header.ReceiptsRoot(), root)
+		}
+		prevID = id
+
+		// recreate the chain to avoid the internal trie holds too many nodes.
+		if num%10000 == 0 {
+			c = repo.NewChain(best.ID())
+		}
+		if time.Since(lastLog) > 10*time.Second {
+			lastLog = time.Now()
+			log.Info("checking blocks", "block", num, "best", best.Number())
+		}
+	}
+	log.Info("blocks checked", "best", best.Number())
+	return nil
+}
+
+// checkState walks the account trie and storage tries at the given block, verifying every node against
+// its hash, and contract codes against code hashes.
+func checkState(ctx context.Context, db *muxdb.MuxDB, summary *chain.BlockSummary) error {
+	type task struct {
+		name string
+		path []byte
+		hash thor.Bytes32
+		ver  trie.Version
+	}
+
+	var (
+		num       = summary.Header.Number()
+		root      = summary.Root()
+		tasks     = []*task{{name: state.AccountTrieName, hash: root.Hash, ver: root.Ver}}
+		codeStore = db.NewStore(state.CodeStoreName)
+		codes     = make(map[thor.Bytes32]struct{})
+		nodes     uint64
+		accounts  uint64
+		lastLog   = time.Now()
+	)
+	inconsistent := func(format string, args ...any) error {
+		return &dbInconsistency{
+			what:   fmt.Sprintf("state of block #%d: ", num) + fmt.Sprintf(format, args...),
+			repair: resyncRepair,
+		}
+	}
+	log.Info("checking state", "block", num)
+
+	// checkAccount queues the storage trie, and checks the code the account refers to
+	checkAccount := func(key []byte, leaf *trie.Leaf) error {
+		var acc state.Account
+		if err := rlp.DecodeBytes(leaf.Value, &acc); err != nil {
+			return inconsistent("decode account %x: %v", key, err)
+		}
+		if len(acc.StorageRoot) > 0 {
+			var meta state.AccountMetadata
+			if err := rlp.DecodeBytes(leaf.Meta, &meta); err != nil {
+				return inconsistent("decode account metadata %x: %v", key, err)
+			}
+			tasks = append(tasks, &task{
+				name: state.StorageTrieName(meta.StorageID),
+				hash: thor.BytesToBytes32(acc.StorageRoot),
+				ver:  trie.Version{Major: meta.StorageMajorVer, Minor: meta.StorageMinorVer},
+			})
+		}
+		if len(acc.CodeHash) == 0 {
+			return nil
+		}
+		hash := thor.BytesToBytes32(acc.CodeHash)
+		if _, ok := codes[hash]; ok {
+			return nil
+		}
+		code, err := codeStore.Get(hash[:])
+		if err != nil {
+			if codeStore.IsNotFound(err) {
+				return inconsistent("missing code %v of account %x", hash, key)
+			}
+			return err
+		}
+		if thor.Keccak256(code) != hash {
+			return inconsistent("code hash mismatch %v of account %x", hash, key)
+		}
+		codes[hash] = struct{}{}
+		return nil
+	}
+
+	for len(tasks) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		t := tasks[len(tasks)-1]
+		tasks = tasks[:len(tasks)-1]
+
+		blob, err := db.GetTrieNode(t.name, t.path, t.ver)
+		if err != nil {
+			if db.IsNotFound(err) {
+				return inconsistent("missing node of trie %q at path %x", t.name, t.path)
+			}
+			return err
+		}
+		var leafErr error
+		if err := trie.VerifyNodeBlob(t.path, t.hash, blob,
+			func(path []byte, hash thor.Bytes32, ver trie.Version) {
+				tasks = append(tasks, &task{t.name, path, hash, ver})
+			},
+			func(key []byte, leaf *trie.Leaf) {
+				if t.name != state.AccountTrieName || leafErr != nil {
+					return
+				}
+				accounts++
+				leafErr = checkAccount(key, leaf)
+			},
+		); err != nil {
+			return inconsistent("node of trie %q at path %x: %v", t.name, t.path, err)
+		}
+		if leafErr != nil {
+			return leafErr
+		}
+		nodes++
+
+		if time.Since(lastLog) > 10*time.Second {
+			lastLog = time.Now()
+			log.Info("checking state", "block", num, "nodes", nodes, "accounts", accounts)
+		}
+	}
+	log.Info("state checked", "block", num, "nodes", nodes, "accounts", accounts, "codes", len(codes))
+	return nil
+}
+
+// checkLogDB verifies logs against receipts of the best chain.
+func checkLogDB(ctx context.Context, repo *chain.Repository, logDB *logdb.LogDB, genesisReceipts tx.Receipts) error {
+	const repair = "run thor logdb rebuild, or remove logs.db of the instance, which is rebuilt from blocks when the node starts"
+
+	pos, err := seekLogDBSyncPosition(repo, logDB)
+	if err != nil {
+		return &dbInconsistency{what: fmt.Sprintf("log db: %v", err), repair: repair}
+	}
+	// logs of the genesis block are always written
+	to := max(pos, 1) - 1
+	if err := verifyLogDB(ctx, 0, to, repo, logDB, genesisReceipts); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &dbInconsistency{what: fmt.Sprintf("log db: %v", err), repair: repair}
+	}
+	// blocks without logs are not recorded, so the log db may end before the best block
+	log.Info("logs checked", "block", to)
+	return nil
+}
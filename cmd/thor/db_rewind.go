@@ -0,0 +1,132 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func rewindDBAction(ctx *cli.Context) error {
+	initLogger(log.LegacyLevelInfo, false)
+
+	to := ctx.String(rewindToFlag.Name)
+	if to == "" {
+		return fmt.Errorf("target block not specified, use --%s to specify", rewindToFlag.Name)
+	}
+	gene, forkConfig, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(instanceDir, "main.db")); err != nil {
+		return fmt.Errorf("main database not found in %v", instanceDir)
+	}
+
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+
+	genesisBlock, _, _, err := gene.Build(state.NewStater(mainDB))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	repo, err := chain.NewRepository(mainDB, genesisBlock)
+	if err != nil {
+		return errors.Wrap(err, "initialize block chain")
+	}
+	freezer, err := openFreezer(instanceDir, repo, false)
+	if err != nil {
+		return err
+	}
+	if freezer != nil {
+		defer freezer.Close()
+	}
+
+	target, err := resolveRevision(repo, to)
+	if err != nil {
+		if repo.IsNotFound(err) {
+			return fmt.Errorf("block %q not found", to)
+		}
+		return errors.WithMessage(err, rewindToFlag.Name)
+	}
+	var (
+		id   = target.Header.ID()
+		num  = target.Header.Number()
+		best = repo.BestBlockSummary().Header
+	)
+	if has, err := repo.NewChain(best.ID()).HasBlock(id); err != nil {
+		return err
+	} else if !has {
+		return fmt.Errorf("block %v is not in the best chain", id)
+	}
+	// blocks are executed again on the state of the target
+	oldest, err := pruner.LoadOldestState(mainDB)
+	if err != nil {
+		return err
+	}
+	if num < oldest {
+		return fmt.Errorf("state of block #%d is pruned, the oldest available is #%d", num, oldest)
+	}
+
+	exitSignal := handleExitSignal()
+	log.Info("rewinding chain", "from", best.Number(), "to", num, "id", id)
+	if err := repo.Rewind(exitSignal, id); err != nil {
+		return errors.WithMessage(err, "rewind chain")
+	}
+
+	if err := pruner.Rewind(mainDB, num); err != nil {
+		return errors.WithMessage(err, "reset pruner")
+	}
+
+	// finalized checkpoint above the target is recomputed, on store points whose states are available
+	engine, err := bft.NewEngine(repo, mainDB, forkConfig, thor.Address{})
+	if err != nil {
+		return err
+	}
+	if err := engine.ResetFinalized(oldest + forkConfig.CheckpointInterval()); err != nil {
+		return errors.WithMessage(err, "reset finalized")
+	}
+
+	if h := mainDB.History(); h != nil {
+		if err := h.Truncate(exitSignal, num+1); err != nil {
+			return errors.WithMessage(err, "truncate history index")
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(instanceDir, "logs.db")); err == nil {
+		logDB, err := openLogDB(instanceDir)
+		if err != nil {
+			return err
+		}
+		defer logDB.Close()
+
+		w := logDB.NewWriter()
+		if err := w.Truncate(num + 1); err != nil {
+			return errors.WithMessage(err, "truncate logs")
+		}
+		if err := w.Commit(); err != nil {
+			return errors.WithMessage(err, "truncate logs")
+		}
+	}
+	log.Info("chain rewound", "best", num, "id", id, "finalized", engine.Finalized())
+	return nil
+}
@@ -6,7 +6,11 @@
 package main
 
 import (
+	"runtime"
+	"time"
+
 	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/tracers/js"
 	cli "gopkg.in/urfave/cli.v1"
 )
 
@@ -64,6 +68,26 @@ var (
 		Name:  "api-allow-custom-tracer",
 		Usage: "allow custom JS tracer to be used tracer API",
 	}
+	apiTracerMaxStepsFlag = cli.Uint64Flag{
+		Name:  "api-tracer-max-steps",
+		Value: js.DefaultLimits.MaxSteps,
+		Usage: "limit the number of hook invocations of a custom JS tracer per request (0 for unlimited)",
+	}
+	apiTracerMaxMemoryFlag = cli.Uint64Flag{
+		Name:  "api-tracer-max-memory",
+		Value: js.DefaultLimits.MaxMemory,
+		Usage: "limit the total bytes of EVM data copied into a custom JS tracer per request (0 for unlimited)",
+	}
+	apiTracerMaxOutputFlag = cli.Uint64Flag{
+		Name:  "api-tracer-max-output",
+		Value: js.DefaultLimits.MaxOutputSize,
+		Usage: "limit the result size in bytes of a custom JS tracer (0 for unlimited)",
+	}
+	apiTracerTimeoutFlag = cli.Uint64Flag{
+		Name:  "api-tracer-timeout",
+		Value: uint64(js.DefaultLimits.MaxDuration / time.Millisecond),
+		Usage: "limit the execution time in milliseconds of a custom JS tracer per request (0 for unlimited)",
+	}
 	apiLogsLimitFlag = cli.Uint64Flag{
 		Name:  "api-logs-limit",
 		Value: 1000,
@@ -145,6 +169,56 @@ var (
 		Name:  "disable-pruner",
 		Usage: "disable state pruner to keep all history",
 	}
+	prunerRetentionFlag = cli.StringFlag{
+		Name:  "pruner-retention",
+		Usage: "keep states of the latest blocks, in blocks (e.g. 100000) or time (e.g. 720h, 30d), at least 65535 blocks",
+	}
+	freezerThresholdFlag = cli.Uint64Flag{
+		Name:  "freezer-threshold",
+		Usage: "move bodies and receipts of finalized blocks older than the threshold (in blocks) from the database into flat files, 0 to disable",
+	}
+	historyIndexFlag = cli.BoolFlag{
+		Name:  "history-index",
+		Usage: "index account and storage changes of new blocks, for fast historical state reads and account history queries",
+	}
+	dropHistoryIndexFlag = cli.BoolFlag{
+		Name:  "drop-history-index",
+		Usage: "delete the history index, which is otherwise kept up to date once enabled",
+	}
+	stateSyncFlag = cli.StringFlag{
+		Name:  "state-sync",
+		Usage: "for a fresh node, download the state of the given trusted finalized block ID from peers instead of executing all blocks",
+	}
+	dbEngineFlag = cli.StringFlag{
+		Name:  "db-engine",
+		Value: "leveldb",
+		Usage: "storage engine of main database (leveldb|pebble), can not be changed once the database is created",
+	}
+	migrateToFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "target storage engine (leveldb|pebble)",
+	}
+	rewindToFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "number or ID of the block in the best chain to rewind to",
+	}
+	logDBFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "number of the first block of the range to re-index, rebuilds the whole log db if no range specified",
+	}
+	logDBToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "number of the last block of the range to re-index, defaults to the best block",
+	}
+	logDBWorkersFlag = cli.IntFlag{
+		Name:  "workers",
+		Value: runtime.NumCPU(),
+		Usage: "number of parallel workers to load blocks and receipts",
+	}
+	logDBVerifyFlag = cli.BoolFlag{
+		Name:  "verify",
+		Usage: "verify logs of the range against receipts instead of re-indexing",
+	}
 	enableMetricsFlag = cli.BoolFlag{
 		Name:  "enable-metrics",
 		Usage: "enables metrics collection",
@@ -204,4 +278,79 @@ var (
 		Name:  "genesis",
 		Usage: "path or URL to genesis file, if not set, the default devnet genesis will be used",
 	}
+	devMnemonicFlag = cli.StringFlag{
+		Name:  "dev-mnemonic",
+		Usage: "BIP-39 mnemonic to derive dev accounts from, if not set, the builtin dev accounts will be used",
+	}
+	devAccountsFlag = cli.Uint64Flag{
+		Name:  "dev-accounts",
+		Value: 10,
+		Usage: "number of dev accounts to fund in genesis",
+	}
+	devBalanceFlag = cli.Uint64Flag{
+		Name:  "dev-balance",
+		Value: 1_000_000_000,
+		Usage: "initial VET balance of each dev account",
+	}
+	devEnergyFlag = cli.Uint64Flag{
+		Name:  "dev-energy",
+		Value: 1_000_000_000,
+		Usage: "initial VTHO balance of each dev account",
+	}
+	blockSignerFlag = cli.Uint64Flag{
+		Name:  "block-signer",
+		Usage: "index of the dev account to sign blocks",
+	}
+	forkURLFlag = cli.StringFlag{
+		Name:  "fork-url",
+		Usage: "API URL of a thor node to fork the state from, the state is fetched on demand",
+	}
+	forkBlockFlag = cli.StringFlag{
+		Name:  "fork-block",
+		Value: "best",
+		Usage: "number or ID of the block to fork from, used with --fork-url",
+	}
+	revisionFlag = cli.StringFlag{
+		Name:  "revision",
+		Value: "best",
+		Usage: "number or ID of the block to read the state at",
+	}
+	exportAccountsFlag = cli.StringFlag{
+		Name:  "accounts",
+		Usage: "comma separated list of accounts to export",
+	}
+	exportAuthorityFlag = cli.StringFlag{
+		Name:  "authority",
+		Usage: "comma separated list of authority nodes in form of master:endorsor:identity, defaults to the authority nodes of the chain",
+	}
+	exportExecutorFlag = cli.StringFlag{
+		Name:  "executor",
+		Usage: "address of the executor, defaults to the executor of the chain",
+	}
+	launchTimeFlag = cli.Uint64Flag{
+		Name:  "launch-time",
+		Usage: "launch time of the exported genesis in unix seconds, defaults to now",
+	}
+	outputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "path of the output file, defaults to stdout",
+	}
+	traceTxsFlag = cli.StringFlag{
+		Name:  "trace-txs",
+		Usage: "trace clauses of packed txs and log the call trees (reverted|all)",
+	}
+	traceTracerFlag = cli.StringFlag{
+		Name:  "trace-tracer",
+		Value: "callTracer",
+		Usage: "name of the tracer or JS code to trace txs, used with --trace-txs",
+	}
+	traceBlocksFlag = cli.IntFlag{
+		Name:  "trace-blocks",
+		Value: 100,
+		Usage: "number of recent blocks to keep the tracing results for the admin API",
+	}
+	authorityChangesFlag = cli.StringFlag{
+		Name:  "authority-changes",
+		Usage: "path of the signed authority changes of a permissioned custom network",
+	}
 )
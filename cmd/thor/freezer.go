@@ -0,0 +1,73 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// freezeStep is the max count of blocks frozen between progress reports.
+const freezeStep = 65536
+
+// startFreezer starts the background task which moves blocks finalized for more than threshold blocks
+// into the freezer. The returned func stops the task.
+func startFreezer(repo *chain.Repository, freezer *chain.Freezer, bftEngine *bft.Engine, threshold uint32) func() {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		goes        co.Goes
+	)
+	goes.Go(func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			if err := freezeBlocks(ctx, repo, freezer, bftEngine.Finalized(), threshold); err != nil {
+				if errors.Cause(err) != context.Canceled {
+					log.Warn("failed to freeze blocks", "err", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+	return func() {
+		cancel()
+		goes.Wait()
+	}
+}
+
+// freezeBlocks freezes blocks in the chain of the finalized block, which are older than threshold blocks.
+func freezeBlocks(ctx context.Context, repo *chain.Repository, freezer *chain.Freezer, finalized thor.Bytes32, threshold uint32) error {
+	num := block.Number(finalized)
+	if num < threshold {
+		return nil
+	}
+	var (
+		limit  = num - threshold + 1
+		report = limit-min(limit, freezer.Frozen()) > freezeStep
+		start  = time.Now()
+	)
+	for freezer.Frozen() < limit {
+		if err := repo.Freeze(ctx, finalized, min(limit, freezer.Frozen()+freezeStep)); err != nil {
+			return err
+		}
+		if report {
+			log.Info("freezing blocks", "frozen", freezer.Frozen(), "target", limit, "elapsed", time.Since(start).Round(time.Second))
+		}
+	}
+	return nil
+}
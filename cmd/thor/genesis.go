@@ -0,0 +1,236 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func exportGenesisAction(ctx *cli.Context) error {
+	addrs, err := parseAddressList(ctx.String(exportAccountsFlag.Name))
+	if err != nil {
+		return errors.WithMessage(err, exportAccountsFlag.Name)
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("no account to export, use --%s to specify", exportAccountsFlag.Name)
+	}
+	authority, err := parseAuthorityList(ctx.String(exportAuthorityFlag.Name))
+	if err != nil {
+		return errors.WithMessage(err, exportAuthorityFlag.Name)
+	}
+	var executor *thor.Address
+	if v := ctx.String(exportExecutorFlag.Name); v != "" {
+		addr, err := thor.ParseAddress(v)
+		if err != nil {
+			return errors.WithMessage(err, exportExecutorFlag.Name)
+		}
+		executor = &addr
+	}
+
+	gene, _, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+
+	stater := state.NewStater(mainDB)
+	genesisBlock, _, _, err := gene.Build(stater)
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	repo, err := chain.NewRepository(mainDB, genesisBlock)
+	if err != nil {
+		return errors.Wrap(err, "initialize block chain")
+	}
+	summary, err := resolveRevision(repo, ctx.String(revisionFlag.Name))
+	if err != nil {
+		if repo.IsNotFound(err) {
+			return fmt.Errorf("block %q not found", ctx.String(revisionFlag.Name))
+		}
+		return errors.WithMessage(err, revisionFlag.Name)
+	}
+	header := summary.Header
+	st := stater.NewState(summary.Root())
+
+	// all forks are enabled from the start
+	forkConfig := thor.SoloFork
+	custom := genesis.CustomGenesis{
+		LaunchTime: ctx.Uint64(launchTimeFlag.Name),
+		GasLimit:   header.GasLimit(),
+		Authority:  authority,
+		ForkConfig: &forkConfig,
+	}
+	if custom.LaunchTime == 0 {
+		custom.LaunchTime = uint64(time.Now().Unix())
+	}
+	for _, addr := range addrs {
+		acc, err := genesis.ExportAccount(st, addr, header.Timestamp())
+		if err != nil {
+			return errors.WithMessage(err, "export account "+addr.String())
+		}
+		custom.Accounts = append(custom.Accounts, *acc)
+	}
+	params, err := genesis.ExportParams(st)
+	if err != nil {
+		return errors.WithMessage(err, "export params")
+	}
+	custom.Params = *params
+	if executor != nil {
+		custom.Params.ExecutorAddress = executor
+	}
+	if len(custom.Authority) == 0 {
+		if custom.Authority, err = genesis.ExportAuthority(st); err != nil {
+			return errors.WithMessage(err, "export authority")
+		}
+	}
+
+	// make sure the exported genesis can be launched
+	verify := custom
+	if _, err := genesis.NewCustomNet(&verify); err != nil {
+		return errors.WithMessage(err, "verify exported genesis")
+	}
+
+	data, err := json.MarshalIndent(&custom, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path := ctx.String(outputFlag.Name); path != "" {
+		return os.WriteFile(path, data, 0600)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// resolveRevision resolves the block on the best chain by number, or any block by ID. "best" stands for the best block.
+func resolveRevision(repo *chain.Repository, revision string) (*chain.BlockSummary, error) {
+	if revision == "" || revision == "best" {
+		return repo.BestBlockSummary(), nil
+	}
+	if len(revision) == 66 || len(revision) == 64 {
+		id, err := thor.ParseBytes32(revision)
+		if err != nil {
+			return nil, err
+		}
+		return repo.GetBlockSummary(id)
+	}
+	num, err := strconv.ParseUint(revision, 10, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse block number")
+	}
+	id, err := repo.NewBestChain().GetBlockID(uint32(num))
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetBlockSummary(id)
+}
+
+func parseAddressList(list string) ([]thor.Address, error) {
+	var addrs []thor.Address
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		addr, err := thor.ParseAddress(item)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// parseAuthorityList parses authority nodes in form of master:endorsor:identity.
+func parseAuthorityList(list string) ([]genesis.Authority, error) {
+	var nodes []genesis.Authority
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid authority node %q", item)
+		}
+		var (
+			node genesis.Authority
+			err  error
+		)
+		if node.MasterAddress, err = thor.ParseAddress(parts[0]); err != nil {
+			return nil, errors.WithMessage(err, "master")
+		}
+		if node.EndorsorAddress, err = thor.ParseAddress(parts[1]); err != nil {
+			return nil, errors.WithMessage(err, "endorsor")
+		}
+		if node.Identity, err = thor.ParseBytes32(parts[2]); err != nil {
+			return nil, errors.WithMessage(err, "identity")
+		}
+		if node.Identity.IsZero() {
+			return nil, errors.New("identity: zero")
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func validateGenesisAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("genesis file not specified")
+	}
+	gen, err := readGenesisFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	gene, problems := genesis.ValidateCustomNet(gen)
+	if gene != nil {
+		fmt.Println("Genesis ID  ", gene.ID())
+	}
+	forkConfig := thor.NoFork
+	if gen.ForkConfig != nil {
+		forkConfig = *gen.ForkConfig
+	}
+	// the invalid schedule is reported as a problem
+	forkConfig.Params, _ = genesis.NewParamsSchedule(gen.ParamsSchedule)
+	forks := forkConfig.String()
+	if forks == "" {
+		forks = "none"
+	}
+	fmt.Println("Forks       ", forks)
+	if gen.Timing != nil {
+		forkConfig.Timing = *gen.Timing
+		fmt.Printf("Timing       block interval: %vs, checkpoint interval: %v, epoch length: %v\n",
+			forkConfig.BlockInterval(), forkConfig.CheckpointInterval(), forkConfig.EpochLength())
+	}
+	if len(problems) == 0 {
+		fmt.Println("No problem found")
+		return nil
+	}
+	fmt.Println("Problems:")
+	for _, p := range problems {
+		fmt.Println("  -", p)
+	}
+	return fmt.Errorf("%d problem(s) found", len(problems))
+}
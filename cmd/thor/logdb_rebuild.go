@@ -0,0 +1,285 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/co"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// number of blocks loaded by a worker at a time
+const logDBRebuildChunk = 256
+
+func rebuildLogDBAction(ctx *cli.Context) error {
+	initLogger(log.LegacyLevelInfo, false)
+
+	workers := ctx.Int(logDBWorkersFlag.Name)
+	if workers <= 0 {
+		return fmt.Errorf("invalid --%s %d", logDBWorkersFlag.Name, workers)
+	}
+	gene, _, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(instanceDir, "main.db")); err != nil {
+		return fmt.Errorf("main database not found in %v", instanceDir)
+	}
+
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer mainDB.Close()
+
+	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(state.NewStater(mainDB))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	repo, err := chain.NewRepository(mainDB, genesisBlock)
+	if err != nil {
+		return errors.Wrap(err, "initialize block chain")
+	}
+	freezer, err := openFreezer(instanceDir, repo, false)
+	if err != nil {
+		return err
+	}
+	if freezer != nil {
+		defer freezer.Close()
+	}
+
+	var (
+		best     = repo.BestBlockSummary().Header
+		from     = ctx.Uint64(logDBFromFlag.Name)
+		to       = uint64(best.Number())
+		receipts = genesisReceipts(genesisEvents, genesisTransfers)
+	)
+	if ctx.IsSet(logDBToFlag.Name) {
+		if to = ctx.Uint64(logDBToFlag.Name); to > uint64(best.Number()) {
+			return fmt.Errorf("--%s: exceeds the best block #%d", logDBToFlag.Name, best.Number())
+		}
+	}
+	if from > to {
+		return fmt.Errorf("empty block range [%d, %d]", from, to)
+	}
+
+	var (
+		exitSignal = handleExitSignal()
+		path       = filepath.Join(instanceDir, "logs.db")
+		ranged     = ctx.IsSet(logDBFromFlag.Name) || ctx.IsSet(logDBToFlag.Name)
+	)
+	if ctx.Bool(logDBVerifyFlag.Name) || ranged {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("log database not found in %v", instanceDir)
+		}
+		logDB, err := openLogDB(instanceDir)
+		if err != nil {
+			return err
+		}
+		defer logDB.Close()
+
+		if ctx.Bool(logDBVerifyFlag.Name) {
+			if err := verifyLogDB(exitSignal, uint32(from), uint32(to), repo, logDB, receipts); err != nil {
+				return errors.WithMessage(err, "verify log db")
+			}
+			log.Info("logs verified", "from", from, "to", to)
+			return nil
+		}
+
+		log.Info("re-indexing logs", "from", from, "to", to, "workers", workers)
+		start := time.Now()
+		if err := rebuildLogDB(exitSignal, repo, logDB, best.ID(), uint32(from), uint32(to), workers, receipts); err != nil {
+			return errors.WithMessage(err, "re-index logs, the range is partially re-indexed and should be done again")
+		}
+		log.Info("logs re-indexed", "from", from, "to", to, "elapsed", time.Since(start).Round(time.Second))
+		return nil
+	}
+
+	// rebuilt into a new file, which replaces the current one once completed
+	tmpPath := path + ".rebuilding"
+	for _, p := range []string{tmpPath, tmpPath + "-wal", tmpPath + "-shm"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	logDB, err := logdb.New(tmpPath)
+	if err != nil {
+		return errors.Wrapf(err, "open log database [%v]", tmpPath)
+	}
+	log.Info("rebuilding logs", "to", to, "workers", workers)
+	start := time.Now()
+	if err := rebuildLogDB(exitSignal, repo, logDB, best.ID(), uint32(from), uint32(to), workers, receipts); err != nil {
+		logDB.Close()
+		return errors.WithMessage(err, "rebuild logs")
+	}
+	if err := logDB.Close(); err != nil {
+		return err
+	}
+	// stale journal files must not be applied to the new database
+	for _, p := range []string{path + "-wal", path + "-shm"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	log.Info("logs rebuilt", "to", to, "elapsed", time.Since(start).Round(time.Second))
+	return nil
+}
+
+// rebuildLogDB replaces logs of blocks numbered within [from, to] in the chain of headID,
+// with logs extracted from receipts, which are loaded by parallel workers.
+// Logs of the genesis block are extracted from the given genesis receipts.
+func rebuildLogDB(ctx context.Context, repo *chain.Repository, logDB *logdb.LogDB, headID thor.Bytes32, from, to uint32, workers int, genesisReceipts tx.Receipts) error {
+	pb := pb.New64(int64(to - from + 1)).
+		Set64(0).
+		SetMaxWidth(90).
+		Start()
+	defer func() { pb.NotPrint = true }()
+
+	w := logDB.NewWriterSyncOff()
+	if err := w.DeleteRange(from, to); err != nil {
+		return err
+	}
+	err := loadBlocksInParallel(ctx, repo, headID, from, to, workers, func(b *block.Block, receipts tx.Receipts) error {
+		if b.Header().Number() == 0 {
+			receipts = genesisReceipts
+		}
+		if err := w.Write(b, receipts); err != nil {
+			return err
+		}
+		if w.UncommittedCount() > 2048 {
+			if err := w.Commit(); err != nil {
+				return err
+			}
+		}
+		pb.Add64(1)
+		return nil
+	})
+	if err != nil {
+		_ = w.Rollback()
+		return err
+	}
+	if err := w.Commit(); err != nil {
+		return err
+	}
+	pb.Finish()
+	return nil
+}
+
+// loadBlocksInParallel loads blocks numbered within [from, to] in the chain of headID and their receipts,
+// in chunks by parallel workers, and calls fn with them in order.
+func loadBlocksInParallel(
+	ctx context.Context,
+	repo *chain.Repository,
+	headID thor.Bytes32,
+	from, to uint32,
+	workers int,
+	fn func(b *block.Block, receipts tx.Receipts) error,
+) error {
+	type task struct {
+		from, to uint32
+		blocks   []*block.Block
+		receipts []tx.Receipts
+		err      error
+		done     chan struct{}
+	}
+
+	var (
+		goes    co.Goes
+		tasks   = make(chan *task)
+		ordered = make(chan *task, workers*2)
+		cancel  func()
+	)
+
+	ctx, cancel = context.WithCancel(ctx)
+	defer goes.Wait()
+	defer cancel()
+
+	goes.Go(func() {
+		defer close(ordered)
+		defer close(tasks)
+		for n := from; n <= to; n += logDBRebuildChunk {
+			t := &task{from: n, to: min(n+logDBRebuildChunk-1, to), done: make(chan struct{})}
+			select {
+			case ordered <- t:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case tasks <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	for range workers {
+		goes.Go(func() {
+			for t := range tasks {
+				t.err = func() error {
+					chain := repo.NewChain(headID)
+					for i := t.from; i <= t.to; i++ {
+						b, err := chain.GetBlock(i)
+						if err != nil {
+							return err
+						}
+						receipts, err := repo.GetBlockReceipts(b.Header().ID())
+						if err != nil {
+							return err
+						}
+						// warm up caches of ids and signers
+						b.Header().ID()
+						for _, tx := range b.Transactions() {
+							tx.ID()
+							_, _ = tx.Origin()
+						}
+						t.blocks = append(t.blocks, b)
+						t.receipts = append(t.receipts, receipts)
+					}
+					return nil
+				}()
+				close(t.done)
+			}
+		})
+	}
+
+	for t := range ordered {
+		select {
+		case <-t.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if t.err != nil {
+			return errors.WithMessagef(t.err, "load block #%d to #%d", t.from, t.to)
+		}
+		for i, b := range t.blocks {
+			if err := fn(b, t.receipts[i]); err != nil {
+				return errors.WithMessagef(err, "block #%d", b.Header().Number())
+			}
+		}
+	}
+	return ctx.Err()
+}
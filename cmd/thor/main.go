@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -24,6 +25,7 @@ import (
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/cmd/thor/pruner"
 	"github.com/vechain/thor/v2/cmd/thor/solo"
+	"github.com/vechain/thor/v2/cmd/thor/solo/fork"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/logdb"
@@ -80,6 +82,10 @@ func main() {
 			apiCallGasLimitFlag,
 			apiBacktraceLimitFlag,
 			apiAllowCustomTracerFlag,
+			apiTracerMaxStepsFlag,
+			apiTracerMaxMemoryFlag,
+			apiTracerMaxOutputFlag,
+			apiTracerTimeoutFlag,
 			apiEnableDeprecatedFlag,
 			enableAPILogsFlag,
 			apiLogsLimitFlag,
@@ -94,12 +100,19 @@ func main() {
 			pprofFlag,
 			verifyLogsFlag,
 			disablePrunerFlag,
+			prunerRetentionFlag,
+			freezerThresholdFlag,
+			historyIndexFlag,
+			dropHistoryIndexFlag,
+			stateSyncFlag,
+			dbEngineFlag,
 			enableMetricsFlag,
 			metricsAddrFlag,
 			adminAddrFlag,
 			enableAdminFlag,
 			txPoolLimitPerAccountFlag,
 			allowedTracersFlag,
+			authorityChangesFlag,
 		},
 		Action: defaultAction,
 		Commands: []cli.Command{
@@ -108,6 +121,17 @@ func main() {
 				Usage: "client runs in solo mode for test & dev",
 				Flags: []cli.Flag{
 					genesisFlag,
+					forkURLFlag,
+					forkBlockFlag,
+					devMnemonicFlag,
+					devAccountsFlag,
+					devBalanceFlag,
+					devEnergyFlag,
+					blockSignerFlag,
+					beneficiaryFlag,
+					traceTxsFlag,
+					traceTracerFlag,
+					traceBlocksFlag,
 					dataDirFlag,
 					cacheFlag,
 					apiAddrFlag,
@@ -116,6 +140,10 @@ func main() {
 					apiCallGasLimitFlag,
 					apiBacktraceLimitFlag,
 					apiAllowCustomTracerFlag,
+					apiTracerMaxStepsFlag,
+					apiTracerMaxMemoryFlag,
+					apiTracerMaxOutputFlag,
+					apiTracerTimeoutFlag,
 					apiEnableDeprecatedFlag,
 					enableAPILogsFlag,
 					apiLogsLimitFlag,
@@ -131,6 +159,8 @@ func main() {
 					txPoolLimitFlag,
 					txPoolLimitPerAccountFlag,
 					disablePrunerFlag,
+					prunerRetentionFlag,
+					dbEngineFlag,
 					enableMetricsFlag,
 					metricsAddrFlag,
 					adminAddrFlag,
@@ -149,6 +179,147 @@ func main() {
 				},
 				Action: masterKeyAction,
 			},
+			{
+				Name:  "export-genesis",
+				Usage: "export selected accounts of a chain into a custom genesis file",
+				Flags: []cli.Flag{
+					networkFlag,
+					dataDirFlag,
+					cacheFlag,
+					disablePrunerFlag,
+					dbEngineFlag,
+					revisionFlag,
+					exportAccountsFlag,
+					exportAuthorityFlag,
+					exportExecutorFlag,
+					launchTimeFlag,
+					outputFlag,
+				},
+				Action: exportGenesisAction,
+			},
+			{
+				Name:  "genesis",
+				Usage: "custom genesis tools",
+				Subcommands: []cli.Command{
+					{
+						Name:      "validate",
+						Usage:     "build the custom genesis in memory and report problems",
+						ArgsUsage: "<file>",
+						Action:    validateGenesisAction,
+					},
+				},
+			},
+			{
+				Name:  "db",
+				Usage: "main database tools",
+				Subcommands: []cli.Command{
+					{
+						Name:  "migrate",
+						Usage: "copy the main database into another storage engine, the node must be stopped",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							migrateToFlag,
+						},
+						Action: migrateDBAction,
+					},
+					{
+						Name:  "check",
+						Usage: "verify blocks, receipts, the state at a block and logs, the node must be stopped",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							dbEngineFlag,
+							revisionFlag,
+							skipLogsFlag,
+						},
+						Action: checkDBAction,
+					},
+					{
+						Name:  "rewind",
+						Usage: "reset the best block to an earlier block, removing blocks and logs above it, the node must be stopped",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							dbEngineFlag,
+							rewindToFlag,
+						},
+						Action: rewindDBAction,
+					},
+				},
+			},
+			{
+				Name:  "logdb",
+				Usage: "log database tools",
+				Subcommands: []cli.Command{
+					{
+						Name:  "rebuild",
+						Usage: "rebuild the log db from receipts, re-index or verify a block range, the node must be stopped",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							dbEngineFlag,
+							logDBFromFlag,
+							logDBToFlag,
+							logDBWorkersFlag,
+							logDBVerifyFlag,
+						},
+						Action: rebuildLogDBAction,
+					},
+				},
+			},
+			{
+				Name:  "snapshot",
+				Usage: "export or import the chain with the state at the finalized block, the node must be stopped",
+				Subcommands: []cli.Command{
+					{
+						Name:  "export",
+						Usage: "write the snapshot of the finalized block into a checksummed archive",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							dbEngineFlag,
+							outputFlag,
+						},
+						Action: exportSnapshotAction,
+					},
+					{
+						Name:      "import",
+						Usage:     "create the databases of an empty instance from the snapshot archive",
+						ArgsUsage: "<file>",
+						Flags: []cli.Flag{
+							networkFlag,
+							dataDirFlag,
+							cacheFlag,
+							disablePrunerFlag,
+							dbEngineFlag,
+						},
+						Action: importSnapshotAction,
+					},
+				},
+			},
+			{
+				Name:  "authority",
+				Usage: "authority changes of permissioned custom networks",
+				Subcommands: []cli.Command{
+					{
+						Name:      "sign",
+						Usage:     "sign the authority changes file with the executor key read from stdin",
+						ArgsUsage: "<file>",
+						Action:    signAuthorityChangesAction,
+					},
+				},
+			},
 		},
 	}
 
@@ -206,10 +377,35 @@ func defaultAction(ctx *cli.Context) error {
 	}
 	defer func() { log.Info("closing log database..."); logDB.Close() }()
 
-	repo, err := initChainRepository(gene, mainDB, logDB)
+	repo, err := initChainRepository(gene, state.NewStater(mainDB), mainDB, logDB)
+	if err != nil {
+		return err
+	}
+
+	// the freezer is always attached if exists, for blocks frozen before
+	freezerThreshold := ctx.Uint64(freezerThresholdFlag.Name)
+	freezer, err := openFreezer(instanceDir, repo, freezerThreshold > 0)
 	if err != nil {
 		return err
 	}
+	if freezer != nil {
+		defer func() { log.Info("closing freezer..."); freezer.Close() }()
+	}
+
+	if err := initHistoryIndex(exitSignal, mainDB, repo, ctx.Bool(historyIndexFlag.Name), ctx.Bool(dropHistoryIndexFlag.Name)); err != nil {
+		return err
+	}
+
+	if path := ctx.String(authorityChangesFlag.Name); path != "" {
+		if gene.Name() != "customnet" {
+			return errors.New("authority changes are only supported by custom networks")
+		}
+		schedule, err := loadAuthorityChanges(path, mainDB, repo)
+		if err != nil {
+			return errors.Wrap(err, "load authority changes")
+		}
+		forkConfig.Authority = schedule
+	}
 
 	master, err := loadNodeMaster(ctx)
 	if err != nil {
@@ -226,6 +422,7 @@ func defaultAction(ctx *cli.Context) error {
 	}
 
 	txpoolOpt := defaultTxPoolOptions
+	txpoolOpt.BlockInterval = forkConfig.BlockInterval()
 	txpoolOpt.LimitPerAccount, err = readIntFromUInt64Flag(ctx.Uint64(txPoolLimitPerAccountFlag.Name))
 	if err != nil {
 		return errors.Wrap(err, "parse txpool-limit-per-account flag")
@@ -233,7 +430,17 @@ func defaultAction(ctx *cli.Context) error {
 	txPool := txpool.New(repo, state.NewStater(mainDB), txpoolOpt)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
-	p2pCommunicator, err := newP2PCommunicator(ctx, repo, txPool, instanceDir)
+	bftEngine, err := bft.NewEngine(repo, mainDB, forkConfig, master.Address())
+	if err != nil {
+		return errors.Wrap(err, "init bft engine")
+	}
+
+	if freezerThreshold > 0 {
+		stopFreezer := startFreezer(repo, freezer, bftEngine, uint32(min(freezerThreshold, math.MaxUint32)))
+		defer func() { log.Info("stopping freezer..."); stopFreezer() }()
+	}
+
+	p2pCommunicator, err := newP2PCommunicator(ctx, repo, txPool, mainDB, bftEngine, forkConfig.BlockInterval(), instanceDir)
 	if err != nil {
 		return err
 	}
@@ -247,7 +454,9 @@ func defaultAction(ctx *cli.Context) error {
 			logLevel,
 			repo,
 			p2pCommunicator.Communicator(),
+			forkConfig.BlockInterval(),
 			logAPIRequests,
+			nil,
 		)
 		if err != nil {
 			return fmt.Errorf("unable to start admin server - %w", err)
@@ -256,9 +465,38 @@ func defaultAction(ctx *cli.Context) error {
 		defer func() { log.Info("stopping admin server..."); closeFunc() }()
 	}
 
-	bftEngine, err := bft.NewEngine(repo, mainDB, forkConfig, master.Address())
-	if err != nil {
-		return errors.Wrap(err, "init bft engine")
+	p2pStarted := false
+	if checkpoint := ctx.String(stateSyncFlag.Name); checkpoint != "" && repo.BestBlockSummary().Header.Number() == 0 {
+		checkpointID, err := thor.ParseBytes32(checkpoint)
+		if err != nil {
+			return errors.WithMessage(err, stateSyncFlag.Name)
+		}
+		// peers are required before the node fully started
+		if err := p2pCommunicator.Start(); err != nil {
+			return err
+		}
+		defer p2pCommunicator.Stop()
+		p2pStarted = true
+
+		if err := syncState(exitSignal, p2pCommunicator.Communicator(), mainDB, repo, forkConfig, checkpointID, !ctx.Bool(disablePrunerFlag.Name)); err != nil {
+			return errors.WithMessage(err, "state sync")
+		}
+		if !skipLogs {
+			if err := syncLogDB(exitSignal, repo, logDB, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	apiConfig := makeAPIConfig(ctx, logAPIRequests, false)
+	if !ctx.Bool(disablePrunerFlag.Name) {
+		retention, err := parsePrunerRetention(ctx.String(prunerRetentionFlag.Name), forkConfig.BlockInterval())
+		if err != nil {
+			return errors.WithMessage(err, prunerRetentionFlag.Name)
+		}
+		pruner := pruner.New(mainDB, repo, retention)
+		defer func() { log.Info("stopping pruner..."); pruner.Stop() }()
+		apiConfig.StateHistory = pruner
 	}
 
 	apiHandler, apiCloser := api.New(
@@ -269,7 +507,7 @@ func defaultAction(ctx *cli.Context) error {
 		bftEngine,
 		p2pCommunicator.Communicator(),
 		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, false),
+		apiConfig,
 	)
 	defer func() { log.Info("closing API..."); apiCloser() }()
 
@@ -281,14 +519,11 @@ func defaultAction(ctx *cli.Context) error {
 
 	printStartupMessage2(gene, apiURL, p2pCommunicator.Enode(), metricsURL, adminURL)
 
-	if err := p2pCommunicator.Start(); err != nil {
-		return err
-	}
-	defer p2pCommunicator.Stop()
-
-	if !ctx.Bool(disablePrunerFlag.Name) {
-		pruner := pruner.New(mainDB, repo)
-		defer func() { log.Info("stopping pruner..."); pruner.Stop() }()
+	if !p2pStarted {
+		if err := p2pCommunicator.Start(); err != nil {
+			return err
+		}
+		defer p2pCommunicator.Stop()
 	}
 
 	return node.New(
@@ -337,13 +572,37 @@ func soloAction(ctx *cli.Context) error {
 	}
 
 	var (
-		gene       *genesis.Genesis
-		forkConfig thor.ForkConfig
+		gene        *genesis.Genesis
+		forkConfig  thor.ForkConfig
+		forkedBlock *fork.Block
 	)
 
+	devnet, err := makeDevnetOptions(ctx)
+	if err != nil {
+		return err
+	}
+	soloBeneficiary, err := beneficiary(ctx)
+	if err != nil {
+		return err
+	}
+
 	flagGenesis := ctx.String(genesisFlag.Name)
-	if flagGenesis == "" {
-		gene = genesis.NewDevnet()
+	forkURL := ctx.String(forkURLFlag.Name)
+	if forkURL != "" {
+		if flagGenesis != "" {
+			return fmt.Errorf("flag %s and %s are exclusive", genesisFlag.Name, forkURLFlag.Name)
+		}
+		if forkedBlock, err = fork.ResolveBlock(forkURL, ctx.String(forkBlockFlag.Name)); err != nil {
+			return err
+		}
+		// the instance dir depends on the genesis ID, so the database is not opened yet
+		fallback := fork.NewState(forkURL, forkedBlock, muxdb.NewMem())
+		if gene, err = genesis.NewFork(forkedBlock.ID, forkedBlock.Timestamp, forkedBlock.GasLimit, fallback); err != nil {
+			return err
+		}
+		forkConfig = thor.SoloFork
+	} else if flagGenesis == "" {
+		gene = genesis.NewDevnetWithOptions(thor.SoloFork, devnet)
 		forkConfig = thor.SoloFork
 	} else {
 		gene, forkConfig, err = parseGenesisFile(flagGenesis)
@@ -378,28 +637,19 @@ func soloAction(ctx *cli.Context) error {
 		logDB = openMemLogDB()
 	}
 
-	repo, err := initChainRepository(gene, mainDB, logDB)
+	stater := state.NewStater(mainDB)
+	if forkedBlock != nil {
+		stater = state.NewStaterWithFallback(mainDB, fork.NewState(forkURL, forkedBlock, mainDB))
+		log.Info("forked from remote chain", "url", forkURL, "number", forkedBlock.Number, "id", forkedBlock.ID)
+	}
+
+	repo, err := initChainRepository(gene, stater, mainDB, logDB)
 	if err != nil {
 		return err
 	}
 
-	adminURL := ""
 	logAPIRequests := &atomic.Bool{}
 	logAPIRequests.Store(ctx.Bool(enableAPILogsFlag.Name))
-	if ctx.Bool(enableAdminFlag.Name) {
-		url, closeFunc, err := api.StartAdminServer(
-			ctx.String(adminAddrFlag.Name),
-			logLevel,
-			repo,
-			nil,
-			logAPIRequests,
-		)
-		if err != nil {
-			return fmt.Errorf("unable to start admin server - %w", err)
-		}
-		adminURL = url
-		defer func() { log.Info("stopping admin server..."); closeFunc() }()
-	}
 
 	printStartupMessage1(gene, repo, nil, instanceDir, forkConfig)
 
@@ -411,6 +661,7 @@ func soloAction(ctx *cli.Context) error {
 	}
 
 	txPoolOption := defaultTxPoolOptions
+	txPoolOption.BlockInterval = forkConfig.BlockInterval()
 	txPoolOption.Limit, err = readIntFromUInt64Flag(ctx.Uint64(txPoolLimitFlag.Name))
 	if err != nil {
 		return errors.Wrap(err, "parse txpool-limit flag")
@@ -420,20 +671,31 @@ func soloAction(ctx *cli.Context) error {
 		return errors.Wrap(err, "parse txpool-limit-per-account flag")
 	}
 
-	txPool := txpool.New(repo, state.NewStater(mainDB), txPoolOption)
+	txPool := txpool.New(repo, stater, txPoolOption)
 	defer func() { log.Info("closing tx pool..."); txPool.Close() }()
 
 	bftEngine := solo.NewBFTEngine(repo)
 
+	apiConfig := makeAPIConfig(ctx, logAPIRequests, true)
+	if !ctx.Bool(disablePrunerFlag.Name) {
+		retention, err := parsePrunerRetention(ctx.String(prunerRetentionFlag.Name), forkConfig.BlockInterval())
+		if err != nil {
+			return errors.WithMessage(err, prunerRetentionFlag.Name)
+		}
+		pruner := pruner.New(mainDB, repo, retention)
+		defer func() { log.Info("stopping pruner..."); pruner.Stop() }()
+		apiConfig.StateHistory = pruner
+	}
+
 	apiHandler, apiCloser := api.New(
 		repo,
-		state.NewStater(mainDB),
+		stater,
 		txPool,
 		logDB,
 		bftEngine,
 		&solo.Communicator{},
 		forkConfig,
-		makeAPIConfig(ctx, logAPIRequests, true),
+		apiConfig,
 	)
 	defer func() { log.Info("closing API..."); apiCloser() }()
 
@@ -451,22 +713,51 @@ func soloAction(ctx *cli.Context) error {
 		return errors.New("block-interval cannot be zero")
 	}
 
-	printStartupMessage2(gene, apiURL, "", metricsURL, adminURL)
-
-	if !ctx.Bool(disablePrunerFlag.Name) {
-		pruner := pruner.New(mainDB, repo)
-		defer func() { log.Info("stopping pruner..."); pruner.Stop() }()
-	}
-
-	return solo.New(repo,
-		state.NewStater(mainDB),
+	soloNode := solo.New(repo,
+		stater,
 		logDB,
 		txPool,
 		ctx.Uint64(gasLimitFlag.Name),
 		onDemandBlockProduction,
 		skipLogs,
 		blockProductionInterval,
-		forkConfig).Run(exitSignal)
+		forkConfig)
+	soloNode.SetDevnet(devnet, soloBeneficiary)
+	soloNode.SetForked(forkedBlock != nil)
+	if mode := ctx.String(traceTxsFlag.Name); mode != "" {
+		if mode != "reverted" && mode != "all" {
+			return fmt.Errorf("invalid %s: %q, should be reverted or all", traceTxsFlag.Name, mode)
+		}
+		if err := soloNode.SetTracing(solo.TraceOptions{
+			Tracer: ctx.String(traceTracerFlag.Name),
+			All:    mode == "all",
+			Blocks: ctx.Int(traceBlocksFlag.Name),
+		}); err != nil {
+			return errors.WithMessage(err, "trace txs")
+		}
+	}
+
+	adminURL := ""
+	if ctx.Bool(enableAdminFlag.Name) {
+		url, closeFunc, err := api.StartAdminServer(
+			ctx.String(adminAddrFlag.Name),
+			logLevel,
+			repo,
+			nil,
+			forkConfig.BlockInterval(),
+			logAPIRequests,
+			soloNode,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to start admin server - %w", err)
+		}
+		adminURL = url
+		defer func() { log.Info("stopping admin server..."); closeFunc() }()
+	}
+
+	printStartupMessage2(gene, apiURL, "", metricsURL, adminURL)
+
+	return soloNode.Run(exitSignal)
 }
 
 func masterKeyAction(ctx *cli.Context) error {
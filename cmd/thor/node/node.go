@@ -165,7 +165,7 @@ func (n *Node) houseKeeping(ctx context.Context) {
 	newBlockCh := make(chan *comm.NewBlockEvent)
 	scope.Track(n.comm.SubscribeBlock(newBlockCh))
 
-	futureTicker := time.NewTicker(time.Duration(thor.BlockInterval) * time.Second)
+	futureTicker := time.NewTicker(time.Duration(n.forkConfig.BlockInterval()) * time.Second)
 	defer futureTicker.Stop()
 
 	connectivityTicker := time.NewTicker(time.Second)
@@ -220,7 +220,7 @@ func (n *Node) houseKeeping(ctx context.Context) {
 				noPeerTimes++
 				if noPeerTimes > 30 {
 					noPeerTimes = 0
-					go checkClockOffset()
+					go checkClockOffset(n.forkConfig.BlockInterval())
 				}
 			} else {
 				noPeerTimes = 0
@@ -514,13 +514,13 @@ side-chain:   %v  %v`,
 	}
 }
 
-func checkClockOffset() {
+func checkClockOffset(blockInterval uint64) {
 	resp, err := ntp.Query("pool.ntp.org")
 	if err != nil {
 		logger.Debug("failed to access NTP", "err", err)
 		return
 	}
-	if resp.ClockOffset > time.Duration(thor.BlockInterval)*time.Second/2 {
+	if resp.ClockOffset > time.Duration(blockInterval)*time.Second/2 {
 		logger.Warn("clock offset detected", "offset", common.PrettyDuration(resp.ClockOffset))
 	}
 }
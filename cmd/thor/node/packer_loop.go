@@ -15,7 +15,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/log"
 	"github.com/vechain/thor/v2/packer"
-	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -72,7 +71,7 @@ func (n *Node) packerLoop(ctx context.Context) {
 		logger.Debug("scheduled to pack block", "after", time.Duration(flow.When()-now)*time.Second)
 
 		for {
-			if uint64(time.Now().Unix())+thor.BlockInterval/2 > flow.When() {
+			if uint64(time.Now().Unix())+n.forkConfig.BlockInterval()/2 > flow.When() {
 				// time to pack block
 				// blockInterval/2 early to allow more time for processing txs
 				if err := n.pack(flow); err != nil {
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -31,21 +32,29 @@ const (
 
 // Pruner is a background task to prune tries.
 type Pruner struct {
-	db     *muxdb.MuxDB
-	repo   *chain.Repository
-	ctx    context.Context
-	cancel func()
-	goes   co.Goes
+	db        *muxdb.MuxDB
+	repo      *chain.Repository
+	retention uint32
+	oldest    atomic.Uint32
+	ctx       context.Context
+	cancel    func()
+	goes      co.Goes
 }
 
-// New creates and starts the pruner.
-func New(db *muxdb.MuxDB, repo *chain.Repository) *Pruner {
+// New creates and starts the pruner, which keeps states of at least the latest retention blocks.
+// The retention is raised to thor.MaxStateHistory if less, which is required by the EVM.
+func New(db *muxdb.MuxDB, repo *chain.Repository, retention uint32) *Pruner {
 	ctx, cancel := context.WithCancel(context.Background())
 	o := &Pruner{
-		db:     db,
-		repo:   repo,
-		ctx:    ctx,
-		cancel: cancel,
+		db:        db,
+		repo:      repo,
+		retention: max(retention, thor.MaxStateHistory),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	// load the status to answer OldestState before the loop starts, failures are reported by the loop
+	if oldest, err := LoadOldestState(db); err == nil {
+		o.oldest.Store(oldest)
 	}
 	o.goes.Go(func() {
 		if err := o.loop(); err != nil {
@@ -57,6 +66,69 @@ func New(db *muxdb.MuxDB, repo *chain.Repository) *Pruner {
 	return o
 }
 
+// Rebase prunes tries before the given block, as if the pruner had processed up to the block.
+// It's for databases restored without replaying blocks, in which states before the block are incomplete.
+// It must be called before the pruner is created.
+func Rebase(ctx context.Context, db *muxdb.MuxDB, repo *chain.Repository, summary *chain.BlockSummary) error {
+	target := summary.Header.Number() + 1
+	p := &Pruner{db: db, repo: repo, ctx: ctx}
+	if err := p.pruneTries(repo.NewChain(summary.Header.ID()), 0, target); err != nil {
+		return err
+	}
+	return saveBase(db, target)
+}
+
+// MarkBase records the given block as processed by the pruner, without pruning tries.
+// It's for databases restored without replaying blocks, whose history nodes are kept, so that
+// the incomplete states before the block are neither reported available nor visited by the pruner.
+func MarkBase(db *muxdb.MuxDB, summary *chain.BlockSummary) error {
+	return saveBase(db, summary.Header.Number()+1)
+}
+
+// Rewind resets the status for the chain rewound to the given block, so that the pruner continues
+// no later than the block, and history nodes of blocks imported again after it are pruned.
+// It must be called before the pruner is created.
+func Rewind(db *muxdb.MuxDB, num uint32) error {
+	var (
+		status     status
+		propsStore = db.NewStore(propsStoreName)
+	)
+	if err := status.Load(propsStore); err != nil {
+		return errors.Wrap(err, "load status")
+	}
+	if status.Base <= num+1 {
+		return nil
+	}
+	status.Base = num + 1
+	return status.Save(propsStore)
+}
+
+func saveBase(db *muxdb.MuxDB, base uint32) error {
+	var (
+		status     status
+		propsStore = db.NewStore(propsStoreName)
+	)
+	if err := status.Load(propsStore); err != nil {
+		return errors.Wrap(err, "load status")
+	}
+	status.Base = base
+	return status.Save(propsStore)
+}
+
+// LoadOldestState returns the number of the oldest block whose state is available, according to the saved status.
+func LoadOldestState(db *muxdb.MuxDB) (uint32, error) {
+	var status status
+	if err := status.Load(db.NewStore(propsStoreName)); err != nil {
+		return 0, errors.Wrap(err, "load status")
+	}
+	return status.OldestState(), nil
+}
+
+// OldestState returns the number of the oldest block whose state is available.
+func (p *Pruner) OldestState() uint32 {
+	return p.oldest.Load()
+}
+
 // Stop stops the pruner.
 func (p *Pruner) Stop() {
 	p.cancel()
@@ -74,6 +146,23 @@ func (p *Pruner) loop() error {
 	if err := status.Load(propsStore); err != nil {
 		return errors.Wrap(err, "load status")
 	}
+	if status.Retention != p.retention {
+		prev := status.Retention
+		if prev == 0 {
+			// saved before retention is configurable
+			prev = thor.MaxStateHistory
+		}
+		if prev != p.retention && status.Base > 0 {
+			logger.Info("retention changed", "from", prev, "to", p.retention)
+		}
+		if prev < p.retention && status.Base > 0 {
+			logger.Warn("pruned states are not recovered by raising retention", "oldest", status.OldestState())
+		}
+		status.Retention = p.retention
+		if err := status.Save(propsStore); err != nil {
+			return errors.Wrap(err, "save status")
+		}
+	}
 
 	for {
 		period := uint32(65536)
@@ -85,12 +174,15 @@ func (p *Pruner) loop() error {
 		// select target
 		target := status.Base + period
 
-		targetChain, err := p.awaitUntilSteady(target + thor.MaxStateHistory)
+		targetChain, err := p.awaitUntilSteady(target + p.retention)
 		if err != nil {
 			return errors.Wrap(err, "awaitUntilSteady")
 		}
 		startTime := time.Now().UnixNano()
 
+		// states before the target become unavailable once pruning starts
+		p.oldest.Store(target - 1)
+
 		// prune index/account/storage tries
 		if err := p.pruneTries(targetChain, status.Base, target); err != nil {
 			return errors.Wrap(err, "prune tries")
@@ -121,6 +213,10 @@ func (p *Pruner) newStorageTrieIfUpdated(accLeaf *trie.Leaf, base uint32) *muxdb
 	if err := rlp.DecodeBytes(accLeaf.Value, &acc); err != nil {
 		panic(errors.Wrap(err, "decode account"))
 	}
+	// metadata of forked accounts is kept without storage
+	if len(acc.StorageRoot) == 0 {
+		return nil
+	}
 
 	if err := rlp.DecodeBytes(accLeaf.Meta, &meta); err != nil {
 		panic(errors.Wrap(err, "decode account metadata"))
@@ -99,6 +99,35 @@ func TestStatus(t *testing.T) {
 	err = s2.Load(store)
 	assert.Nil(t, err, "load should not error")
 	assert.Equal(t, uint32(1), s.Base)
+
+	assert.Equal(t, uint32(0), (&status{}).OldestState())
+	assert.Equal(t, uint32(8191), (&status{Base: 8192}).OldestState())
+
+	// status saved before retention is configurable
+	assert.Nil(t, store.Put([]byte(statusKey), []byte(`{"Base":8192}`)))
+	s3 := &status{}
+	assert.Nil(t, s3.Load(store))
+	assert.Equal(t, status{Base: 8192}, *s3)
+}
+
+func TestRewind(t *testing.T) {
+	db := muxdb.NewMem()
+
+	oldest, err := LoadOldestState(db)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), oldest)
+
+	assert.Nil(t, saveBase(db, 100))
+	// rewound after the base, left unchanged
+	assert.Nil(t, Rewind(db, 200))
+	oldest, err = LoadOldestState(db)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(99), oldest)
+
+	assert.Nil(t, Rewind(db, 50))
+	oldest, err = LoadOldestState(db)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(50), oldest)
 }
 
 func TestNewPruner(t *testing.T) {
@@ -108,7 +137,22 @@ func TestNewPruner(t *testing.T) {
 	b0, _, _, _ := gene.Build(stater)
 	repo, _ := chain.NewRepository(db, b0)
 
-	pr := New(db, repo)
+	pr := New(db, repo, 0)
+	assert.Equal(t, uint32(thor.MaxStateHistory), pr.retention)
+	assert.Equal(t, uint32(0), pr.OldestState())
+	pr.Stop()
+
+	// the retention is saved into status
+	var s status
+	assert.Nil(t, s.Load(db.NewStore(propsStoreName)))
+	assert.Equal(t, uint32(thor.MaxStateHistory), s.Retention)
+
+	s.Base = 65536
+	assert.Nil(t, s.Save(db.NewStore(propsStoreName)))
+
+	pr = New(db, repo, 100000)
+	assert.Equal(t, uint32(100000), pr.retention)
+	assert.Equal(t, uint32(65535), pr.OldestState())
 	pr.Stop()
 }
 
@@ -12,7 +12,17 @@ import (
 )
 
 type status struct {
-	Base uint32
+	Base      uint32
+	Retention uint32 `json:",omitempty"` // zero for status saved before retention is configurable
+}
+
+// OldestState returns the number of the oldest block whose state is available.
+// Tries are pruned in [0, Base), with the state of block Base-1 checkpointed.
+func (s *status) OldestState() uint32 {
+	if s.Base == 0 {
+		return 0
+	}
+	return s.Base - 1
 }
 
 func (s *status) Load(getter kv.Getter) error {
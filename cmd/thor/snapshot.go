@@ -0,0 +1,162 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/snapshot"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/state"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func exportSnapshotAction(ctx *cli.Context) error {
+	// the archive is written to stdout if no output specified
+	if ctx.String(outputFlag.Name) != "" {
+		initLogger(log.LegacyLevelInfo, false)
+	}
+
+	gene, forkConfig, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(instanceDir, "main.db")); err != nil {
+		return fmt.Errorf("main database not found in %v", instanceDir)
+	}
+
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	defer func() { log.Info("closing main database..."); mainDB.Close() }()
+
+	genesisBlock, _, _, err := gene.Build(state.NewStater(mainDB))
+	if err != nil {
+		return errors.Wrap(err, "build genesis block")
+	}
+	repo, err := chain.NewRepository(mainDB, genesisBlock)
+	if err != nil {
+		return err
+	}
+	freezer, err := openFreezer(instanceDir, repo, false)
+	if err != nil {
+		return err
+	}
+	if freezer != nil {
+		defer freezer.Close()
+	}
+
+	export := func(w io.Writer) error {
+		start := time.Now()
+		id, err := snapshot.Export(handleExitSignal(), w, mainDB, repo, forkConfig)
+		if err != nil {
+			return errors.WithMessage(err, "export snapshot")
+		}
+		log.Info("snapshot exported", "block", block.Number(id), "id", id, "elapsed", time.Since(start).Round(time.Second))
+		return nil
+	}
+
+	output := ctx.String(outputFlag.Name)
+	if output == "" {
+		return export(os.Stdout)
+	}
+
+	// the archive appears at the output path only if completed
+	tmp := output + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	if err := export(bw); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, output)
+}
+
+func importSnapshotAction(ctx *cli.Context) (err error) {
+	initLogger(log.LegacyLevelInfo, false)
+
+	input := ctx.Args().First()
+	if input == "" {
+		return errors.New("snapshot file not specified")
+	}
+	gene, forkConfig, err := selectGenesis(ctx)
+	if err != nil {
+		return err
+	}
+	instanceDir, err := makeInstanceDir(ctx, gene)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{"main.db", "logs.db", "ancient"} {
+		if _, err := os.Stat(filepath.Join(instanceDir, name)); err == nil {
+			return fmt.Errorf("%v exists in %v, snapshot can only be imported into an empty instance", name, instanceDir)
+		}
+	}
+
+	f, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	mainDB, err := openMainDB(ctx, instanceDir)
+	if err != nil {
+		return err
+	}
+	logDB, err := openLogDB(instanceDir)
+	if err != nil {
+		mainDB.Close()
+		return err
+	}
+	// remove the partially imported databases
+	defer func() {
+		mainDB.Close()
+		logDB.Close()
+		if err != nil {
+			os.RemoveAll(filepath.Join(instanceDir, "main.db"))
+			os.RemoveAll(filepath.Join(instanceDir, "logs.db"))
+		}
+	}()
+
+	repo, err := initChainRepository(gene, state.NewStater(mainDB), mainDB, logDB)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	log.Info("importing snapshot", "file", input, "instance", instanceDir)
+	id, err := snapshot.Import(handleExitSignal(), bufio.NewReader(f), mainDB, repo, forkConfig, !ctx.Bool(disablePrunerFlag.Name))
+	if err != nil {
+		return errors.WithMessage(err, "import snapshot")
+	}
+	log.Info("snapshot imported", "block", block.Number(id), "id", id, "elapsed", time.Since(start).Round(time.Second))
+	fmt.Println("Logs are rebuilt from blocks when the node starts, which may take a while")
+	return nil
+}
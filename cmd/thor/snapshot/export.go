@@ -0,0 +1,238 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package snapshot exports the chain and the state at the finalized block into an archive,
+// from which a new database can be restored without replaying all blocks.
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+var logger = log.WithContext("pkg", "snapshot")
+
+// Export writes the snapshot of the finalized block into w, and returns the id of the block.
+//
+// Besides blocks, the snapshot contains the state at the parent of the finalized block,
+// which is the state the bft engine requires to justify the round started by the finalized block.
+// The state of the finalized block itself is rebuilt by executing the block while importing.
+// The database must not be written by others while exporting.
+func Export(ctx context.Context, w io.Writer, db *muxdb.MuxDB, repo *chain.Repository, forkConfig thor.ForkConfig) (thor.Bytes32, error) {
+	bftEngine, err := bft.NewEngine(repo, db, forkConfig, thor.Address{})
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	finalized := bftEngine.Finalized()
+	if block.Number(finalized) == 0 {
+		return thor.Bytes32{}, errors.New("no block finalized yet")
+	}
+
+	finalizedChain := repo.NewChain(finalized)
+	parent, err := finalizedChain.GetBlockSummary(block.Number(finalized) - 1)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+
+	sw, err := newWriter(w)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	if err := sw.write(kindHeader, &header{
+		Version:   formatVersion,
+		GenesisID: repo.GenesisBlock().Header().ID(),
+		BlockID:   finalized,
+	}); err != nil {
+		return thor.Bytes32{}, err
+	}
+
+	logger.Info("exporting blocks", "finalized", block.Number(finalized))
+	if err := exportBlocks(ctx, sw, repo, finalizedChain); err != nil {
+		return thor.Bytes32{}, err
+	}
+	logger.Info("exporting state", "block", parent.Header.Number())
+	if err := exportState(ctx, sw, db, parent); err != nil {
+		return thor.Bytes32{}, err
+	}
+	if err := exportQualities(sw, db, finalizedChain, forkConfig, parent.Header.Number()); err != nil {
+		return thor.Bytes32{}, err
+	}
+	if err := sw.close(); err != nil {
+		return thor.Bytes32{}, err
+	}
+	return finalized, nil
+}
+
+func exportBlocks(ctx context.Context, sw *writer, repo *chain.Repository, c *chain.Chain) error {
+	progress := newProgress()
+	head := block.Number(c.HeadID())
+	for i := uint32(1); i <= head; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		summary, err := c.GetBlockSummary(i)
+		if err != nil {
+			return err
+		}
+		blk, err := repo.GetBlock(summary.Header.ID())
+		if err != nil {
+			return err
+		}
+		receipts, err := repo.GetBlockReceipts(summary.Header.ID())
+		if err != nil {
+			return err
+		}
+		if err := sw.write(kindBlock, &blockRecord{blk, receipts, summary.Conflicts}); err != nil {
+			return err
+		}
+		progress.log("exporting blocks", "block", i)
+	}
+	return nil
+}
+
+func exportState(ctx context.Context, sw *writer, db *muxdb.MuxDB, summary *chain.BlockSummary) error {
+	var (
+		progress  = newProgress()
+		codeStore = db.NewStore(state.CodeStoreName)
+		codes     = make(map[thor.Bytes32]struct{})
+		accounts  uint64
+		slots     uint64
+	)
+
+	accTrie := db.NewTrie(state.AccountTrieName, summary.Root())
+	accTrie.SetNoFillCache(true)
+
+	it := trie.NewIterator(accTrie.NodeIterator(nil, 0))
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := sw.write(kindAccount, &leafRecord{it.Key, it.Value, it.Meta}); err != nil {
+			return err
+		}
+		accounts++
+
+		var acc state.Account
+		if err := rlp.DecodeBytes(it.Value, &acc); err != nil {
+			return fmt.Errorf("decode account %x: %w", it.Key, err)
+		}
+
+		if len(acc.StorageRoot) > 0 {
+			var meta state.AccountMetadata
+			if err := rlp.DecodeBytes(it.Meta, &meta); err != nil {
+				return fmt.Errorf("decode account metadata %x: %w", it.Key, err)
+			}
+			if meta.Forked {
+				return fmt.Errorf("account %x has storage in the forked state, which can not be exported", it.Key)
+			}
+			n, err := exportStorage(ctx, sw, db, &acc, &meta)
+			if err != nil {
+				return err
+			}
+			slots += n
+		}
+
+		if len(acc.CodeHash) > 0 {
+			hash := thor.BytesToBytes32(acc.CodeHash)
+			if _, ok := codes[hash]; !ok {
+				code, err := codeStore.Get(acc.CodeHash)
+				if err != nil {
+					return fmt.Errorf("get code %v: %w", hash, err)
+				}
+				if err := sw.write(kindCode, &codeRecord{hash, code}); err != nil {
+					return err
+				}
+				codes[hash] = struct{}{}
+			}
+		}
+		progress.log("exporting state", "accounts", accounts, "slots", slots)
+	}
+	if err := it.Err; err != nil {
+		if trie.IsMissingNodeError(err) {
+			return fmt.Errorf("state of block #%d is not available, it might be pruned: %w", summary.Header.Number(), err)
+		}
+		return err
+	}
+	logger.Info("state exported", "accounts", accounts, "slots", slots, "codes", len(codes))
+	return nil
+}
+
+func exportStorage(ctx context.Context, sw *writer, db *muxdb.MuxDB, acc *state.Account, meta *state.AccountMetadata) (uint64, error) {
+	sTrie := db.NewTrie(state.StorageTrieName(meta.StorageID), trie.Root{
+		Hash: thor.BytesToBytes32(acc.StorageRoot),
+		Ver: trie.Version{
+			Major: meta.StorageMajorVer,
+			Minor: meta.StorageMinorVer,
+		},
+	})
+	sTrie.SetNoFillCache(true)
+
+	var n uint64
+	it := trie.NewIterator(sTrie.NodeIterator(nil, 0))
+	for it.Next() {
+		if n%10000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		if err := sw.write(kindStorage, &leafRecord{it.Key, it.Value, it.Meta}); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return n, it.Err
+}
+
+// exportQualities exports qualities of rounds concluded before the finalized block,
+// which are required to find checkpoints by quality.
+func exportQualities(sw *writer, db *muxdb.MuxDB, c *chain.Chain, forkConfig thor.ForkConfig, last uint32) error {
+	interval := forkConfig.CheckpointInterval()
+	for n := forkConfig.FINALITY/interval*interval + interval - 1; n <= last; n += interval {
+		id, err := c.GetBlockID(n)
+		if err != nil {
+			return err
+		}
+		quality, err := bft.LoadQuality(db, id)
+		if err != nil {
+			if db.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := sw.write(kindQuality, &qualityRecord{id, quality}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// progress logs progress at intervals.
+type progress struct {
+	last time.Time
+}
+
+func newProgress() *progress {
+	return &progress{last: time.Now()}
+}
+
+func (p *progress) log(msg string, ctx ...any) {
+	if time.Since(p.last) >= 10*time.Second {
+		p.last = time.Now()
+		logger.Info(msg, ctx...)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// The snapshot is a gzip compressed stream, which starts with the magic, followed by rlp encoded records:
+//
+//	header
+//	block * N          blocks from #1 to the finalized block
+//	account/storage *  leaves of the state trie at the parent of the finalized block, storage leaves follow their account
+//	code *             codes of contracts, interleaved with accounts
+//	quality *          bft qualities of rounds
+//	end                the checksum of all records above
+const (
+	magic         = "THORSNAP"
+	formatVersion = 1
+)
+
+type recordKind uint8
+
+const (
+	kindHeader recordKind = iota + 1
+	kindBlock
+	kindAccount
+	kindStorage
+	kindCode
+	kindQuality
+	kindEnd
+)
+
+type record struct {
+	Kind recordKind
+	Data rlp.RawValue
+}
+
+type header struct {
+	Version   uint
+	GenesisID thor.Bytes32
+	BlockID   thor.Bytes32 // the finalized block
+}
+
+type blockRecord struct {
+	Block     *block.Block
+	Receipts  tx.Receipts
+	Conflicts uint32
+}
+
+type leafRecord struct {
+	Key   []byte
+	Value []byte
+	Meta  []byte
+}
+
+type codeRecord struct {
+	Hash thor.Bytes32
+	Code []byte
+}
+
+type qualityRecord struct {
+	ID      thor.Bytes32
+	Quality uint32
+}
+
+type endRecord struct {
+	Checksum thor.Bytes32
+}
+
+// writer writes records and computes the checksum.
+type writer struct {
+	gz     *gzip.Writer
+	hasher hash.Hash
+}
+
+func newWriter(w io.Writer) (*writer, error) {
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return &writer{gz: gz, hasher: thor.NewBlake2b()}, nil
+}
+
+func (w *writer) write(kind recordKind, val any) error {
+	data, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	rec, err := rlp.EncodeToBytes(&record{kind, data})
+	if err != nil {
+		return err
+	}
+	w.hasher.Write(rec)
+	_, err = w.gz.Write(rec)
+	return err
+}
+
+// close writes the end record and flushes.
+func (w *writer) close() error {
+	var end endRecord
+	w.hasher.Sum(end.Checksum[:0])
+	data, err := rlp.EncodeToBytes(&end)
+	if err != nil {
+		return err
+	}
+	rec, err := rlp.EncodeToBytes(&record{kindEnd, data})
+	if err != nil {
+		return err
+	}
+	if _, err := w.gz.Write(rec); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// reader reads records and verifies the checksum at the end.
+type reader struct {
+	s      *rlp.Stream
+	hasher hash.Hash
+	ended  bool
+}
+
+func newReader(r io.Reader) (*reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a snapshot: %w", err)
+	}
+	br := bufio.NewReader(gz)
+	m := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, m); err != nil || string(m) != magic {
+		return nil, errors.New("not a snapshot: bad magic")
+	}
+	return &reader{s: rlp.NewStream(br, 0), hasher: thor.NewBlake2b()}, nil
+}
+
+// read reads the next record. It returns io.EOF after the end record read and verified.
+func (r *reader) read() (*record, error) {
+	if r.ended {
+		return nil, io.EOF
+	}
+	raw, err := r.s.Raw()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	var rec record
+	if err := rlp.DecodeBytes(raw, &rec); err != nil {
+		return nil, err
+	}
+	if rec.Kind != kindEnd {
+		r.hasher.Write(raw)
+		return &rec, nil
+	}
+
+	var end endRecord
+	if err := rlp.DecodeBytes(rec.Data, &end); err != nil {
+		return nil, err
+	}
+	if sum := thor.BytesToBytes32(r.hasher.Sum(nil)); sum != end.Checksum {
+		return nil, fmt.Errorf("checksum mismatch: want %v, have %v", end.Checksum, sum)
+	}
+	r.ended = true
+	return nil, io.EOF
+}
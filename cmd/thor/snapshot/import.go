@@ -0,0 +1,315 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// leaves updated before the trie is committed to release memory.
+const commitEvery = 100000
+
+// importer rebuilds the database from records.
+type importer struct {
+	ctx        context.Context
+	db         *muxdb.MuxDB
+	repo       *chain.Repository
+	forkConfig thor.ForkConfig
+	header     header
+	progress   *progress
+
+	correctRoots map[string]string // receipts roots of blocks whose headers are known incorrect
+
+	finalized *blockRecord        // the finalized block, executed after the state restored
+	parent    *chain.BlockSummary // parent of the finalized block, whose state is restored
+
+	accTrie   *muxdb.Trie
+	accounts  uint64
+	storage   *storageImport // storage trie of the current account
+	codeBulk  kv.Bulk
+	qualities int
+}
+
+// storageImport tracks the storage trie being rebuilt.
+type storageImport struct {
+	trie *muxdb.Trie
+	root thor.Bytes32
+	ver  trie.Version
+	n    uint64
+}
+
+// Import restores the snapshot from r into the database, which must contain only the genesis block.
+// Blocks are added with txs and receipts roots validated, the state root is validated against the header,
+// and then the finalized block is executed on the restored state.
+//
+// If prune is true, the restored tries are checkpointed and history nodes are deleted,
+// as if pruned by the pruner. Either way, the pruner continues from the finalized block.
+// It returns the id of the finalized block, which becomes the best block.
+func Import(ctx context.Context, r io.Reader, db *muxdb.MuxDB, repo *chain.Repository, forkConfig thor.ForkConfig, prune bool) (thor.Bytes32, error) {
+	if repo.BestBlockSummary().Header.Number() != 0 {
+		return thor.Bytes32{}, errors.New("database is not empty")
+	}
+
+	sr, err := newReader(r)
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+
+	imp := &importer{
+		ctx:        ctx,
+		db:         db,
+		repo:       repo,
+		forkConfig: forkConfig,
+		progress:   newProgress(),
+		codeBulk:   db.NewStore(state.CodeStoreName).Bulk(),
+
+		correctRoots: thor.LoadCorrectReceiptsRoots(),
+	}
+	imp.codeBulk.EnableAutoFlush()
+
+	rec, err := sr.read()
+	if err != nil {
+		return thor.Bytes32{}, err
+	}
+	if rec.Kind != kindHeader {
+		return thor.Bytes32{}, errors.New("header expected")
+	}
+	if err := rlp.DecodeBytes(rec.Data, &imp.header); err != nil {
+		return thor.Bytes32{}, err
+	}
+	if imp.header.Version != formatVersion {
+		return thor.Bytes32{}, fmt.Errorf("unsupported snapshot version %d", imp.header.Version)
+	}
+	if imp.header.GenesisID != repo.GenesisBlock().Header().ID() {
+		return thor.Bytes32{}, fmt.Errorf("genesis mismatch: snapshot of %v, database of %v", imp.header.GenesisID, repo.GenesisBlock().Header().ID())
+	}
+
+	for {
+		rec, err := sr.read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return thor.Bytes32{}, err
+		}
+		if err := ctx.Err(); err != nil {
+			return thor.Bytes32{}, err
+		}
+		if err := imp.handle(rec); err != nil {
+			return thor.Bytes32{}, err
+		}
+	}
+	if err := imp.finish(prune); err != nil {
+		return thor.Bytes32{}, err
+	}
+	return imp.header.BlockID, nil
+}
+
+func (imp *importer) handle(rec *record) error {
+	switch rec.Kind {
+	case kindBlock:
+		var br blockRecord
+		if err := rlp.DecodeBytes(rec.Data, &br); err != nil {
+			return err
+		}
+		return imp.addBlock(&br)
+	case kindAccount, kindStorage:
+		var leaf leafRecord
+		if err := rlp.DecodeBytes(rec.Data, &leaf); err != nil {
+			return err
+		}
+		if imp.finalized == nil {
+			return errors.New("state before blocks")
+		}
+		if rec.Kind == kindAccount {
+			return imp.updateAccount(&leaf)
+		}
+		return imp.updateStorage(&leaf)
+	case kindCode:
+		var code codeRecord
+		if err := rlp.DecodeBytes(rec.Data, &code); err != nil {
+			return err
+		}
+		if thor.Keccak256(code.Code) != code.Hash {
+			return fmt.Errorf("code hash mismatch: %v", code.Hash)
+		}
+		return imp.codeBulk.Put(code.Hash[:], code.Code)
+	case kindQuality:
+		var q qualityRecord
+		if err := rlp.DecodeBytes(rec.Data, &q); err != nil {
+			return err
+		}
+		imp.qualities++
+		return bft.SaveQuality(imp.db, q.ID, q.Quality)
+	default:
+		return fmt.Errorf("unexpected record kind %d", rec.Kind)
+	}
+}
+
+func (imp *importer) addBlock(br *blockRecord) error {
+	if imp.finalized != nil {
+		return errors.New("block after the finalized block")
+	}
+	var (
+		header = br.Block.Header()
+		best   = imp.repo.BestBlockSummary().Header
+	)
+	if header.ParentID() != best.ID() {
+		return fmt.Errorf("block #%d is not a child of the previous block", header.Number())
+	}
+	if header.TxsRoot() != br.Block.Transactions().RootHash() {
+		return fmt.Errorf("txs root mismatch of block #%d", header.Number())
+	}
+	if root := br.Receipts.RootHash(); root != header.ReceiptsRoot() && imp.correctRoots[header.ID().String()] != root.String() {
+		return fmt.Errorf("receipts root mismatch of block #%d", header.Number())
+	}
+	if header.ID() == imp.header.BlockID {
+		// executed after the state restored
+		imp.finalized = br
+		imp.parent = imp.repo.BestBlockSummary()
+		return nil
+	}
+	if err := imp.repo.AddBlock(br.Block, br.Receipts, br.Conflicts, true); err != nil {
+		return err
+	}
+	imp.progress.log("importing blocks", "block", header.Number())
+	return nil
+}
+
+func (imp *importer) updateAccount(leaf *leafRecord) error {
+	if err := imp.commitStorage(); err != nil {
+		return err
+	}
+	if imp.accTrie == nil {
+		imp.accTrie = imp.db.NewTrie(state.AccountTrieName, trie.Root{})
+		imp.accTrie.SetNoFillCache(true)
+	}
+	if err := imp.accTrie.Update(leaf.Key, leaf.Value, leaf.Meta); err != nil {
+		return err
+	}
+	imp.accounts++
+	if imp.accounts%commitEvery == 0 {
+		if err := imp.accTrie.Commit(imp.parent.Root().Ver, false); err != nil {
+			return err
+		}
+	}
+
+	var acc state.Account
+	if err := rlp.DecodeBytes(leaf.Value, &acc); err != nil {
+		return fmt.Errorf("decode account %x: %w", leaf.Key, err)
+	}
+	if len(acc.StorageRoot) > 0 {
+		var meta state.AccountMetadata
+		if err := rlp.DecodeBytes(leaf.Meta, &meta); err != nil {
+			return fmt.Errorf("decode account metadata %x: %w", leaf.Key, err)
+		}
+		sTrie := imp.db.NewTrie(state.StorageTrieName(meta.StorageID), trie.Root{})
+		sTrie.SetNoFillCache(true)
+		imp.storage = &storageImport{
+			trie: sTrie,
+			root: thor.BytesToBytes32(acc.StorageRoot),
+			ver:  trie.Version{Major: meta.StorageMajorVer, Minor: meta.StorageMinorVer},
+		}
+	}
+	imp.progress.log("importing state", "accounts", imp.accounts)
+	return nil
+}
+
+func (imp *importer) updateStorage(leaf *leafRecord) error {
+	if imp.storage == nil {
+		return errors.New("storage without account")
+	}
+	if err := imp.storage.trie.Update(leaf.Key, leaf.Value, leaf.Meta); err != nil {
+		return err
+	}
+	imp.storage.n++
+	if imp.storage.n%commitEvery == 0 {
+		return imp.storage.trie.Commit(imp.storage.ver, false)
+	}
+	return nil
+}
+
+// commitStorage commits the storage trie of the current account, and validates its root.
+func (imp *importer) commitStorage() error {
+	s := imp.storage
+	if s == nil {
+		return nil
+	}
+	imp.storage = nil
+	if err := s.trie.Commit(s.ver, false); err != nil {
+		return err
+	}
+	if hash := s.trie.Hash(); hash != s.root {
+		return fmt.Errorf("storage root mismatch: want %v, have %v", s.root, hash)
+	}
+	return nil
+}
+
+// finish validates and commits the restored state, and executes the finalized block.
+func (imp *importer) finish(prune bool) error {
+	if imp.finalized == nil {
+		return errors.New("finalized block not found")
+	}
+	if imp.accTrie == nil {
+		return errors.New("state not found")
+	}
+	if err := imp.commitStorage(); err != nil {
+		return err
+	}
+	if err := imp.codeBulk.Write(); err != nil {
+		return err
+	}
+	if err := imp.accTrie.Commit(imp.parent.Root().Ver, false); err != nil {
+		return err
+	}
+	if hash, want := imp.accTrie.Hash(), imp.parent.Header.StateRoot(); hash != want {
+		return fmt.Errorf("state root mismatch of block #%d: want %v, have %v", imp.parent.Header.Number(), want, hash)
+	}
+	logger.Info("state imported", "block", imp.parent.Header.Number(), "accounts", imp.accounts, "qualities", imp.qualities)
+
+	if prune {
+		if err := imp.prune(); err != nil {
+			return err
+		}
+	} else if err := pruner.MarkBase(imp.db, imp.parent); err != nil {
+		return err
+	}
+
+	// execute the finalized block to produce its state
+	var (
+		blk  = imp.finalized.Block
+		cons = consensus.New(imp.repo, state.NewStater(imp.db), imp.forkConfig)
+	)
+	stage, receipts, err := cons.Process(imp.parent, blk, uint64(time.Now().Unix()), imp.finalized.Conflicts)
+	if err != nil {
+		return fmt.Errorf("execute block #%d: %w", blk.Header().Number(), err)
+	}
+	if _, err := stage.Commit(); err != nil {
+		return err
+	}
+	return imp.repo.AddBlock(blk, receipts, imp.finalized.Conflicts, true)
+}
+
+// prune moves the restored tries into deduped space, and deletes history nodes,
+// then the pruner continues from the finalized block.
+func (imp *importer) prune() error {
+	return pruner.Rebase(imp.ctx, imp.db, imp.repo, imp.parent)
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"github.com/vechain/thor/v2/tx"
+)
+
+var devAccounts = genesis.DevAccounts()
+
+func newGenesis() *genesis.Builder {
+	return new(genesis.Builder).
+		GasLimit(thor.InitialGasLimit).
+		Timestamp(1526400000).
+		State(func(state *state.State) error {
+			state.SetCode(builtin.Authority.Address, builtin.Authority.RuntimeBytecodes())
+			state.SetCode(builtin.Params.Address, builtin.Params.RuntimeBytecodes())
+			builtin.Params.Native(state).Set(thor.KeyMaxBlockProposers, big.NewInt(3))
+			for _, acc := range devAccounts {
+				state.SetBalance(acc.Address, big.NewInt(1e18))
+				state.SetEnergy(acc.Address, big.NewInt(1e18), 1526400000)
+				builtin.Authority.Native(state).Add(acc.Address, acc.Address, thor.Bytes32{})
+			}
+			return nil
+		})
+}
+
+type testChain struct {
+	db     *muxdb.MuxDB
+	repo   *chain.Repository
+	stater *state.Stater
+	fc     thor.ForkConfig
+}
+
+func newTestChain(t *testing.T, fc thor.ForkConfig) *testChain {
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	b0, _, _, err := newGenesis().Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, b0)
+	require.NoError(t, err)
+	return &testChain{db, repo, stater, fc}
+}
+
+// pack packs a block on the best block by the earliest scheduled proposer.
+func (c *testChain) pack(t *testing.T, txs ...*tx.Transaction) (*chain.BlockSummary, *packer.Flow, genesis.DevAccount) {
+	parent := c.repo.BestBlockSummary()
+	var (
+		flow     *packer.Flow
+		proposer genesis.DevAccount
+	)
+	for _, acc := range devAccounts {
+		f, err := packer.New(c.repo, c.stater, acc.Address, &acc.Address, c.fc).Schedule(parent, parent.Header.Timestamp()+c.fc.BlockInterval())
+		if err != nil {
+			// not a candidate
+			continue
+		}
+		if flow == nil || f.When() < flow.When() {
+			flow, proposer = f, acc
+		}
+	}
+	for _, trx := range txs {
+		require.NoError(t, flow.Adopt(trx))
+	}
+	return parent, flow, proposer
+}
+
+func (c *testChain) mint(t *testing.T, engine *bft.Engine, txs ...*tx.Transaction) {
+	_, flow, proposer := c.pack(t, txs...)
+	blk, stage, receipts, err := flow.Pack(proposer.PrivateKey, 0, true)
+	require.NoError(t, err)
+	_, err = stage.Commit()
+	require.NoError(t, err)
+	require.NoError(t, c.repo.AddBlock(blk, receipts, 0, true))
+	require.NoError(t, engine.CommitBlock(blk.Header(), false))
+}
+
+func newTx(t *testing.T, c *testChain) *tx.Transaction {
+	trx := new(tx.Builder).
+		ChainTag(c.repo.ChainTag()).
+		Expiration(math.MaxUint32).
+		Gas(21000).
+		Clause(tx.NewClause(&devAccounts[1].Address).WithValue(big.NewInt(1000))).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), devAccounts[0].PrivateKey)
+	require.NoError(t, err)
+	return trx.WithSignature(sig)
+}
+
+func TestExportImport(t *testing.T) {
+	fc := thor.NoFork
+	fc.FINALITY = 0
+	fc.Timing.CheckpointInterval = 10
+
+	src := newTestChain(t, fc)
+	engine, err := bft.NewEngine(src.repo, src.db, fc, thor.Address{})
+	require.NoError(t, err)
+
+	// nothing finalized
+	_, err = Export(context.Background(), &bytes.Buffer{}, src.db, src.repo, fc)
+	assert.EqualError(t, err, "no block finalized yet")
+
+	src.mint(t, engine, newTx(t, src))
+	for range 40 {
+		src.mint(t, engine)
+	}
+	finalized := engine.Finalized()
+	require.NotEqual(t, src.repo.GenesisBlock().Header().ID(), finalized)
+
+	var archive bytes.Buffer
+	id, err := Export(context.Background(), &archive, src.db, src.repo, fc)
+	require.NoError(t, err)
+	assert.Equal(t, finalized, id)
+
+	for _, prune := range []bool{false, true} {
+		dst := newTestChain(t, fc)
+		id, err := Import(context.Background(), bytes.NewReader(archive.Bytes()), dst.db, dst.repo, fc, prune)
+		require.NoError(t, err)
+		assert.Equal(t, finalized, id)
+
+		best := dst.repo.BestBlockSummary()
+		assert.Equal(t, finalized, best.Header.ID())
+
+		// the pruner continues from the finalized block
+		oldest, err := pruner.LoadOldestState(dst.db)
+		require.NoError(t, err)
+		assert.Equal(t, best.Header.Number()-1, oldest)
+
+		// receipts and tx index imported
+		srcChain := src.repo.NewChain(finalized)
+		dstChain := dst.repo.NewBestChain()
+		blk1, err := dstChain.GetBlock(1)
+		require.NoError(t, err)
+		txID := blk1.Transactions()[0].ID()
+		want, err := srcChain.GetTransactionReceipt(txID)
+		require.NoError(t, err)
+		have, err := dstChain.GetTransactionReceipt(txID)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Receipts{want}.RootHash(), tx.Receipts{have}.RootHash())
+
+		// states of the finalized block and its parent are available
+		for _, num := range []uint32{best.Header.Number() - 1, best.Header.Number()} {
+			summary, err := dstChain.GetBlockSummary(num)
+			require.NoError(t, err)
+			srcState := src.stater.NewState(summary.Root())
+			dstState := dst.stater.NewState(summary.Root())
+			for _, acc := range devAccounts {
+				b1, err := srcState.GetBalance(acc.Address)
+				require.NoError(t, err)
+				b2, err := dstState.GetBalance(acc.Address)
+				require.NoError(t, err)
+				assert.Equal(t, b1, b2)
+			}
+			code, err := dstState.GetCode(builtin.Authority.Address)
+			require.NoError(t, err)
+			assert.Equal(t, builtin.Authority.RuntimeBytecodes(), code)
+		}
+
+		// the chain continues with bft
+		dstEngine, err := bft.NewEngine(dst.repo, dst.db, fc, thor.Address{})
+		require.NoError(t, err)
+		for range 20 {
+			dst.mint(t, dstEngine)
+		}
+		// the genesis state is pruned
+		_, err = dst.stater.NewState(trie.Root{Hash: dst.repo.GenesisBlock().Header().StateRoot()}).GetBalance(devAccounts[0].Address)
+		if prune {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		// imports only into an empty database
+		_, err = Import(context.Background(), bytes.NewReader(archive.Bytes()), dst.db, dst.repo, fc, prune)
+		assert.EqualError(t, err, "database is not empty")
+	}
+
+	// corrupted
+	data := append([]byte{}, archive.Bytes()...)
+	data[len(data)/2] ^= 0xff
+	dst := newTestChain(t, fc)
+	_, err = Import(context.Background(), bytes.NewReader(data), dst.db, dst.repo, fc, false)
+	assert.Error(t, err)
+}
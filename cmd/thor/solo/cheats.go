@@ -0,0 +1,136 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/rand/v2"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// cheat is a change applied to the state of the next packed block, or an impersonated tx adopted
+// by it, before any tx from the pool.
+type cheat struct {
+	apply func(rt *runtime.Runtime) error // changes the state, nil if adopt is set
+	adopt func(flow *packer.Flow) error   // adopts an impersonated tx, nil if apply is set
+	done  func(err error)                 // called with the result of packing, optional
+}
+
+func (s *Solo) addCheat(c cheat) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cheats = append(s.cheats, c)
+}
+
+func (s *Solo) hasCheats() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.cheats) > 0
+}
+
+// SetBalance sets the VET balance of the account in the next packed block.
+func (s *Solo) SetBalance(addr thor.Address, balance *big.Int) {
+	s.addCheat(cheat{apply: func(rt *runtime.Runtime) error {
+		return rt.State().SetBalance(addr, balance)
+	}})
+}
+
+// SetEnergy sets the energy of the account in the next packed block.
+func (s *Solo) SetEnergy(addr thor.Address, energy *big.Int) {
+	s.addCheat(cheat{apply: func(rt *runtime.Runtime) error {
+		return rt.State().SetEnergy(addr, energy, rt.Context().Time)
+	}})
+}
+
+// SetCode sets the code of the account in the next packed block.
+func (s *Solo) SetCode(addr thor.Address, code []byte) {
+	s.addCheat(cheat{apply: func(rt *runtime.Runtime) error {
+		return rt.State().SetCode(addr, code)
+	}})
+}
+
+// SetStorage sets a storage slot of the account in the next packed block.
+func (s *Solo) SetStorage(addr thor.Address, key, value thor.Bytes32) {
+	s.addCheat(cheat{apply: func(rt *runtime.Runtime) error {
+		rt.State().SetStorage(addr, key, value)
+		return nil
+	}})
+}
+
+// Impersonate sends a tx of the clauses as the origin in the next packed block, without its private key.
+// The gas of the clauses is paid by the first dev account as the VIP-191 delegator. The tx is included
+// in the block body like any other tx, so that it has a receipt and logs, and the block can be replayed.
+// It waits until the block is packed, and returns outputs of the executed clauses.
+func (s *Solo) Impersonate(ctx context.Context, origin thor.Address, clauses []*tx.Clause, gas uint64) ([]*runtime.Output, error) {
+	var (
+		outputs []*runtime.Output
+		done    = make(chan error, 1)
+	)
+	s.addCheat(cheat{
+		adopt: func(flow *packer.Flow) error {
+			trx, err := s.newImpersonatedTx(origin, clauses, gas)
+			if err != nil {
+				return err
+			}
+			outputs, err = flow.AdoptWithOutputs(trx)
+			return errors.WithMessage(err, "adopt tx")
+		},
+		done: func(err error) {
+			done <- err
+		},
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+		return outputs, nil
+	}
+}
+
+// newImpersonatedTx builds a tx of the clauses with the given gas in addition to the intrinsic gas,
+// signed by the impersonated signature of the origin and delegated to the first dev account.
+func (s *Solo) newImpersonatedTx(origin thor.Address, clauses []*tx.Clause, gas uint64) (*tx.Transaction, error) {
+	intrinsicGas, err := tx.IntrinsicGas(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	if gas > math.MaxUint64-intrinsicGas {
+		return nil, errors.New("gas overflow")
+	}
+
+	var features tx.Features
+	features.SetDelegated(true)
+	builder := new(tx.Builder).ChainTag(s.repo.ChainTag())
+	for _, c := range clauses {
+		builder.Clause(c)
+	}
+	trx := builder.BlockRef(tx.NewBlockRef(0)).
+		Expiration(math.MaxUint32).
+		Nonce(rand.Uint64()). //#nosec G404
+		Gas(intrinsicGas + gas).
+		Features(features).
+		Build()
+
+	delegatorSig, err := crypto.Sign(trx.DelegatorSigningHash(origin).Bytes(), s.devnet.Accounts[0].PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return trx.WithSignature(append(tx.ImpersonatedSignature(origin), delegatorSig...)), nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Mine packs the executable txs in the pool into a new block immediately.
+// The block is packed even if there is no tx.
+func (s *Solo) Mine() (*block.Block, error) {
+	return s.packing(s.txPool.Executables(), false)
+}
+
+// MineEmpty packs n empty blocks immediately.
+func (s *Solo) MineEmpty(n int) ([]*block.Block, error) {
+	blocks := make([]*block.Block, 0, n)
+	for range n {
+		b, err := s.packing(nil, false)
+		if err != nil {
+			return blocks, err
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// IncreaseTime advances the timestamp of the following blocks by the given seconds.
+// It returns the total time offset.
+func (s *Solo) IncreaseTime(seconds uint64) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.timeOffset += seconds
+	logger.Info("time increased", "seconds", seconds, "offset", s.timeOffset)
+	return s.timeOffset
+}
+
+// Snapshot saves the best block and the time offset with the given name, an existing
+// snapshot with the same name is overwritten. It returns the summary of the best block.
+func (s *Solo) Snapshot(name string) (*chain.BlockSummary, error) {
+	if name == "" {
+		return nil, errors.New("empty snapshot name")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	best := s.repo.BestBlockSummary()
+	s.snapshots[name] = snapshot{id: best.Header.ID(), timeOffset: s.timeOffset}
+	logger.Info("snapshot taken", "name", name, "number", best.Header.Number(), "id", best.Header.ID())
+	return best, nil
+}
+
+// Revert rewinds the chain to the named snapshot, which sets the snapshot block as the
+// best block, deletes logs of the reverted blocks and restores the time offset.
+// Txs in the reverted blocks are dropped. The snapshot is kept for later reverts.
+func (s *Solo) Revert(name string) (*chain.BlockSummary, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snap, ok := s.snapshots[name]
+	if !ok {
+		return nil, errors.Errorf("snapshot %q not found", name)
+	}
+
+	best := s.repo.BestBlockSummary()
+	num := block.Number(snap.id)
+	if id, err := s.repo.NewChain(best.Header.ID()).GetBlockID(num); err != nil || id != snap.id {
+		return nil, errors.Errorf("snapshot %q is not on the canonical chain", name)
+	}
+
+	summary, err := s.repo.GetBlockSummary(snap.id)
+	if err != nil {
+		return nil, errors.WithMessage(err, "get snapshot block")
+	}
+	if _, err := s.stater.NewState(summary.Root()).Exists(thor.Address{}); err != nil {
+		return nil, errors.WithMessage(err, "state of snapshot "+name)
+	}
+
+	if !s.skipLogs {
+		w := s.logDB.NewWriter()
+		if err := w.Truncate(num + 1); err != nil {
+			return nil, errors.WithMessage(err, "truncate logs")
+		}
+		if err := w.Commit(); err != nil {
+			return nil, errors.WithMessage(err, "commit logs")
+		}
+	}
+
+	if err := s.repo.SetBestBlockID(snap.id); err != nil {
+		return nil, errors.WithMessage(err, "set best block")
+	}
+	s.timeOffset = snap.timeOffset
+
+	logger.Info("reverted to snapshot", "name", name, "number", num, "id", snap.id)
+	return summary, nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+// Package fork provides the state of a remote chain, which a solo chain is forked from.
+package fork
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	tccommon "github.com/vechain/thor/v2/thorclient/common"
+)
+
+const storeName = "solo.fork"
+
+// Block is the block of the remote chain that the local chain is forked from.
+type Block struct {
+	ID        thor.Bytes32
+	Number    uint32
+	Timestamp uint64
+	GasLimit  uint64
+}
+
+// State is the state of a remote chain at the forked block. It implements state.Fallback
+// by fetching accounts, code and storage through the remote REST API on first access,
+// and caches them in the local database.
+type State struct {
+	client *thorclient.Client
+	block  Block
+	store  kv.Store
+}
+
+var _ state.Fallback = (*State)(nil)
+
+// forkedAccount is the cached remote account.
+type forkedAccount struct {
+	Exists  bool
+	Balance *big.Int
+	Energy  *big.Int
+	Master  []byte
+	Code    []byte
+}
+
+// errRawStorageUnsupported is returned if the remote node omits raw storage values. Storage values encoded
+// by builtins are rlp lists or strings not fitting in 32 bytes, which can't be recovered from the plain values.
+var errRawStorageUnsupported = errors.New("the remote node does not support raw storage values, upgrade it to fork from")
+
+// ResolveBlock fetches the block of the remote node at url by the revision,
+// which can be a block number, ID or "best". It fails if the remote node does not support raw storage values.
+func ResolveBlock(url string, revision string) (*Block, error) {
+	client := thorclient.New(url)
+	b, err := client.Block(revision)
+	if err != nil {
+		if err == tccommon.ErrNotFound {
+			return nil, errors.Errorf("forked block %q not found", revision)
+		}
+		return nil, errors.WithMessage(err, "fetch forked block")
+	}
+
+	// nodes supporting it always respond raw values, even empty ones
+	res, err := client.AccountRawStorage(&builtin.Params.Address, &thor.KeyBaseGasPrice, thorclient.Revision(b.ID.String()))
+	if err != nil {
+		return nil, errors.WithMessage(err, "probe raw storage")
+	}
+	if res.Raw == "" {
+		return nil, errRawStorageUnsupported
+	}
+	return &Block{
+		ID:        b.ID,
+		Number:    b.Number,
+		Timestamp: b.Timestamp,
+		GasLimit:  b.GasLimit,
+	}, nil
+}
+
+// NewState creates the state of the forked block, which is read from the remote node at url.
+func NewState(url string, block *Block, db *muxdb.MuxDB) *State {
+	return &State{
+		client: thorclient.New(url),
+		block:  *block,
+		store:  db.NewStore(storeName),
+	}
+}
+
+// GetAccount implements state.Fallback.
+func (s *State) GetAccount(addr thor.Address) (*state.Account, []byte, error) {
+	key := append([]byte("a"), addr[:]...)
+
+	var fa forkedAccount
+	if data, err := s.store.Get(key); err == nil {
+		if err := rlp.DecodeBytes(data, &fa); err != nil {
+			return nil, nil, err
+		}
+	} else if s.store.IsNotFound(err) {
+		if fa, err = s.fetchAccount(addr); err != nil {
+			return nil, nil, errors.WithMessage(err, "fork: fetch account "+addr.String())
+		}
+		data, err := rlp.EncodeToBytes(&fa)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.store.Put(key, data); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		return nil, nil, err
+	}
+
+	if !fa.Exists {
+		return nil, nil, nil
+	}
+	return &state.Account{
+		Balance:   fa.Balance,
+		Energy:    fa.Energy,
+		BlockTime: s.block.Timestamp,
+		Master:    fa.Master,
+	}, fa.Code, nil
+}
+
+// GetRawStorage implements state.Fallback.
+func (s *State) GetRawStorage(addr thor.Address, key thor.Bytes32) (rlp.RawValue, error) {
+	storeKey := append(append([]byte("s"), addr[:]...), key[:]...)
+
+	data, err := s.store.Get(storeKey)
+	if err == nil {
+		return data, nil
+	}
+	if !s.store.IsNotFound(err) {
+		return nil, err
+	}
+
+	if data, err = s.fetchStorage(addr, key); err != nil {
+		return nil, errors.WithMessage(err, "fork: fetch storage "+addr.String()+" "+key.String())
+	}
+	// empty value is saved as well, so that zero values are not fetched again
+	if err := s.store.Put(storeKey, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *State) fetchAccount(addr thor.Address) (forkedAccount, error) {
+	rev := thorclient.Revision(s.block.ID.String())
+
+	acc, err := s.client.Account(&addr, rev)
+	if err != nil {
+		return forkedAccount{}, err
+	}
+
+	var code []byte
+	if acc.HasCode {
+		res, err := s.client.AccountCode(&addr, rev)
+		if err != nil {
+			return forkedAccount{}, err
+		}
+		if code, err = hexutil.Decode(res.Code); err != nil {
+			return forkedAccount{}, errors.WithMessage(err, "decode code")
+		}
+	}
+
+	// master is not exposed by the accounts API, read it through the prototype contract
+	method, _ := builtin.Prototype.ABI.MethodByName("master")
+	input, err := method.EncodeInput(addr)
+	if err != nil {
+		return forkedAccount{}, err
+	}
+	results, err := s.client.InspectClauses(&accounts.BatchCallData{
+		Clauses: accounts.Clauses{{To: &builtin.Prototype.Address, Data: hexutil.Encode(input)}},
+	}, rev)
+	if err != nil {
+		return forkedAccount{}, err
+	}
+	if len(results) != 1 || results[0].Reverted {
+		return forkedAccount{}, errors.New("failed to call prototype master")
+	}
+	output, err := hexutil.Decode(results[0].Data)
+	if err != nil {
+		return forkedAccount{}, errors.WithMessage(err, "decode master")
+	}
+	var master common.Address
+	if err := method.DecodeOutput(output, &master); err != nil {
+		return forkedAccount{}, errors.WithMessage(err, "decode master")
+	}
+
+	fa := forkedAccount{
+		Balance: (*big.Int)(&acc.Balance),
+		Energy:  (*big.Int)(&acc.Energy),
+		Code:    code,
+	}
+	if !thor.Address(master).IsZero() {
+		fa.Master = master.Bytes()
+	}
+	fa.Exists = fa.Balance.Sign() != 0 || fa.Energy.Sign() != 0 || len(fa.Master) > 0 || len(fa.Code) > 0
+	return fa, nil
+}
+
+func (s *State) fetchStorage(addr thor.Address, key thor.Bytes32) (rlp.RawValue, error) {
+	res, err := s.client.AccountRawStorage(&addr, &key, thorclient.Revision(s.block.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+	// checked by ResolveBlock, but the remote node might be replaced since then
+	if res.Raw == "" {
+		return nil, errRawStorageUnsupported
+	}
+	return hexutil.Decode(res.Raw)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/api/accounts"
+	"github.com/vechain/thor/v2/api/blocks"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/solo/fork"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+// newRemoteNode serves the API of a solo chain, as a stand-in for a remote node.
+func newRemoteNode(t *testing.T, remote *Solo) (*httptest.Server, *atomic.Int64) {
+	router := mux.NewRouter()
+	accounts.New(remote.repo, remote.stater, 10_000_000, thor.SoloFork, NewBFTEngine(remote.repo), false, nil).
+		Mount(router, "/accounts")
+	blocks.New(remote.repo, NewBFTEngine(remote.repo)).Mount(router, "/blocks")
+
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func newForkedSolo(t *testing.T, url, revision string) *Solo {
+	forked, err := fork.ResolveBlock(url, revision)
+	require.NoError(t, err)
+
+	db := muxdb.NewMem()
+	stater := state.NewStaterWithFallback(db, fork.NewState(url, forked, db))
+	gene, err := genesis.NewFork(forked.ID, forked.Timestamp, forked.GasLimit, fork.NewState(url, forked, muxdb.NewMem()))
+	require.NoError(t, err)
+	b, _, _, err := gene.Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, b)
+	require.NoError(t, err)
+	logDb, _ := logdb.NewMem()
+	mempool := txpool.New(repo, stater, txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
+
+	s := New(repo, stater, logDb, mempool, 0, true, true, thor.BlockInterval, thor.SoloFork)
+	s.SetForked(true)
+	return s
+}
+
+func TestFork(t *testing.T) {
+	remote := newSolo()
+	remote.skipLogs = true // the in-memory log db is shared
+	require.NoError(t, remote.init(context.Background()))
+
+	// state of the remote chain
+	addr := thor.BytesToAddress([]byte("account"))
+	trx, err := remote.newTx([]*tx.Clause{tx.NewClause(&addr).WithValue(big.NewInt(1000))}, genesis.DevAccounts()[0])
+	require.NoError(t, err)
+	forkedBlock, err := remote.packing(tx.Transactions{trx}, false)
+	require.NoError(t, err)
+	remote.SetCode(addr, []byte{0x60, 0x01})
+	_, err = remote.Mine()
+	require.NoError(t, err)
+
+	srv, requests := newRemoteNode(t, remote)
+
+	_, err = fork.ResolveBlock(srv.URL, "100")
+	assert.EqualError(t, err, `forked block "100" not found`)
+	_, err = fork.ResolveBlock(srv.URL, "invalid")
+	assert.Error(t, err)
+
+	// remote nodes without raw storage values are refused
+	legacy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		q.Del("raw")
+		r.URL.RawQuery = q.Encode()
+		http.Redirect(w, r, srv.URL+r.URL.String(), http.StatusTemporaryRedirect)
+	}))
+	defer legacy.Close()
+	_, err = fork.ResolveBlock(legacy.URL, "best")
+	assert.ErrorContains(t, err, "does not support raw storage values")
+
+	solo := newForkedSolo(t, srv.URL, strconv.FormatUint(uint64(forkedBlock.Header().Number()), 10))
+	assert.Equal(t, forkedBlock.Header().Timestamp(), solo.repo.GenesisBlock().Header().Timestamp())
+	// dev accounts are funded on init
+	require.NoError(t, solo.init(context.Background()))
+	assert.Equal(t, uint32(1), solo.repo.BestBlockSummary().Header.Number())
+
+	newState := func() *state.State {
+		return solo.stater.NewState(solo.repo.BestBlockSummary().Root())
+	}
+
+	// remote state at the forked block
+	st := newState()
+	balance, err := st.GetBalance(addr)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+	code, err := st.GetCode(addr)
+	assert.Nil(t, err)
+	assert.Empty(t, code, "code set after the forked block")
+	bgp, err := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	assert.Nil(t, err)
+	assert.Equal(t, baseGasPrice, bgp)
+	devBalance, err := st.GetBalance(genesis.DevAccounts()[1].Address)
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000000000000000000000000", devBalance.String())
+
+	// fetched values are cached
+	n := requests.Load()
+	st = newState()
+	_, err = st.GetBalance(addr)
+	assert.Nil(t, err)
+	_, err = builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	assert.Nil(t, err)
+	assert.Equal(t, n, requests.Load())
+
+	// pack new blocks on top of the forked state
+	trx, err = solo.newTx([]*tx.Clause{tx.NewClause(&addr).WithValue(big.NewInt(1))}, genesis.DevAccounts()[1])
+	require.NoError(t, err)
+	b, err := solo.packing(tx.Transactions{trx}, false)
+	assert.Nil(t, err)
+	assert.Len(t, b.Transactions(), 1)
+	solo.SetStorage(builtin.Params.Address, thor.KeyBaseGasPrice, thor.Bytes32{})
+	_, err = solo.Mine()
+	assert.Nil(t, err)
+
+	st = newState()
+	balance, err = st.GetBalance(addr)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1001), balance)
+	bgp, err = builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, bgp.Sign(), "deleted storage shadows the remote value")
+	reward, err := builtin.Params.Native(st).Get(thor.KeyRewardRatio)
+	assert.Nil(t, err)
+	assert.Equal(t, thor.InitialRewardRatio, reward)
+
+	// the remote chain is not affected
+	remoteState := remote.stater.NewState(remote.repo.BestBlockSummary().Root())
+	balance, err = remoteState.GetBalance(addr)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+}
@@ -7,9 +7,11 @@ package solo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"math/rand/v2"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -48,6 +50,22 @@ type Solo struct {
 	blockInterval uint64
 	onDemand      bool
 	skipLogs      bool
+	forked        bool
+	forkConfig    thor.ForkConfig
+	devnet        genesis.DevnetOptions // accounts to sign blocks, set chain parameters and to be funded
+
+	lock       sync.Mutex // serializes packing and the control operations
+	timeOffset uint64     // seconds added to the wall clock for block timestamps
+	snapshots  map[string]snapshot
+	cheats     []cheat // to be applied in the next packed block
+	tracing    *TraceOptions
+	traces     []json.RawMessage // results of the recent traced blocks
+}
+
+// snapshot is a named chain position that solo can be reverted to.
+type snapshot struct {
+	id         thor.Bytes32
+	timeOffset uint64
 }
 
 // New returns Solo instance
@@ -62,22 +80,38 @@ func New(
 	blockInterval uint64,
 	forkConfig thor.ForkConfig,
 ) *Solo {
-	return &Solo{
-		repo:   repo,
-		stater: stater,
-		txPool: txPool,
-		packer: packer.New(
-			repo,
-			stater,
-			genesis.DevAccounts()[0].Address,
-			&genesis.DevAccounts()[0].Address,
-			forkConfig),
+	s := &Solo{
+		repo:          repo,
+		stater:        stater,
+		txPool:        txPool,
 		logDB:         logDB,
 		gasLimit:      gasLimit,
 		blockInterval: blockInterval,
 		skipLogs:      skipLogs,
 		onDemand:      onDemand,
+		forkConfig:    forkConfig,
+		snapshots:     make(map[string]snapshot),
+	}
+	s.SetDevnet(genesis.DefaultDevnetOptions(), nil)
+	return s
+}
+
+// SetDevnet sets the dev accounts, which should be the same as the devnet genesis is built with.
+// Blocks are signed by the block signer, and rewarded to the beneficiary if given, otherwise the signer.
+func (s *Solo) SetDevnet(opts genesis.DevnetOptions, beneficiary *thor.Address) {
+	if beneficiary == nil {
+		beneficiary = &opts.BlockSigner.Address
 	}
+	s.devnet = opts
+	s.packer = packer.New(s.repo, s.stater, opts.BlockSigner.Address, beneficiary, s.forkConfig)
+	// blocks of solo are never validated by others, so impersonated txs can be packed
+	s.packer.SetImpersonation(true)
+}
+
+// SetForked marks the chain as forked from a remote chain, see package fork. On init, the dev
+// accounts are funded instead of changing the chain parameters, which are kept as the remote ones.
+func (s *Solo) SetForked(forked bool) {
+	s.forked = forked
 }
 
 // Run runs the packer for solo
@@ -109,14 +143,14 @@ func (s *Solo) loop(ctx context.Context) {
 			logger.Info("stopping interval packing service......")
 			return
 		case <-time.After(time.Duration(1) * time.Second):
-			if left := uint64(time.Now().Unix()) % s.blockInterval; left == 0 {
-				if err := s.packing(s.txPool.Executables(), false); err != nil {
+			if left := s.now() % s.blockInterval; left == 0 {
+				if _, err := s.packing(s.txPool.Executables(), false); err != nil {
 					logger.Error("failed to pack block", "err", err)
 				}
 			} else if s.onDemand {
 				pendingTxs := s.txPool.Executables()
-				if len(pendingTxs) > 0 {
-					if err := s.packing(pendingTxs, true); err != nil {
+				if len(pendingTxs) > 0 || s.hasCheats() {
+					if _, err := s.packing(pendingTxs, true); err != nil {
 						logger.Error("failed to pack block", "err", err)
 					}
 				}
@@ -125,9 +159,22 @@ func (s *Solo) loop(ctx context.Context) {
 	}
 }
 
-func (s *Solo) packing(pendingTxs tx.Transactions, onDemand bool) error {
+// now returns the current time of solo, which is the wall clock shifted by the time offset.
+func (s *Solo) now() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return uint64(time.Now().Unix()) + s.timeOffset
+}
+
+// packing packs the pending txs into a new block. If onDemand is set, no block
+// is packed when none of the txs is adopted and there is no cheat, and the returned block is nil.
+func (s *Solo) packing(pendingTxs tx.Transactions, onDemand bool) (b *block.Block, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	best := s.repo.BestBlockSummary()
-	now := uint64(time.Now().Unix())
+	// block timestamps must be increasing, even if blocks are packed faster than the clock
+	now := max(uint64(time.Now().Unix())+s.timeOffset, best.Header.Timestamp()+1)
 
 	var txsToRemove []*tx.Transaction
 	defer func() {
@@ -143,11 +190,39 @@ func (s *Solo) packing(pendingTxs tx.Transactions, onDemand bool) error {
 
 	flow, err := s.packer.Mock(best, now, s.gasLimit)
 	if err != nil {
-		return errors.WithMessage(err, "mock packer")
+		return nil, errors.WithMessage(err, "mock packer")
 	}
 
+	cheats, cheatErrs := s.cheats, make([]error, len(s.cheats))
+	s.cheats = nil
+	defer func() {
+		for i, c := range cheats {
+			if c.done != nil {
+				if cheatErrs[i] == nil {
+					cheatErrs[i] = err
+				}
+				c.done(cheatErrs[i])
+			}
+		}
+	}()
+
 	startTime := mclock.Now()
+	for i, c := range cheats {
+		if c.apply != nil {
+			cheatErrs[i] = c.apply(flow.Runtime())
+		} else {
+			cheatErrs[i] = c.adopt(flow)
+		}
+		if cheatErrs[i] != nil {
+			logger.Warn("failed to apply cheat", "err", cheatErrs[i])
+		}
+	}
 	for _, tx := range pendingTxs {
+		// impersonated txs are only accepted from the cheat API
+		if tx.IsImpersonated() {
+			txsToRemove = append(txsToRemove, tx)
+			continue
+		}
 		if err := flow.Adopt(tx); err != nil {
 			if packer.IsGasLimitReached(err) {
 				break
@@ -159,35 +234,41 @@ func (s *Solo) packing(pendingTxs tx.Transactions, onDemand bool) error {
 		}
 	}
 
-	b, stage, receipts, err := flow.Pack(genesis.DevAccounts()[0].PrivateKey, 0, false)
+	// blocks at the same height exist if the chain was reverted to a snapshot
+	conflicts, err := s.repo.ScanConflicts(flow.Number())
 	if err != nil {
-		return errors.WithMessage(err, "pack")
+		return nil, errors.WithMessage(err, "scan conflicts")
+	}
+
+	b, stage, receipts, err := flow.Pack(s.devnet.BlockSigner.PrivateKey, conflicts, false)
+	if err != nil {
+		return nil, errors.WithMessage(err, "pack")
 	}
 	execElapsed := mclock.Now() - startTime
 
 	// If there is no tx packed in the on-demanded block then skip
-	if onDemand && len(b.Transactions()) == 0 {
-		return nil
+	if onDemand && len(b.Transactions()) == 0 && len(cheats) == 0 {
+		return nil, nil
 	}
 
 	if _, err := stage.Commit(); err != nil {
-		return errors.WithMessage(err, "commit state")
+		return nil, errors.WithMessage(err, "commit state")
 	}
 
 	if !s.skipLogs {
 		w := s.logDB.NewWriter()
 		if err := w.Write(b, receipts); err != nil {
-			return errors.WithMessage(err, "write logs")
+			return nil, errors.WithMessage(err, "write logs")
 		}
 
 		if err := w.Commit(); err != nil {
-			return errors.WithMessage(err, "commit logs")
+			return nil, errors.WithMessage(err, "commit logs")
 		}
 	}
 
 	// ignore fork when solo
-	if err := s.repo.AddBlock(b, receipts, 0, true); err != nil {
-		return errors.WithMessage(err, "commit block")
+	if err := s.repo.AddBlock(b, receipts, conflicts, true); err != nil {
+		return nil, errors.WithMessage(err, "commit block")
 	}
 	realElapsed := mclock.Now() - startTime
 
@@ -206,11 +287,20 @@ func (s *Solo) packing(pendingTxs tx.Transactions, onDemand bool) error {
 	)
 	logger.Debug(b.String())
 
-	return nil
+	if s.tracing != nil {
+		if err := s.trace(b, receipts, cheats, cheatErrs); err != nil {
+			logger.Warn("failed to trace block", "err", err)
+		}
+	}
+	return b, nil
 }
 
 // The init function initializes the chain parameters.
 func (s *Solo) init(ctx context.Context) error {
+	if s.forked {
+		return s.initFork()
+	}
+
 	best := s.repo.BestBlockSummary()
 	newState := s.stater.NewState(best.Root())
 	currentBGP, err := builtin.Params.Native(newState).Get(thor.KeyBaseGasPrice)
@@ -232,7 +322,7 @@ func (s *Solo) init(ctx context.Context) error {
 	}
 
 	clause := tx.NewClause(&builtin.Params.Address).WithData(data)
-	baseGasePriceTx, err := s.newTx([]*tx.Clause{clause}, genesis.DevAccounts()[0])
+	baseGasePriceTx, err := s.newTx([]*tx.Clause{clause}, s.devnet.Accounts[0])
 	if err != nil {
 		return err
 	}
@@ -245,7 +335,22 @@ func (s *Solo) init(ctx context.Context) error {
 		}
 	}
 
-	return s.packing(tx.Transactions{baseGasePriceTx}, false)
+	_, err = s.packing(tx.Transactions{baseGasePriceTx}, false)
+	return err
+}
+
+// initFork funds the dev accounts in the first block of the forked chain.
+func (s *Solo) initFork() error {
+	if s.repo.BestBlockSummary().Header.Number() > 0 {
+		return nil
+	}
+
+	for _, a := range s.devnet.Accounts {
+		s.SetBalance(a.Address, s.devnet.Balance)
+		s.SetEnergy(a.Address, s.devnet.Energy)
+	}
+	_, err := s.packing(nil, false)
+	return err
 }
 
 // newTx builds and signs a new transaction from the given clauses
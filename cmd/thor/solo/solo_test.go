@@ -7,17 +7,21 @@ package solo
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/consensus"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/runtime"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 )
 
@@ -47,3 +51,223 @@ func TestInitSolo(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, baseGasPrice, currentBGP)
 }
+
+func TestControl(t *testing.T) {
+	solo := newSolo()
+	assert.Nil(t, solo.init(context.Background()))
+	genesisTime := solo.repo.GenesisBlock().Header().Timestamp()
+
+	// mine an empty block on demand
+	b, err := solo.Mine()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), b.Header().Number())
+	assert.Equal(t, b.Header().ID(), solo.repo.BestBlockSummary().Header.ID())
+
+	snap, err := solo.Snapshot("s1")
+	assert.Nil(t, err)
+	assert.Equal(t, b.Header().ID(), snap.Header.ID())
+
+	// time travel
+	assert.Equal(t, uint64(3600), solo.IncreaseTime(3600))
+	blocks, err := solo.MineEmpty(3)
+	assert.Nil(t, err)
+	assert.Len(t, blocks, 3)
+	for i, blk := range blocks {
+		assert.Equal(t, uint32(3+i), blk.Header().Number())
+		assert.Empty(t, blk.Transactions())
+		assert.True(t, blk.Header().Timestamp() >= uint64(time.Now().Unix())+3600)
+	}
+	assert.True(t, blocks[0].Header().Timestamp() > genesisTime)
+
+	// pack a transfer, which writes logs
+	to := genesis.DevAccounts()[1].Address
+	trx, err := solo.newTx([]*tx.Clause{tx.NewClause(&to).WithValue(big.NewInt(1))}, genesis.DevAccounts()[0])
+	assert.Nil(t, err)
+	b, err = solo.packing(tx.Transactions{trx}, false)
+	assert.Nil(t, err)
+	assert.Len(t, b.Transactions(), 1)
+	transfers, err := solo.logDB.FilterTransfers(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, transfers, 1)
+
+	// revert
+	reverted, err := solo.Revert("s1")
+	assert.Nil(t, err)
+	assert.Equal(t, snap.Header.ID(), reverted.Header.ID())
+	assert.Equal(t, snap.Header.ID(), solo.repo.BestBlockSummary().Header.ID())
+	assert.Equal(t, uint64(0), solo.IncreaseTime(0))
+	transfers, err = solo.logDB.FilterTransfers(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, transfers, 0)
+
+	// the reverted height can be packed again
+	b, err = solo.Mine()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), b.Header().Number())
+	assert.NotEqual(t, blocks[0].Header().ID(), b.Header().ID())
+	summary, err := solo.repo.GetBlockSummary(b.Header().ID())
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), summary.Conflicts)
+
+	// snapshot is kept
+	_, err = solo.Revert("s1")
+	assert.Nil(t, err)
+
+	_, err = solo.Revert("unknown")
+	assert.EqualError(t, err, `snapshot "unknown" not found`)
+
+	// the snapshot of a reverted block is not on the canonical chain
+	_, err = solo.Mine()
+	assert.Nil(t, err)
+	_, err = solo.Snapshot("s2")
+	assert.Nil(t, err)
+	_, err = solo.Revert("s1")
+	assert.Nil(t, err)
+	_, err = solo.Revert("s2")
+	assert.EqualError(t, err, `snapshot "s2" is not on the canonical chain`)
+}
+
+func TestCheats(t *testing.T) {
+	solo := newSolo()
+	assert.Nil(t, solo.init(context.Background()))
+
+	addr := thor.BytesToAddress([]byte("account"))
+	key, value := thor.BytesToBytes32([]byte("key")), thor.BytesToBytes32([]byte("value"))
+	solo.SetBalance(addr, big.NewInt(100))
+	solo.SetEnergy(addr, big.NewInt(200))
+	solo.SetCode(addr, []byte{0x60, 0x01})
+	solo.SetStorage(addr, key, value)
+
+	// on-demand packing is not skipped with pending cheats
+	b, err := solo.packing(nil, true)
+	assert.Nil(t, err)
+	assert.NotNil(t, b)
+	assert.False(t, solo.hasCheats())
+
+	st := solo.stater.NewState(solo.repo.BestBlockSummary().Root())
+	balance, err := st.GetBalance(addr)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), balance)
+	energy, err := st.GetEnergy(addr, b.Header().Timestamp())
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(200), energy)
+	code, err := st.GetCode(addr)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x60, 0x01}, code)
+	storage, err := st.GetStorage(addr, key)
+	assert.Nil(t, err)
+	assert.Equal(t, value, storage)
+
+	// impersonate the executor to set params
+	method, _ := builtin.Params.ABI.MethodByName("set")
+	paramKey := thor.BytesToBytes32([]byte("cheat"))
+	data, err := method.EncodeInput(paramKey, big.NewInt(1))
+	assert.Nil(t, err)
+	clauses := []*tx.Clause{
+		tx.NewClause(&addr).WithValue(big.NewInt(10)),
+		tx.NewClause(&builtin.Params.Address).WithData(data),
+	}
+
+	impersonate := func(origin thor.Address) ([]*runtime.Output, error) {
+		type result struct {
+			outputs []*runtime.Output
+			err     error
+		}
+		ch := make(chan result, 1)
+		go func() {
+			outputs, err := solo.Impersonate(context.Background(), origin, clauses, 1_000_000)
+			ch <- result{outputs, err}
+		}()
+		for !solo.hasCheats() {
+			time.Sleep(time.Millisecond)
+		}
+		_, err := solo.Mine()
+		assert.Nil(t, err)
+		res := <-ch
+		return res.outputs, res.err
+	}
+
+	// not the executor, reverted
+	outputs, err := impersonate(addr)
+	assert.Nil(t, err)
+	assert.Len(t, outputs, 2)
+	assert.Nil(t, outputs[0].VMErr)
+	assert.NotNil(t, outputs[1].VMErr)
+	st = solo.stater.NewState(solo.repo.BestBlockSummary().Root())
+	balance, _ = st.GetBalance(addr)
+	assert.Equal(t, big.NewInt(100), balance)
+
+	executor := genesis.DevAccounts()[0].Address
+	outputs, err = impersonate(executor)
+	assert.Nil(t, err)
+	assert.Len(t, outputs, 2)
+	assert.Nil(t, outputs[1].VMErr)
+	assert.Len(t, outputs[0].Transfers, 1)
+	assert.Equal(t, executor, outputs[0].Transfers[0].Sender)
+
+	st = solo.stater.NewState(solo.repo.BestBlockSummary().Root())
+	balance, _ = st.GetBalance(addr)
+	assert.Equal(t, big.NewInt(110), balance)
+	param, err := builtin.Params.Native(st).Get(paramKey)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), param)
+
+	// packed as a tx with a receipt
+	best := solo.repo.BestBlockSummary()
+	b, err = solo.repo.GetBlock(best.Header.ID())
+	assert.Nil(t, err)
+	assert.Len(t, b.Transactions(), 1)
+	origin, _, err := b.Transactions()[0].ImpersonatedSigners()
+	assert.Nil(t, err)
+	assert.Equal(t, executor, origin)
+	// replayed by the debug API
+	rt, err := consensus.New(solo.repo, solo.stater, solo.forkConfig).NewRuntimeForReplay(b.Header(), true)
+	assert.Nil(t, err)
+	_, err = rt.ExecuteTransaction(b.Transactions()[0])
+	assert.NotNil(t, err, "impersonation is not enabled by default")
+	_, err = rt.SetImpersonation(true).ExecuteTransaction(b.Transactions()[0])
+	assert.Nil(t, err)
+	receipts, err := solo.repo.GetBlockReceipts(best.Header.ID())
+	assert.Nil(t, err)
+	assert.Len(t, receipts, 1)
+	assert.False(t, receipts[0].Reverted)
+	assert.Equal(t, genesis.DevAccounts()[0].Address, receipts[0].GasPayer)
+	assert.Len(t, receipts[0].Outputs[0].Transfers, 1)
+
+	// canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = solo.Impersonate(ctx, executor, clauses, 1_000_000)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestCustomDevnet(t *testing.T) {
+	accs, err := genesis.DeriveDevAccounts("ignore empty bird silly journey junior ripple have guard waste between tenant", 3)
+	assert.Nil(t, err)
+	opts := genesis.DevnetOptions{
+		Accounts:    accs,
+		Balance:     big.NewInt(1e18),
+		Energy:      big.NewInt(1e18),
+		BlockSigner: accs[2],
+	}
+
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	b0, _, _, err := genesis.NewDevnetWithOptions(thor.SoloFork, opts).Build(stater)
+	assert.Nil(t, err)
+	repo, _ := chain.NewRepository(db, b0)
+	logDb, _ := logdb.NewMem()
+	mempool := txpool.New(repo, stater, txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
+
+	solo := New(repo, stater, logDb, mempool, 0, true, true, thor.BlockInterval, thor.SoloFork)
+	beneficiary := thor.BytesToAddress([]byte("beneficiary"))
+	solo.SetDevnet(opts, &beneficiary)
+	assert.Nil(t, solo.init(context.Background()))
+
+	b := solo.repo.BestBlockSummary().Header
+	assert.Equal(t, uint32(1), b.Number())
+	signer, err := b.Signer()
+	assert.Nil(t, err)
+	assert.Equal(t, accs[2].Address, signer)
+	assert.Equal(t, beneficiary, b.Beneficiary())
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/runtime"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tracers"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/vm"
+	"github.com/vechain/thor/v2/xenv"
+)
+
+// TraceOptions configures the tracing of packed blocks.
+type TraceOptions struct {
+	Tracer string          // name of a registered tracer or JS code
+	Config json.RawMessage // config of the tracer, optional
+	All    bool            // trace all clauses, otherwise clauses of reverted txs only
+	Blocks int             // number of recent blocks to keep the results of
+}
+
+// ClauseTrace is the tracing result of a clause.
+type ClauseTrace struct {
+	TxID         thor.Bytes32    `json:"txID"`
+	TxIndex      uint64          `json:"txIndex"`
+	ClauseIndex  uint32          `json:"clauseIndex"`
+	Reverted     bool            `json:"reverted"`
+	VMError      string          `json:"vmError,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// BlockTrace is the tracing result of a packed block.
+type BlockTrace struct {
+	ID      thor.Bytes32   `json:"id"`
+	Number  uint32         `json:"number"`
+	Clauses []*ClauseTrace `json:"clauses"`
+}
+
+// SetTracing enables tracing of every packed block. The results are logged, and kept for the
+// most recent blocks, see Traces.
+func (s *Solo) SetTracing(opts TraceOptions) error {
+	if opts.Blocks <= 0 {
+		return errors.New("invalid number of blocks to keep traces")
+	}
+	// fail early on unknown tracers or invalid config
+	if _, err := tracers.DefaultDirectory.New(opts.Tracer, opts.Config, true); err != nil {
+		return errors.WithMessage(err, "create tracer")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tracing = &opts
+	s.traces = nil
+	return nil
+}
+
+// Traces returns the tracing results of at most n recent blocks, in ascending order of packing.
+// It returns all kept results if n is not positive.
+func (s *Solo) Traces(n int) []json.RawMessage {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	traces := s.traces
+	if n > 0 && n < len(traces) {
+		traces = traces[len(traces)-n:]
+	}
+	return append([]json.RawMessage(nil), traces...)
+}
+
+// trace replays the packed block with the same cheats applied in the same order, traces its clauses and
+// logs the results. The errors of cheats are the ones returned in packing.
+func (s *Solo) trace(b *block.Block, receipts tx.Receipts, cheats []cheat, cheatErrs []error) error {
+	header := b.Header()
+	parent, err := s.repo.GetBlockSummary(header.ParentID())
+	if err != nil {
+		return err
+	}
+	signer, err := header.Signer()
+	if err != nil {
+		return err
+	}
+
+	rt := runtime.New(
+		s.repo.NewChain(header.ParentID()),
+		s.stater.NewState(parent.Root()),
+		&xenv.BlockContext{
+			Beneficiary: header.Beneficiary(),
+			Signer:      signer,
+			Number:      header.Number(),
+			Time:        header.Timestamp(),
+			GasLimit:    header.GasLimit(),
+			TotalScore:  header.TotalScore(),
+		},
+		s.forkConfig).SetImpersonation(true)
+	if err := runtime.ApplyScheduledChanges(rt.State(), header.Number(), s.forkConfig); err != nil {
+		return err
+	}
+
+	var (
+		bt = &BlockTrace{
+			ID:      header.ID(),
+			Number:  header.Number(),
+			Clauses: []*ClauseTrace{},
+		}
+		txs  = b.Transactions()
+		next = 0
+	)
+	replayTx := func() error {
+		if next >= len(txs) {
+			return errors.New("adopted tx not found in the block")
+		}
+		trx, i := txs[next], next
+		next++
+		if !s.tracing.All && !receipts[i].Reverted {
+			_, err := rt.ExecuteTransaction(trx)
+			return err
+		}
+		clauses, err := s.traceTx(rt, header, trx, uint64(i))
+		if err != nil {
+			return errors.WithMessage(err, "trace tx "+trx.ID().String())
+		}
+		bt.Clauses = append(bt.Clauses, clauses...)
+		return nil
+	}
+
+	// impersonated txs adopted by cheats lead the block, interleaved with changes of other cheats
+	for i, c := range cheats {
+		if c.apply != nil {
+			// failed changes are not reverted in packing either
+			_ = c.apply(rt)
+		} else if cheatErrs[i] == nil {
+			if err := replayTx(); err != nil {
+				return err
+			}
+		}
+	}
+	for next < len(txs) {
+		if err := replayTx(); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(bt)
+	if err != nil {
+		return err
+	}
+	s.traces = append(s.traces, data)
+	if len(s.traces) > s.tracing.Blocks {
+		s.traces = s.traces[len(s.traces)-s.tracing.Blocks:]
+	}
+	return nil
+}
+
+// traceTx executes the tx and traces each clause with a new tracer, until a clause is reverted.
+func (s *Solo) traceTx(rt *runtime.Runtime, header *block.Header, trx *tx.Transaction, txIndex uint64) ([]*ClauseTrace, error) {
+	txExec, err := rt.PrepareTransaction(trx)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.SetVMConfig(vm.Config{})
+
+	var traces []*ClauseTrace
+	for clauseIndex := uint32(0); txExec.HasNextClause(); clauseIndex++ {
+		tracer, err := tracers.DefaultDirectory.New(s.tracing.Tracer, s.tracing.Config, true)
+		if err != nil {
+			return nil, err
+		}
+		tracer.SetContext(&tracers.Context{
+			BlockID:     header.ID(),
+			BlockTime:   header.Timestamp(),
+			TxID:        trx.ID(),
+			TxIndex:     txIndex,
+			ClauseIndex: clauseIndex,
+			State:       rt.State(),
+		})
+		rt.SetVMConfig(vm.Config{Tracer: tracer})
+
+		exec, _ := txExec.PrepareNext()
+		_, output, err := exec()
+		if err != nil {
+			return nil, err
+		}
+		result, err := tracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+
+		ct := &ClauseTrace{
+			TxID:        trx.ID(),
+			TxIndex:     txIndex,
+			ClauseIndex: clauseIndex,
+			Result:      result,
+		}
+		if output.VMErr != nil {
+			ct.Reverted = true
+			ct.VMError = output.VMErr.Error()
+			ct.RevertReason = decodeRevertReason(output.Data)
+		}
+		logClauseTrace(ct)
+		traces = append(traces, ct)
+	}
+	if _, err := txExec.Finalize(); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+func logClauseTrace(ct *ClauseTrace) {
+	msg := "clause traced"
+	if ct.Reverted {
+		msg = "clause reverted"
+	}
+	ctx := []any{"tx", ct.TxID, "clause", ct.ClauseIndex}
+
+	// render the call tree if the result is of the call tracer, it includes the errors
+	var frame callFrame
+	if err := json.Unmarshal(ct.Result, &frame); err == nil && frame.Type != "" {
+		var sb strings.Builder
+		frame.render(&sb, 1)
+		msg += "\n" + strings.TrimRight(sb.String(), "\n")
+	} else if ct.Reverted {
+		ctx = append(ctx, "error", ct.VMError)
+		if ct.RevertReason != "" {
+			ctx = append(ctx, "reason", ct.RevertReason)
+		}
+	}
+
+	if ct.Reverted {
+		logger.Warn(msg, ctx...)
+	} else {
+		logger.Info(msg, ctx...)
+	}
+}
+
+// callFrame is the result of the call tracer.
+type callFrame struct {
+	Type    string         `json:"type"`
+	From    thor.Address   `json:"from"`
+	To      *thor.Address  `json:"to"`
+	Value   *hexutil.Big   `json:"value"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Input   hexutil.Bytes  `json:"input"`
+	Output  hexutil.Bytes  `json:"output"`
+	Error   string         `json:"error"`
+	Calls   []callFrame    `json:"calls"`
+}
+
+// render writes the frame and its sub calls, one call per line.
+func (f *callFrame) render(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(f.Type)
+	sb.WriteString(" " + f.From.String() + " -> ")
+	if f.To != nil {
+		sb.WriteString(f.To.String())
+	} else {
+		sb.WriteString("(create)")
+	}
+	if f.Value != nil && f.Value.ToInt().Sign() > 0 {
+		sb.WriteString(" value: " + f.Value.ToInt().String())
+	}
+	if len(f.Input) >= 4 && f.To != nil {
+		sb.WriteString(" method: " + hexutil.Encode(f.Input[:4]))
+	}
+	fmt.Fprintf(sb, " gasUsed: %d", uint64(f.GasUsed))
+	if f.Error != "" {
+		sb.WriteString(" error: " + f.Error)
+		if reason := decodeRevertReason(f.Output); reason != "" {
+			sb.WriteString(" reason: " + reason)
+		}
+	}
+	sb.WriteString("\n")
+	for i := range f.Calls {
+		f.Calls[i].render(sb, depth+1)
+	}
+}
+
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+
+	panicReasons = map[uint64]string{
+		0x00: "generic panic",
+		0x01: "assert(false)",
+		0x11: "arithmetic underflow or overflow",
+		0x12: "division or modulo by zero",
+		0x21: "enum overflow",
+		0x22: "invalid encoded storage byte array accessed",
+		0x31: "out-of-bounds array access; popping on an empty array",
+		0x32: "out-of-bounds access of an array or bytesN",
+		0x41: "out of memory",
+		0x51: "uninitialized function",
+	}
+)
+
+// decodeRevertReason decodes the revert data of Error(string) or Panic(uint256) raised by solidity.
+// It returns empty string if the data is in neither form.
+func decodeRevertReason(data []byte) string {
+	switch {
+	case len(data) >= 4+64 && bytes.Equal(data[:4], errorSelector):
+		payload := data[4:]
+		offset := new(big.Int).SetBytes(payload[:32])
+		if !offset.IsUint64() || offset.Uint64() > uint64(len(payload)-32) {
+			return ""
+		}
+		start := offset.Uint64() + 32
+		size := new(big.Int).SetBytes(payload[start-32 : start])
+		if !size.IsUint64() || size.Uint64() > uint64(len(payload))-start {
+			return ""
+		}
+		return fmt.Sprintf("%q", payload[start:start+size.Uint64()])
+	case len(data) == 4+32 && bytes.Equal(data[:4], panicSelector):
+		code := new(big.Int).SetBytes(data[4:])
+		if reason, ok := panicReasons[code.Uint64()]; ok && code.IsUint64() {
+			return fmt.Sprintf("panic 0x%x: %s", code, reason)
+		}
+		return fmt.Sprintf("panic 0x%x", code)
+	}
+	return ""
+}
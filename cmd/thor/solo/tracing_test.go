@@ -0,0 +1,133 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package solo
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+
+	_ "github.com/vechain/thor/v2/tracers/js"
+	_ "github.com/vechain/thor/v2/tracers/native"
+)
+
+// revertCode reverts with Error("nope").
+var revertCode = common.FromHex("0x606460" + "0c" + "600039" + "60646000fd" +
+	"08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000004" +
+	"6e6f706500000000000000000000000000000000000000000000000000000000")
+
+func TestTracing(t *testing.T) {
+	solo := newSolo()
+	solo.skipLogs = true // the in-memory log db is shared
+	require.NoError(t, solo.init(context.Background()))
+
+	assert.Error(t, solo.SetTracing(TraceOptions{Tracer: "unknown", Blocks: 1}))
+	assert.Error(t, solo.SetTracing(TraceOptions{Tracer: "callTracer"}))
+	require.NoError(t, solo.SetTracing(TraceOptions{Tracer: "callTracer", Blocks: 2}))
+
+	reverter := thor.BytesToAddress([]byte("reverter"))
+	recipient := thor.BytesToAddress([]byte("recipient"))
+	solo.SetCode(reverter, revertCode)
+	_, err := solo.Mine()
+	require.NoError(t, err)
+
+	okTx, err := solo.newTx([]*tx.Clause{tx.NewClause(&recipient).WithValue(big.NewInt(1))}, genesis.DevAccounts()[0])
+	require.NoError(t, err)
+	revertedTx, err := solo.newTx([]*tx.Clause{
+		tx.NewClause(&recipient).WithValue(big.NewInt(1)),
+		tx.NewClause(&reverter),
+		tx.NewClause(&recipient),
+	}, genesis.DevAccounts()[1])
+	require.NoError(t, err)
+	b, err := solo.packing(tx.Transactions{okTx, revertedTx}, false)
+	require.NoError(t, err)
+	require.Len(t, b.Transactions(), 2)
+
+	traces := solo.Traces(0)
+	require.Len(t, traces, 2)
+	var bt BlockTrace
+	require.NoError(t, json.Unmarshal(traces[1], &bt))
+	assert.Equal(t, b.Header().ID(), bt.ID)
+	// clauses of the reverted tx only, until the reverted clause
+	require.Len(t, bt.Clauses, 2)
+	assert.Equal(t, revertedTx.ID(), bt.Clauses[0].TxID)
+	assert.Equal(t, uint64(1), bt.Clauses[0].TxIndex)
+	assert.False(t, bt.Clauses[0].Reverted)
+	assert.Equal(t, uint32(1), bt.Clauses[1].ClauseIndex)
+	assert.True(t, bt.Clauses[1].Reverted)
+	assert.Equal(t, "execution reverted", bt.Clauses[1].VMError)
+	assert.Equal(t, `"nope"`, bt.Clauses[1].RevertReason)
+
+	var frame callFrame
+	require.NoError(t, json.Unmarshal(bt.Clauses[1].Result, &frame))
+	assert.Equal(t, "CALL", frame.Type)
+	assert.Equal(t, reverter, *frame.To)
+
+	// results of the recent blocks are kept only
+	_, err = solo.Mine()
+	require.NoError(t, err)
+	traces = solo.Traces(0)
+	require.Len(t, traces, 2)
+	assert.Equal(t, []json.RawMessage{traces[1]}, solo.Traces(1))
+	require.NoError(t, json.Unmarshal(traces[0], &bt))
+	assert.Equal(t, b.Header().ID(), bt.ID)
+
+	// trace all clauses
+	require.NoError(t, solo.SetTracing(TraceOptions{Tracer: "callTracer", All: true, Blocks: 1}))
+	okTx, err = solo.newTx([]*tx.Clause{tx.NewClause(&recipient).WithValue(big.NewInt(1))}, genesis.DevAccounts()[0])
+	require.NoError(t, err)
+	_, err = solo.packing(tx.Transactions{okTx}, false)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(solo.Traces(0)[0], &bt))
+	require.Len(t, bt.Clauses, 1)
+	assert.False(t, bt.Clauses[0].Reverted)
+
+	// cheats are replayed in the order of packing, the code is set after the impersonated tx called it
+	target := thor.BytesToAddress([]byte("target"))
+	done := make(chan error, 1)
+	go func() {
+		_, err := solo.Impersonate(context.Background(), recipient, []*tx.Clause{tx.NewClause(&target)}, 100000)
+		done <- err
+	}()
+	for !solo.hasCheats() {
+		time.Sleep(time.Millisecond)
+	}
+	solo.SetCode(target, revertCode)
+	b, err = solo.packing(nil, false)
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+	require.Len(t, b.Transactions(), 1)
+	receipts, err := solo.repo.GetBlockReceipts(b.Header().ID())
+	require.NoError(t, err)
+	assert.False(t, receipts[0].Reverted)
+
+	require.NoError(t, json.Unmarshal(solo.Traces(0)[0], &bt))
+	assert.Equal(t, b.Header().ID(), bt.ID)
+	require.Len(t, bt.Clauses, 1)
+	assert.Equal(t, b.Transactions()[0].ID(), bt.Clauses[0].TxID)
+	assert.False(t, bt.Clauses[0].Reverted)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	assert.Equal(t, `"nope"`, decodeRevertReason(revertCode[12:]))
+	assert.Equal(t, "panic 0x11: arithmetic underflow or overflow",
+		decodeRevertReason(common.FromHex("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")))
+	assert.Equal(t, "panic 0x99",
+		decodeRevertReason(common.FromHex("0x4e487b710000000000000000000000000000000000000000000000000000000000000099")))
+	assert.Equal(t, "", decodeRevertReason(nil))
+	assert.Equal(t, "", decodeRevertReason(revertCode[12:80]))
+}
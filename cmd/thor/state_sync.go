@@ -0,0 +1,69 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/cmd/thor/pruner"
+	"github.com/vechain/thor/v2/comm"
+	"github.com/vechain/thor/v2/consensus"
+	"github.com/vechain/thor/v2/log"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// syncState restores a fresh node from peers, by downloading the state of the checkpoint block's parent,
+// and then executing the checkpoint block, which becomes the best block.
+// Blocks after the finalized block are synced as usual once the node started.
+func syncState(
+	ctx context.Context,
+	communicator *comm.Communicator,
+	mainDB *muxdb.MuxDB,
+	repo *chain.Repository,
+	forkConfig thor.ForkConfig,
+	checkpoint thor.Bytes32,
+	prune bool,
+) error {
+	start := time.Now()
+	log.Info("waiting for peers to sync state")
+
+	blk, conflicts, err := communicator.SyncState(ctx, forkConfig, checkpoint)
+	if err != nil {
+		return err
+	}
+	parent, err := repo.GetBlockSummary(blk.Header().ParentID())
+	if err != nil {
+		return err
+	}
+
+	// history nodes before the synced state are incomplete
+	if prune {
+		if err := pruner.Rebase(ctx, mainDB, repo, parent); err != nil {
+			return errors.WithMessage(err, "prune")
+		}
+	} else if err := pruner.MarkBase(mainDB, parent); err != nil {
+		return err
+	}
+
+	stage, receipts, err := consensus.New(repo, state.NewStater(mainDB), forkConfig).
+		Process(parent, blk, uint64(time.Now().Unix()), conflicts)
+	if err != nil {
+		return errors.WithMessagef(err, "execute block #%d", blk.Header().Number())
+	}
+	if _, err := stage.Commit(); err != nil {
+		return err
+	}
+	if err := repo.AddBlock(blk, receipts, conflicts, true); err != nil {
+		return err
+	}
+	log.Info("state synced", "block", blk.Header().Number(), "id", blk.Header().ID(), "elapsed", time.Since(start).Round(time.Second))
+	return nil
+}
@@ -28,7 +28,7 @@ func syncLogDB(ctx context.Context, repo *chain.Repository, logDB *logdb.LogDB,
 		return errors.Wrap(err, "seek log db sync position")
 	}
 	if verify && startPos > 0 {
-		if err := verifyLogDB(ctx, startPos-1, repo, logDB); err != nil {
+		if err := verifyLogDB(ctx, 1, startPos-1, repo, logDB, nil); err != nil {
 			return errors.Wrap(err, "verify log db")
 		}
 	}
@@ -154,9 +154,11 @@ func seekLogDBSyncPosition(repo *chain.Repository, logDB *logdb.LogDB) (uint32,
 	return block.Number(header.ID()) + 1, nil
 }
 
-func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository, logDB *logdb.LogDB) error {
+// verifyLogDB verifies logs of blocks numbered within [from, to] in the best chain against receipts.
+// The receipts of the genesis block are required if from is 0.
+func verifyLogDB(ctx context.Context, from, to uint32, repo *chain.Repository, logDB *logdb.LogDB, genesisReceipts tx.Receipts) error {
 	fmt.Println(">> Verifying log db <<")
-	pb := pb.New64(int64(endBlockNum)).
+	pb := pb.New64(int64(to - from + 1)).
 		Set64(0).
 		SetMaxWidth(90).
 		Start()
@@ -168,7 +170,7 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 		best        = repo.BestBlockSummary()
 		evLogs      []*logdb.Event
 		trLogs      []*logdb.Transfer
-		logLimit    = uint32(0)
+		fetched     = from // logs of blocks before are fetched
 		splitEvLogs = func(id thor.Bytes32) (logs []*logdb.Event) {
 			if len(evLogs) == 0 {
 				return
@@ -216,7 +218,7 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 	defer goes.Wait()
 	goes.Go(func() {
 		defer close(ch)
-		pumpErr = pumpBlockAndReceipts(ctx, repo, best.Header.ID(), 1, endBlockNum, ch)
+		pumpErr = pumpBlockAndReceipts(ctx, repo, best.Header.ID(), from, to, ch)
 	})
 
 	defer cancel()
@@ -224,13 +226,13 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 	for b := range ch {
 		id := b.Header().ID()
 		num := b.Header().Number()
-		if num > logLimit {
+		if num >= fetched {
 			var err error
-			logLimit += logStep
+			fetched += logStep
 			evLogs, err = logDB.FilterEvents(context.TODO(), &logdb.EventFilter{
 				Range: &logdb.Range{
 					From: num,
-					To:   logLimit,
+					To:   fetched - 1,
 				},
 			})
 			if err != nil {
@@ -239,7 +241,7 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 			trLogs, err = logDB.FilterTransfers(context.TODO(), &logdb.TransferFilter{
 				Range: &logdb.Range{
 					From: num,
-					To:   logLimit,
+					To:   fetched - 1,
 				},
 			})
 			if err != nil {
@@ -247,13 +249,16 @@ func verifyLogDB(ctx context.Context, endBlockNum uint32, repo *chain.Repository
 			}
 		}
 
-		receipts, err := repo.GetBlockReceipts(id)
-		if err != nil {
-			return err
+		receipts := genesisReceipts
+		if num > 0 {
+			var err error
+			if receipts, err = repo.GetBlockReceipts(id); err != nil {
+				return err
+			}
 		}
 
 		if err := verifyLogDBPerBlock(b, receipts, splitEvLogs(id), splitTrLogs(id)); err != nil {
-			return err
+			return errors.WithMessagef(err, "block #%d", num)
 		}
 		pb.Add64(1)
 
@@ -292,8 +297,14 @@ func verifyLogDBPerBlock(
 	var expectedTrLogs []*logdb.Transfer
 	txs := block.Transactions()
 	for txIndex, r := range receipts {
-		tx := txs[txIndex]
-		origin, _ := tx.Origin()
+		var (
+			txID   thor.Bytes32
+			origin thor.Address
+		)
+		if txIndex < len(txs) { // block 0 has no tx, but has receipts
+			txID = txs[txIndex].ID()
+			origin, _ = txs[txIndex].Origin()
+		}
 		evCount = 0
 		trCount = 0
 
@@ -308,7 +319,7 @@ func verifyLogDBPerBlock(
 					LogIndex:    uint32(evCount),
 					BlockID:     id,
 					BlockTime:   ts,
-					TxID:        tx.ID(),
+					TxID:        txID,
 					TxOrigin:    origin,
 					ClauseIndex: uint32(clauseIndex),
 					Address:     ev.Address,
@@ -324,7 +335,7 @@ func verifyLogDBPerBlock(
 					LogIndex:    uint32(trCount),
 					BlockID:     id,
 					BlockTime:   ts,
-					TxID:        tx.ID(),
+					TxID:        txID,
 					TxOrigin:    origin,
 					ClauseIndex: uint32(clauseIndex),
 					Sender:      tr.Sender,
@@ -14,6 +14,7 @@ import (
 	"io"
 	"log/slog"
 	"math"
+	"math/big"
 	"net"
 	"net/http"
 	"os"
@@ -22,6 +23,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -40,6 +42,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/doc"
+	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/cmd/thor/node"
 	"github.com/vechain/thor/v2/cmd/thor/p2p"
@@ -52,6 +55,7 @@ import (
 	"github.com/vechain/thor/v2/p2psrv"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tracers/js"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 	"gopkg.in/urfave/cli.v1"
@@ -251,6 +255,31 @@ func selectGenesis(ctx *cli.Context) (*genesis.Genesis, thor.ForkConfig, error)
 }
 
 func parseGenesisFile(uri string) (*genesis.Genesis, thor.ForkConfig, error) {
+	gen, err := readGenesisFile(uri)
+	if err != nil {
+		return nil, thor.ForkConfig{}, err
+	}
+
+	customGen, err := genesis.NewCustomNet(gen)
+	if err != nil {
+		return nil, thor.ForkConfig{}, errors.Wrap(err, "build genesis")
+	}
+
+	forkConfig := thor.NoFork
+	if gen.ForkConfig != nil {
+		forkConfig = *gen.ForkConfig
+	}
+	if forkConfig.Params, err = genesis.NewParamsSchedule(gen.ParamsSchedule); err != nil {
+		return nil, thor.ForkConfig{}, errors.Wrap(err, "params schedule")
+	}
+	if gen.Timing != nil {
+		forkConfig.Timing = *gen.Timing
+	}
+	return customGen, forkConfig, nil
+}
+
+// readGenesisFile reads the custom genesis from a local file or URL, forks are disabled if not configured.
+func readGenesisFile(uri string) (*genesis.CustomGenesis, error) {
 	var (
 		reader io.ReadCloser
 		err    error
@@ -258,13 +287,13 @@ func parseGenesisFile(uri string) (*genesis.Genesis, thor.ForkConfig, error) {
 	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
 		res, err := http.Get(uri) // #nosec
 		if err != nil {
-			return nil, thor.ForkConfig{}, errors.Wrap(err, "http get genesis file")
+			return nil, errors.Wrap(err, "http get genesis file")
 		}
 		reader = res.Body
 	} else {
 		reader, err = os.Open(uri)
 		if err != nil {
-			return nil, thor.ForkConfig{}, errors.Wrap(err, "open genesis file")
+			return nil, errors.Wrap(err, "open genesis file")
 		}
 	}
 	defer reader.Close()
@@ -277,15 +306,9 @@ func parseGenesisFile(uri string) (*genesis.Genesis, thor.ForkConfig, error) {
 	gen.ForkConfig = &forkConfig
 
 	if err := decoder.Decode(&gen); err != nil {
-		return nil, thor.ForkConfig{}, errors.Wrap(err, "decode genesis file")
-	}
-
-	customGen, err := genesis.NewCustomNet(&gen)
-	if err != nil {
-		return nil, thor.ForkConfig{}, errors.Wrap(err, "build genesis")
+		return nil, errors.Wrap(err, "decode genesis file")
 	}
-
-	return customGen, forkConfig, nil
+	return &gen, nil
 }
 
 func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool) api.Config {
@@ -296,12 +319,18 @@ func makeAPIConfig(ctx *cli.Context, logAPIRequests *atomic.Bool, soloMode bool)
 		PprofOn:           ctx.Bool(pprofFlag.Name),
 		SkipLogs:          ctx.Bool(skipLogsFlag.Name),
 		AllowCustomTracer: ctx.Bool(apiAllowCustomTracerFlag.Name),
-		EnableReqLogger:   logAPIRequests,
-		EnableMetrics:     ctx.Bool(enableMetricsFlag.Name),
-		LogsLimit:         ctx.Uint64(apiLogsLimitFlag.Name),
-		AllowedTracers:    parseTracerList(strings.TrimSpace(ctx.String(allowedTracersFlag.Name))),
-		EnableDeprecated:  ctx.Bool(apiEnableDeprecatedFlag.Name),
-		SoloMode:          soloMode,
+		CustomTracerLimits: js.Limits{
+			MaxSteps:      ctx.Uint64(apiTracerMaxStepsFlag.Name),
+			MaxMemory:     ctx.Uint64(apiTracerMaxMemoryFlag.Name),
+			MaxOutputSize: ctx.Uint64(apiTracerMaxOutputFlag.Name),
+			MaxDuration:   time.Duration(ctx.Uint64(apiTracerTimeoutFlag.Name)) * time.Millisecond,
+		},
+		EnableReqLogger:  logAPIRequests,
+		EnableMetrics:    ctx.Bool(enableMetricsFlag.Name),
+		LogsLimit:        ctx.Uint64(apiLogsLimitFlag.Name),
+		AllowedTracers:   parseTracerList(strings.TrimSpace(ctx.String(allowedTracersFlag.Name))),
+		EnableDeprecated: ctx.Bool(apiEnableDeprecatedFlag.Name),
+		SoloMode:         soloMode,
 	}
 }
 
@@ -334,7 +363,7 @@ func makeInstanceDir(ctx *cli.Context, gene *genesis.Genesis) (string, error) {
 	return instanceDir, nil
 }
 
-func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
+func makeMainDBOptions(ctx *cli.Context) *muxdb.Options {
 	cacheMB := normalizeCacheSize(ctx.Int(cacheFlag.Name))
 	log.Debug("cache size(MB)", "size", cacheMB)
 
@@ -349,6 +378,7 @@ func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
 		OpenFilesCacheCapacity:     fdCache,
 		ReadCacheMB:                256, // rely on os page cache other than huge db read cache.
 		WriteBufferMB:              128,
+		Engine:                     ctx.String(dbEngineFlag.Name),
 	}
 
 	// go-ethereum stuff
@@ -364,15 +394,52 @@ func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
 	} else {
 		opts.TrieHistPartitionFactor = 524288
 	}
+	return &opts
+}
 
+func openMainDB(ctx *cli.Context, dir string) (*muxdb.MuxDB, error) {
 	path := filepath.Join(dir, "main.db")
-	db, err := muxdb.Open(path, &opts)
+	db, err := muxdb.Open(path, makeMainDBOptions(ctx))
 	if err != nil {
 		return nil, errors.Wrapf(err, "open main database [%v]", path)
 	}
 	return db, nil
 }
 
+// parsePrunerRetention parses the retention in blocks, or in time like "720h" or "30d".
+// Zero is returned if not specified, to use the pruner's built-in retention.
+func parsePrunerRetention(str string, blockInterval uint64) (uint32, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return 0, nil
+	}
+
+	var blocks uint64
+	if n, err := strconv.ParseUint(str, 10, 32); err == nil {
+		blocks = n
+	} else {
+		var dur time.Duration
+		if days, ok := strings.CutSuffix(str, "d"); ok {
+			n, err := strconv.ParseUint(days, 10, 32)
+			if err != nil {
+				return 0, fmt.Errorf("invalid retention %q", str)
+			}
+			dur = time.Duration(n) * 24 * time.Hour
+		} else if dur, err = time.ParseDuration(str); err != nil {
+			return 0, fmt.Errorf("invalid retention %q", str)
+		}
+		blocks = uint64(dur/time.Second) / blockInterval
+	}
+
+	if blocks < thor.MaxStateHistory {
+		return 0, fmt.Errorf("retention %q is less than %d blocks required by the EVM", str, thor.MaxStateHistory)
+	}
+	if blocks > math.MaxUint32 {
+		return 0, fmt.Errorf("retention %q is too large", str)
+	}
+	return uint32(blocks), nil
+}
+
 func normalizeCacheSize(sizeMB int) int {
 	if sizeMB < 128 {
 		sizeMB = 128
@@ -422,8 +489,60 @@ func openLogDB(dir string) (*logdb.LogDB, error) {
 	return db, nil
 }
 
-func initChainRepository(gene *genesis.Genesis, mainDB *muxdb.MuxDB, logDB *logdb.LogDB) (*chain.Repository, error) {
-	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(state.NewStater(mainDB))
+// openFreezer opens the freezer of ancient blocks and attaches it to the repository.
+// If the freezer doesn't exist, it's created only if create is true, otherwise nil is returned.
+func openFreezer(dir string, repo *chain.Repository, create bool) (*chain.Freezer, error) {
+	path := filepath.Join(dir, "ancient")
+	if !create {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	freezer, err := chain.OpenFreezer(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open freezer [%v]", path)
+	}
+	repo.AttachFreezer(freezer)
+	return freezer, nil
+}
+
+// initHistoryIndex enables the history index of the main database, which indexes blocks above all existing ones,
+// or deletes the index if dropped. An existing index is kept up to date even if not enabled, since blocks imported
+// meanwhile would be missing from it otherwise.
+func initHistoryIndex(ctx context.Context, db *muxdb.MuxDB, repo *chain.Repository, enable, drop bool) error {
+	if drop {
+		if enable {
+			return errors.New("history index can not be both enabled and dropped")
+		}
+		if db.History() != nil {
+			log.Info("deleting history index...")
+			if err := db.DisableHistory(ctx); err != nil {
+				return errors.Wrap(err, "delete history index")
+			}
+		}
+		return nil
+	}
+	if h := db.History(); h != nil {
+		log.Info("history index enabled", "since", h.Since())
+		return nil
+	}
+	if !enable {
+		return nil
+	}
+	maxNum, err := repo.GetMaxBlockNum()
+	if err != nil {
+		return err
+	}
+	h, err := db.EnableHistory(maxNum + 1)
+	if err != nil {
+		return errors.Wrap(err, "enable history index")
+	}
+	log.Info("history index enabled", "since", h.Since())
+	return nil
+}
+
+func initChainRepository(gene *genesis.Genesis, stater *state.Stater, mainDB *muxdb.MuxDB, logDB *logdb.LogDB) (*chain.Repository, error) {
+	genesisBlock, genesisEvents, genesisTransfers, err := gene.Build(stater)
 	if err != nil {
 		return nil, errors.Wrap(err, "build genesis block")
 	}
@@ -433,11 +552,7 @@ func initChainRepository(gene *genesis.Genesis, mainDB *muxdb.MuxDB, logDB *logd
 		return nil, errors.Wrap(err, "initialize block chain")
 	}
 	w := logDB.NewWriter()
-	if err := w.Write(genesisBlock, tx.Receipts{{
-		Outputs: []*tx.Output{
-			{Events: genesisEvents, Transfers: genesisTransfers},
-		},
-	}}); err != nil {
+	if err := w.Write(genesisBlock, genesisReceipts(genesisEvents, genesisTransfers)); err != nil {
 		return nil, errors.Wrap(err, "write genesis logs")
 	}
 	if err := w.Commit(); err != nil {
@@ -446,6 +561,15 @@ func initChainRepository(gene *genesis.Genesis, mainDB *muxdb.MuxDB, logDB *logd
 	return repo, nil
 }
 
+// genesisReceipts wraps events and transfers of the genesis block into receipts, which are written into the log db.
+func genesisReceipts(events tx.Events, transfers tx.Transfers) tx.Receipts {
+	return tx.Receipts{{
+		Outputs: []*tx.Output{
+			{Events: events, Transfers: transfers},
+		},
+	}}
+}
+
 func beneficiary(ctx *cli.Context) (*thor.Address, error) {
 	value := ctx.String(beneficiaryFlag.Name)
 	if value == "" {
@@ -458,6 +582,40 @@ func beneficiary(ctx *cli.Context) (*thor.Address, error) {
 	return &addr, nil
 }
 
+func makeDevnetOptions(ctx *cli.Context) (genesis.DevnetOptions, error) {
+	count := ctx.Uint64(devAccountsFlag.Name)
+	if count == 0 {
+		return genesis.DevnetOptions{}, errors.New("dev-accounts cannot be zero")
+	}
+
+	var accounts []genesis.DevAccount
+	if mnemonic := ctx.String(devMnemonicFlag.Name); mnemonic != "" {
+		var err error
+		if accounts, err = genesis.DeriveDevAccounts(mnemonic, int(count)); err != nil {
+			return genesis.DevnetOptions{}, errors.WithMessage(err, "derive dev accounts")
+		}
+	} else {
+		accounts = genesis.DevAccounts()
+		if count > uint64(len(accounts)) {
+			return genesis.DevnetOptions{}, fmt.Errorf("at most %d builtin dev accounts, use --%s to derive more", len(accounts), devMnemonicFlag.Name)
+		}
+		accounts = accounts[:count]
+	}
+
+	signer := ctx.Uint64(blockSignerFlag.Name)
+	if signer >= count {
+		return genesis.DevnetOptions{}, fmt.Errorf("block-signer should be less than %d", count)
+	}
+
+	unit := big.NewInt(1e18)
+	return genesis.DevnetOptions{
+		Accounts:    accounts,
+		Balance:     new(big.Int).Mul(new(big.Int).SetUint64(ctx.Uint64(devBalanceFlag.Name)), unit),
+		Energy:      new(big.Int).Mul(new(big.Int).SetUint64(ctx.Uint64(devEnergyFlag.Name)), unit),
+		BlockSigner: accounts[signer],
+	}, nil
+}
+
 func masterKeyPath(ctx *cli.Context) (string, error) {
 	configDir, err := makeConfigDir(ctx)
 	if err != nil {
@@ -466,13 +624,14 @@ func masterKeyPath(ctx *cli.Context) (string, error) {
 	return filepath.Join(configDir, "master.key"), nil
 }
 
-func loadNodeMasterFromStdin() (*ecdsa.PrivateKey, error) {
+// readPrivateKeyFromStdin reads a hex encoded private key from stdin, the prompt is shown if stdin is a terminal.
+func readPrivateKeyFromStdin(prompt string) (*ecdsa.PrivateKey, error) {
 	var (
 		input string
 		err   error
 	)
 	if isatty.IsTerminal(os.Stdin.Fd()) {
-		input, err = readPasswordFromNewTTY("Enter master key: ")
+		input, err = readPasswordFromNewTTY(prompt)
 		if err != nil {
 			return nil, err
 		}
@@ -493,7 +652,7 @@ func loadNodeMaster(ctx *cli.Context) (*node.Master, error) {
 
 	useStdin := ctx.Bool(masterKeyStdinFlag.Name)
 	if useStdin {
-		key, err = loadNodeMasterFromStdin()
+		key, err = readPrivateKeyFromStdin("Enter master key: ")
 		if err != nil {
 			return nil, errors.Wrap(err, "read master key from stdin")
 		}
@@ -515,7 +674,15 @@ func loadNodeMaster(ctx *cli.Context) (*node.Master, error) {
 	return master, nil
 }
 
-func newP2PCommunicator(ctx *cli.Context, repo *chain.Repository, txPool *txpool.TxPool, instanceDir string) (*p2p.P2P, error) {
+func newP2PCommunicator(
+	ctx *cli.Context,
+	repo *chain.Repository,
+	txPool *txpool.TxPool,
+	mainDB *muxdb.MuxDB,
+	bftEngine bft.Committer,
+	blockInterval uint64,
+	instanceDir string,
+) (*p2p.P2P, error) {
 	// known peers will be loaded/stored from/in this file
 	peersCachePath := filepath.Join(instanceDir, "peers.cache")
 
@@ -555,7 +722,7 @@ func newP2PCommunicator(ctx *cli.Context, repo *chain.Repository, txPool *txpool
 	}
 
 	return p2p.New(
-		comm.New(repo, txPool),
+		comm.New(repo, txPool, mainDB, bftEngine, blockInterval),
 		key,
 		instanceDir,
 		userNAT,
@@ -610,13 +777,22 @@ func printStartupMessage1(
 	fmt.Printf(`Starting %v
     Network      [ %v %v ]
     Best block   [ %v #%v @%v ]
-    Forks        [ %v ]%v
+    Forks        [ %v ]%v%v
     Instance dir [ %v ]
 `,
 		name,
 		gene.ID(), gene.Name(),
 		bestBlock.Header.ID(), bestBlock.Header.Number(), time.Unix(int64(bestBlock.Header.Timestamp()), 0),
 		forkConfig,
+		func() string {
+			// only custom networks may change the timing
+			if forkConfig.Timing == (thor.Timing{}) {
+				return ""
+			}
+			return fmt.Sprintf(`
+    Timing       [ block interval: %vs, checkpoint interval: %v, epoch length: %v ]`,
+				forkConfig.BlockInterval(), forkConfig.CheckpointInterval(), forkConfig.EpochLength())
+		}(),
 		func() string {
 			// solo mode does not have master, so skip this part
 			if master == nil {
@@ -16,12 +16,13 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discv5"
+	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
 	"github.com/vechain/thor/v2/co"
 	"github.com/vechain/thor/v2/comm/proto"
 	"github.com/vechain/thor/v2/log"
-	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/muxdb"
 	"github.com/vechain/thor/v2/tx"
 	"github.com/vechain/thor/v2/txpool"
 )
@@ -32,6 +33,9 @@ var logger = log.WithContext("pkg", "comm")
 type Communicator struct {
 	repo           *chain.Repository
 	txPool         *txpool.TxPool
+	db             *muxdb.MuxDB
+	bftEngine      bft.Committer
+	blockInterval  uint64
 	ctx            context.Context
 	cancel         context.CancelFunc
 	peerSet        *PeerSet
@@ -44,11 +48,16 @@ type Communicator struct {
 }
 
 // New create a new Communicator instance.
-func New(repo *chain.Repository, txPool *txpool.TxPool) *Communicator {
+// The main database and the bft engine are used to serve state sync, which is disabled if either is nil.
+// The block interval is in seconds, as configured by the fork config.
+func New(repo *chain.Repository, txPool *txpool.TxPool, db *muxdb.MuxDB, bftEngine bft.Committer, blockInterval uint64) *Communicator {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Communicator{
 		repo:           repo,
 		txPool:         txPool,
+		db:             db,
+		bftEngine:      bftEngine,
+		blockInterval:  blockInterval,
 		ctx:            ctx,
 		cancel:         cancel,
 		peerSet:        newPeerSet(),
@@ -75,7 +84,7 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 	isSynced := func() bool {
 		bestBlockTime := c.repo.BestBlockSummary().Header.Timestamp()
 		now := uint64(time.Now().Unix())
-		if bestBlockTime+thor.BlockInterval >= now {
+		if bestBlockTime+c.blockInterval >= now {
 			return true
 		}
 		if syncCount > 2 {
@@ -127,13 +136,24 @@ func (c *Communicator) Sync(ctx context.Context, handler HandleBlockStream) {
 }
 
 // Protocols returns all supported protocols.
+// The legacy version is kept for peers not upgraded, the highest version shared by both sides is used.
 func (c *Communicator) Protocols() []*p2p.Protocol {
 	return []*p2p.Protocol{
 		{
 			Name:    proto.Name,
 			Version: proto.Version,
 			Length:  proto.Length,
-			Run:     c.servePeer,
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return c.servePeer(p, rw, proto.Version)
+			},
+		},
+		{
+			Name:    proto.Name,
+			Version: proto.Version1,
+			Length:  proto.Length1,
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return c.servePeer(p, rw, proto.Version1)
+			},
 		}}
 }
 
@@ -161,8 +181,8 @@ type txsToSync struct {
 	synced bool
 }
 
-func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter) error {
-	peer := newPeer(p, rw)
+func (c *Communicator) servePeer(p *p2p.Peer, rw p2p.MsgReadWriter, version uint) error {
+	peer := newPeer(p, rw, version, c.blockInterval)
 	c.goes.Go(func() {
 		c.runPeer(peer)
 	})
@@ -197,7 +217,7 @@ func (c *Communicator) runPeer(peer *Peer) {
 	if localClock < remoteClock {
 		diff = remoteClock - localClock
 	}
-	if diff > thor.BlockInterval*2 {
+	if diff > c.blockInterval*2 {
 		peer.logger.Debug("failed to handshake", "err", "sys time diff too large")
 		return
 	}
@@ -12,9 +12,12 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/bft"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -165,6 +168,121 @@ func (c *Communicator) handleRPC(peer *Peer, msg *p2p.Msg, write func(any), txsT
 			}
 			write(toSend)
 		}
+	case proto.MsgGetFinalizedID:
+		if err := msg.Decode(&struct{}{}); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+		if c.bftEngine == nil || c.db == nil {
+			// not serving state sync
+			write(c.repo.GenesisBlock().Header().ID())
+		} else {
+			write(c.bftEngine.Finalized())
+		}
+	case proto.MsgGetReceipts:
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxSize = 512 * 1024
+		result := make([]*proto.BlockReceipts, 0, len(ids))
+		var size thor.StorageSize
+		for _, id := range ids {
+			if size >= maxSize {
+				break
+			}
+			summary, err := c.repo.GetBlockSummary(id)
+			if err != nil {
+				if !c.repo.IsNotFound(err) {
+					log.Error("failed to get block summary", "err", err)
+				}
+				break
+			}
+			receipts, err := c.repo.GetBlockReceipts(id)
+			if err != nil {
+				if !c.repo.IsNotFound(err) {
+					log.Error("failed to get block receipts", "err", err)
+				}
+				break
+			}
+			raw, _ := rlp.EncodeToBytes(receipts)
+			result = append(result, &proto.BlockReceipts{Receipts: raw, Conflicts: summary.Conflicts})
+			size += thor.StorageSize(len(raw))
+		}
+		write(result)
+	case proto.MsgGetQualities:
+		var ids []thor.Bytes32
+		if err := msg.Decode(&ids); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		result := make([]*proto.Quality, 0, min(len(ids), maxQualitiesPerRequest))
+		if c.db != nil {
+			for _, id := range ids[:min(len(ids), maxQualitiesPerRequest)] {
+				quality, err := bft.LoadQuality(c.db, id)
+				if err != nil {
+					if !c.db.IsNotFound(err) {
+						log.Error("failed to load quality", "err", err)
+					}
+					continue
+				}
+				result = append(result, &proto.Quality{BlockID: id, Quality: quality})
+			}
+		}
+		write(result)
+	case proto.MsgGetTrieNodes:
+		var keys []*proto.TrieNodeKey
+		if err := msg.Decode(&keys); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxSize = 512 * 1024
+		result := make([][]byte, 0, len(keys))
+		var size thor.StorageSize
+		if c.db != nil {
+			for _, key := range keys {
+				if size >= maxSize {
+					break
+				}
+				blob, err := c.db.GetTrieNode(key.Name, key.Path, trie.Version{Major: key.Major, Minor: key.Minor})
+				if err != nil {
+					if !c.db.IsNotFound(err) {
+						log.Error("failed to get trie node", "err", err)
+					}
+					break
+				}
+				result = append(result, blob)
+				size += thor.StorageSize(len(blob))
+			}
+		}
+		write(result)
+	case proto.MsgGetCodes:
+		var hashes []thor.Bytes32
+		if err := msg.Decode(&hashes); err != nil {
+			return errors.WithMessage(err, "decode msg")
+		}
+
+		const maxSize = 512 * 1024
+		result := make([][]byte, 0, len(hashes))
+		var size thor.StorageSize
+		if c.db != nil {
+			codeStore := c.db.NewStore(state.CodeStoreName)
+			for _, hash := range hashes {
+				if size >= maxSize {
+					break
+				}
+				code, err := codeStore.Get(hash[:])
+				if err != nil {
+					if !codeStore.IsNotFound(err) {
+						log.Error("failed to get code", "err", err)
+					}
+					break
+				}
+				result = append(result, code)
+				size += thor.StorageSize(len(code))
+			}
+		}
+		write(result)
 	default:
 		return fmt.Errorf("unknown message (%v)", msg.Code)
 	}
@@ -28,7 +28,9 @@ const (
 type Peer struct {
 	*p2p.Peer
 	*rpc.RPC
-	logger log.Logger
+	logger        log.Logger
+	version       uint
+	blockInterval uint64
 
 	createdTime mclock.AbsTime
 	knownTxs    *lru.Cache
@@ -40,7 +42,7 @@ type Peer struct {
 	}
 }
 
-func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter, version uint, blockInterval uint64) *Peer {
 	dir := "outbound"
 	if peer.Inbound() {
 		dir = "inbound"
@@ -52,15 +54,22 @@ func newPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
 	knownTxs, _ := lru.New(maxKnownTxs)
 	knownBlocks, _ := lru.New(maxKnownBlocks)
 	return &Peer{
-		Peer:        peer,
-		RPC:         rpc.New(peer, rw),
-		logger:      logger.New(ctx...),
-		createdTime: mclock.Now(),
-		knownTxs:    knownTxs,
-		knownBlocks: knownBlocks,
+		Peer:          peer,
+		RPC:           rpc.New(peer, rw),
+		logger:        logger.New(ctx...),
+		version:       version,
+		blockInterval: blockInterval,
+		createdTime:   mclock.Now(),
+		knownTxs:      knownTxs,
+		knownBlocks:   knownBlocks,
 	}
 }
 
+// Version returns the negotiated protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
 // Head returns head block ID and total score.
 func (p *Peer) Head() (id thor.Bytes32, totalScore uint64) {
 	p.head.Lock()
@@ -80,7 +89,7 @@ func (p *Peer) UpdateHead(id thor.Bytes32, totalScore uint64) {
 // MarkTransaction marks a transaction to known.
 func (p *Peer) MarkTransaction(hash thor.Bytes32) {
 	// that's 10~100 block intervals
-	expiration := mclock.AbsTime(time.Second * time.Duration(thor.BlockInterval*uint64(rand.N(91)+10))) //#nosec G404
+	expiration := mclock.AbsTime(time.Second * time.Duration(p.blockInterval*uint64(rand.N(91)+10))) //#nosec G404
 
 	deadline := mclock.Now() + expiration
 	p.knownTxs.Add(hash, deadline)
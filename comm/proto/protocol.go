@@ -12,9 +12,13 @@ import (
 // Constants
 const (
 	Name              = "thor"
-	Version    uint   = 1
-	Length     uint64 = 8
+	Version    uint   = 2
+	Length     uint64 = 13
 	MaxMsgSize        = 10 * 1024 * 1024
+
+	// Version1 and Length1 define the legacy protocol without state sync messages.
+	Version1 uint   = 1
+	Length1  uint64 = 8
 )
 
 // Protocol messages of thor
@@ -27,6 +31,13 @@ const (
 	MsgGetBlockIDByNumber
 	MsgGetBlocksFromNumber // fetch blocks from given number (including given number)
 	MsgGetTxs
+
+	// since version 2, for state sync
+	MsgGetFinalizedID
+	MsgGetReceipts  // fetch receipts of given blocks
+	MsgGetQualities // fetch bft qualities of given blocks
+	MsgGetTrieNodes // fetch trie nodes of given keys
+	MsgGetCodes     // fetch contract codes of given hashes
 )
 
 // MsgName convert msg code to string.
@@ -48,6 +59,16 @@ func MsgName(msgCode uint64) string {
 		return "MsgGetBlocksFromNumber"
 	case MsgGetTxs:
 		return "MsgGetTxs"
+	case MsgGetFinalizedID:
+		return "MsgGetFinalizedID"
+	case MsgGetReceipts:
+		return "MsgGetReceipts"
+	case MsgGetQualities:
+		return "MsgGetQualities"
+	case MsgGetTrieNodes:
+		return "MsgGetTrieNodes"
+	case MsgGetCodes:
+		return "MsgGetCodes"
 	default:
 		return fmt.Sprintf("unknown msg code(%v)", msgCode)
 	}
@@ -23,6 +23,26 @@ type (
 		BestBlockID    thor.Bytes32
 		TotalScore     uint64
 	}
+
+	// BlockReceipts result item of MsgGetReceipts.
+	BlockReceipts struct {
+		Receipts  rlp.RawValue // rlp encoded tx.Receipts
+		Conflicts uint32       // the minor version of the block's state
+	}
+
+	// Quality result item of MsgGetQualities.
+	Quality struct {
+		BlockID thor.Bytes32
+		Quality uint32
+	}
+
+	// TrieNodeKey locates a trie node, arg item of MsgGetTrieNodes.
+	TrieNodeKey struct {
+		Name  string
+		Path  []byte // hex nibbles
+		Major uint32
+		Minor uint32
+	}
 )
 
 // RPC defines RPC interface.
@@ -94,3 +114,52 @@ func GetTxs(ctx context.Context, rpc RPC) (tx.Transactions, error) {
 	}
 	return txs, nil
 }
+
+// GetFinalizedID get the id of the finalized block from remote peer.
+func GetFinalizedID(ctx context.Context, rpc RPC) (thor.Bytes32, error) {
+	var id thor.Bytes32
+	if err := rpc.Call(ctx, MsgGetFinalizedID, &struct{}{}, &id); err != nil {
+		return thor.Bytes32{}, err
+	}
+	return id, nil
+}
+
+// GetReceipts get receipts of blocks from remote peer.
+// The result corresponds to a prefix of ids, which might be shorter.
+func GetReceipts(ctx context.Context, rpc RPC, ids []thor.Bytes32) ([]*BlockReceipts, error) {
+	var result []*BlockReceipts
+	if err := rpc.Call(ctx, MsgGetReceipts, ids, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetQualities get bft qualities of blocks from remote peer.
+// Blocks without quality are omitted.
+func GetQualities(ctx context.Context, rpc RPC, ids []thor.Bytes32) ([]*Quality, error) {
+	var result []*Quality
+	if err := rpc.Call(ctx, MsgGetQualities, ids, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTrieNodes get blobs of trie nodes from remote peer.
+// The result corresponds to a prefix of keys, which might be shorter.
+func GetTrieNodes(ctx context.Context, rpc RPC, keys []*TrieNodeKey) ([][]byte, error) {
+	var result [][]byte
+	if err := rpc.Call(ctx, MsgGetTrieNodes, keys, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCodes get contract codes from remote peer.
+// The result corresponds to a prefix of hashes, which might be shorter.
+func GetCodes(ctx context.Context, rpc RPC, hashes []thor.Bytes32) ([][]byte, error) {
+	var result [][]byte
+	if err := rpc.Call(ctx, MsgGetCodes, hashes, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,574 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/bft"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"github.com/vechain/thor/v2/tx"
+)
+
+const (
+	stateSyncMinPeers      = 3
+	maxReceiptsPerRequest  = 256
+	maxQualitiesPerRequest = 1024
+	maxNodesPerRequest     = 384
+	maxCodesPerRequest     = 128
+)
+
+// stateSyncPeerWait is how long to wait for enough peers, before state sync starts with fewer peers.
+var stateSyncPeerWait = 30 * time.Second
+
+// SyncState downloads blocks up to the given checkpoint, which is a finalized block trusted by the caller,
+// and the state of its parent, so that a fresh node catches up without executing all blocks.
+//
+// Blocks are verified by the chain of ids back from the checkpoint, receipts by roots in headers,
+// and the state node by node starting from the state root in the header. Block conflicts, bft qualities,
+// versions and metadata of trie nodes are not covered by hashes, they are trusted as served by peers.
+//
+// Blocks before the checkpoint are added without changing the best block, while the checkpoint block
+// is returned along with its conflicts, which is expected to be executed on the synced state by the caller.
+// Downloaded nodes are kept, so that they are not fetched again if state sync is interrupted.
+func (c *Communicator) SyncState(ctx context.Context, forkConfig thor.ForkConfig, checkpoint thor.Bytes32) (*block.Block, uint32, error) {
+	if c.db == nil {
+		return nil, 0, errors.New("database not available")
+	}
+	if c.repo.BestBlockSummary().Header.Number() != 0 {
+		return nil, 0, errors.New("database is not empty")
+	}
+	if block.Number(checkpoint) == 0 {
+		return nil, 0, errors.New("checkpoint should be after the genesis block")
+	}
+
+	peers, err := c.awaitSyncPeers(ctx, checkpoint)
+	if err != nil {
+		return nil, 0, err
+	}
+	logger.Info("state sync started", "checkpoint", block.Number(checkpoint), "peers", len(peers))
+
+	s := &stateSyncer{
+		ctx:        ctx,
+		repo:       c.repo,
+		db:         c.db,
+		peers:      peers,
+		checkpoint: checkpoint,
+		lastLog:    time.Now(),
+	}
+	blk, conflicts, err := s.syncBlocks()
+	if err != nil {
+		return nil, 0, errors.WithMessage(err, "sync blocks")
+	}
+	parent, err := c.repo.GetBlockSummary(blk.Header().ParentID())
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.syncQualities(forkConfig, parent); err != nil {
+		return nil, 0, errors.WithMessage(err, "sync qualities")
+	}
+	if err := s.syncState(parent); err != nil {
+		return nil, 0, errors.WithMessage(err, "sync state")
+	}
+	return blk, conflicts, nil
+}
+
+// awaitSyncPeers waits for peers supporting state sync, which have finalized the checkpoint or later blocks.
+// Peers not having the checkpoint in their chains are dropped once blocks requested.
+func (c *Communicator) awaitSyncPeers(ctx context.Context, checkpoint thor.Bytes32) (Peers, error) {
+	var (
+		start  = time.Now()
+		ticker = time.NewTicker(time.Second)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		peers := c.peerSet.Slice().Filter(func(peer *Peer) bool {
+			return peer.Version() >= proto.Version
+		})
+		if len(peers) == 0 || (len(peers) < stateSyncMinPeers && time.Since(start) < stateSyncPeerWait) {
+			logger.Debug("waiting for peers to sync state", "peers", len(peers))
+			continue
+		}
+
+		var synced Peers
+		for _, peer := range peers {
+			id, err := proto.GetFinalizedID(ctx, peer)
+			if err != nil {
+				peer.logger.Debug("failed to get finalized id", "err", err)
+				continue
+			}
+			if block.Number(id) >= block.Number(checkpoint) {
+				synced = append(synced, peer)
+			}
+		}
+		if len(synced) > 0 {
+			return synced, nil
+		}
+		logger.Debug("checkpoint not finalized by peers")
+	}
+}
+
+// stateSyncer downloads data from a set of peers.
+type stateSyncer struct {
+	ctx        context.Context
+	repo       *chain.Repository
+	db         *muxdb.MuxDB
+	peers      Peers
+	next       int
+	checkpoint thor.Bytes32
+	lastLog    time.Time
+}
+
+// request calls fn with peers in turn until succeeded, peers failed are not requested again.
+func (s *stateSyncer) request(fn func(peer *Peer) error) error {
+	for {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		if len(s.peers) == 0 {
+			return errors.New("no peers available")
+		}
+		s.next = (s.next + 1) % len(s.peers)
+		peer := s.peers[s.next]
+		err := fn(peer)
+		if err == nil {
+			return nil
+		}
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		peer.logger.Debug("state sync request failed", "err", err)
+		s.peers = slices.Delete(s.peers, s.next, s.next+1)
+	}
+}
+
+// logProgress logs at intervals.
+func (s *stateSyncer) logProgress(msg string, ctx ...any) {
+	if time.Since(s.lastLog) >= 10*time.Second {
+		s.lastLog = time.Now()
+		logger.Info(msg, ctx...)
+	}
+}
+
+// syncBlocks downloads and adds blocks before the checkpoint, and returns the checkpoint block with its conflicts.
+func (s *stateSyncer) syncBlocks() (*block.Block, uint32, error) {
+	var (
+		target = block.Number(s.checkpoint)
+		parent = s.repo.GenesisBlock().Header()
+	)
+	for {
+		var blocks []*block.Block
+		if err := s.request(func(peer *Peer) error {
+			result, err := proto.GetBlocksFromNumber(s.ctx, peer, parent.Number()+1)
+			if err != nil {
+				return err
+			}
+			if len(result) == 0 {
+				return errors.New("no blocks")
+			}
+			blocks = blocks[:0]
+			prev := parent
+			for _, raw := range result {
+				var blk block.Block
+				if err := rlp.DecodeBytes(raw, &blk); err != nil {
+					return errors.Wrap(err, "invalid block")
+				}
+				header := blk.Header()
+				if header.ParentID() != prev.ID() {
+					return errors.New("broken sequence")
+				}
+				if header.TxsRoot() != blk.Transactions().RootHash() {
+					return fmt.Errorf("txs root mismatch of block #%d", header.Number())
+				}
+				blocks = append(blocks, &blk)
+				prev = header
+				if header.Number() == target {
+					if header.ID() != s.checkpoint {
+						return errors.New("checkpoint mismatch")
+					}
+					break
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, 0, err
+		}
+
+		receipts, err := s.fetchReceipts(blocks)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i, blk := range blocks {
+			if blk.Header().Number() == target {
+				// executed after the state synced
+				return blk, receipts[i].Conflicts, nil
+			}
+			var r tx.Receipts
+			if err := rlp.DecodeBytes(receipts[i].Receipts, &r); err != nil {
+				return nil, 0, err
+			}
+			if err := s.repo.AddBlock(blk, r, receipts[i].Conflicts, false); err != nil {
+				return nil, 0, err
+			}
+		}
+		parent = blocks[len(blocks)-1].Header()
+		s.logProgress("syncing blocks", "block", parent.Number(), "checkpoint", target)
+	}
+}
+
+// fetchReceipts fetches receipts of blocks and verifies them.
+func (s *stateSyncer) fetchReceipts(blocks []*block.Block) ([]*proto.BlockReceipts, error) {
+	correctRoots := thor.LoadCorrectReceiptsRoots()
+
+	result := make([]*proto.BlockReceipts, 0, len(blocks))
+	for len(result) < len(blocks) {
+		batch := blocks[len(result):min(len(blocks), len(result)+maxReceiptsPerRequest)]
+		ids := make([]thor.Bytes32, 0, len(batch))
+		for _, blk := range batch {
+			ids = append(ids, blk.Header().ID())
+		}
+
+		if err := s.request(func(peer *Peer) error {
+			items, err := proto.GetReceipts(s.ctx, peer, ids)
+			if err != nil {
+				return err
+			}
+			if len(items) == 0 {
+				return errors.New("no receipts")
+			}
+			if len(items) > len(ids) {
+				return errors.New("too many receipts")
+			}
+			for i, item := range items {
+				var receipts tx.Receipts
+				if err := rlp.DecodeBytes(item.Receipts, &receipts); err != nil {
+					return errors.Wrap(err, "invalid receipts")
+				}
+				header := batch[i].Header()
+				if root := receipts.RootHash(); root != header.ReceiptsRoot() && correctRoots[header.ID().String()] != root.String() {
+					return fmt.Errorf("receipts root mismatch of block #%d", header.Number())
+				}
+			}
+			result = append(result, items...)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// syncQualities downloads bft qualities saved at store points no later than the given block.
+func (s *stateSyncer) syncQualities(forkConfig thor.ForkConfig, summary *chain.BlockSummary) error {
+	var (
+		interval = forkConfig.CheckpointInterval()
+		last     = summary.Header.Number()
+		c        = s.repo.NewChain(summary.Header.ID())
+		ids      []thor.Bytes32
+	)
+	if forkConfig.FINALITY > last {
+		return nil
+	}
+	for n := forkConfig.FINALITY/interval*interval + interval - 1; n <= last; n += interval {
+		id, err := c.GetBlockID(n)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	for len(ids) > 0 {
+		batch := ids[:min(len(ids), maxQualitiesPerRequest)]
+		ids = ids[len(batch):]
+
+		var qualities []*proto.Quality
+		if err := s.request(func(peer *Peer) (err error) {
+			qualities, err = proto.GetQualities(s.ctx, peer, batch)
+			return err
+		}); err != nil {
+			return err
+		}
+		for _, q := range qualities {
+			if !slices.Contains(batch, q.BlockID) {
+				continue
+			}
+			if err := bft.SaveQuality(s.db, q.BlockID, q.Quality); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nodeTask is a trie node to be synced.
+type nodeTask struct {
+	name string
+	path []byte
+	hash thor.Bytes32
+	ver  trie.Version
+}
+
+// nodeOutput collects what verified nodes refer to.
+type nodeOutput struct {
+	tasks      []*nodeTask
+	codes      []thor.Bytes32
+	storageIDs [][]byte
+	accounts   int
+}
+
+// syncState downloads the state of the given block.
+func (s *stateSyncer) syncState(summary *chain.BlockSummary) error {
+	var (
+		root       = summary.Root()
+		tasks      = []*nodeTask{{name: state.AccountTrieName, hash: root.Hash, ver: root.Ver}}
+		writer     = s.db.NewTrieNodeWriter()
+		codeStore  = s.db.NewStore(state.CodeStoreName)
+		codeBulk   = codeStore.Bulk()
+		codes      []thor.Bytes32
+		knownCodes = make(map[thor.Bytes32]struct{})
+		storageIDs = make(map[string]struct{})
+		nodes      uint64
+		accounts   uint64
+	)
+	codeBulk.EnableAutoFlush()
+
+	// merge applies the output of verified nodes
+	merge := func(out *nodeOutput) error {
+		for _, id := range out.storageIDs {
+			if _, ok := storageIDs[string(id)]; ok {
+				return fmt.Errorf("duplicated storage id %x", id)
+			}
+			storageIDs[string(id)] = struct{}{}
+		}
+		for _, hash := range out.codes {
+			if _, ok := knownCodes[hash]; ok {
+				continue
+			}
+			knownCodes[hash] = struct{}{}
+			if has, err := codeStore.Has(hash[:]); err != nil {
+				return err
+			} else if !has {
+				codes = append(codes, hash)
+			}
+		}
+		tasks = append(tasks, out.tasks...)
+		accounts += uint64(out.accounts)
+		return nil
+	}
+
+	for len(tasks) > 0 {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		// take from the top to walk the tries depth-first, which keeps the pending tasks few
+		batch := slices.Clone(tasks[len(tasks)-min(len(tasks), maxNodesPerRequest):])
+		tasks = tasks[:len(tasks)-len(batch)]
+
+		// nodes downloaded by previous attempts
+		var (
+			missing []*nodeTask
+			out     nodeOutput
+		)
+		for _, task := range batch {
+			blob, err := s.db.GetTrieNode(task.name, task.path, task.ver)
+			if err != nil {
+				if !s.db.IsNotFound(err) {
+					return err
+				}
+				missing = append(missing, task)
+				continue
+			}
+			// a deduped node at the same path may be stale, fetch it instead
+			var local nodeOutput
+			if err := verifyNode(task, blob, root.Ver, &local); err != nil {
+				missing = append(missing, task)
+				continue
+			}
+			out.tasks = append(out.tasks, local.tasks...)
+			out.codes = append(out.codes, local.codes...)
+			out.storageIDs = append(out.storageIDs, local.storageIDs...)
+			out.accounts += local.accounts
+			nodes++
+		}
+		if err := merge(&out); err != nil {
+			return err
+		}
+
+		for len(missing) > 0 {
+			keys := make([]*proto.TrieNodeKey, 0, len(missing))
+			for _, task := range missing {
+				keys = append(keys, &proto.TrieNodeKey{Name: task.name, Path: task.path, Major: task.ver.Major, Minor: task.ver.Minor})
+			}
+
+			var (
+				blobs [][]byte
+				out   nodeOutput
+			)
+			if err := s.request(func(peer *Peer) (err error) {
+				out = nodeOutput{}
+				if blobs, err = proto.GetTrieNodes(s.ctx, peer, keys); err != nil {
+					return err
+				}
+				if len(blobs) == 0 {
+					return errors.New("no trie nodes")
+				}
+				if len(blobs) > len(keys) {
+					return errors.New("too many trie nodes")
+				}
+				for i, blob := range blobs {
+					if err := verifyNode(missing[i], blob, root.Ver, &out); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			for i, blob := range blobs {
+				if err := writer.Put(missing[i].name, missing[i].path, missing[i].ver, blob); err != nil {
+					return err
+				}
+			}
+			if err := merge(&out); err != nil {
+				return err
+			}
+			missing = missing[len(blobs):]
+			nodes += uint64(len(blobs))
+		}
+
+		if len(codes) >= maxCodesPerRequest {
+			if err := s.fetchCodes(codes, codeBulk); err != nil {
+				return err
+			}
+			codes = codes[:0]
+		}
+		s.logProgress("syncing state", "block", summary.Header.Number(), "nodes", nodes, "accounts", accounts)
+	}
+	if err := s.fetchCodes(codes, codeBulk); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if err := codeBulk.Write(); err != nil {
+		return err
+	}
+	logger.Info("state synced", "block", summary.Header.Number(), "nodes", nodes, "accounts", accounts, "codes", len(knownCodes))
+	return nil
+}
+
+// verifyNode verifies the node blob, and collects what it refers to into out.
+// Versions of nodes are also checked not to exceed the version of the state.
+func verifyNode(task *nodeTask, blob []byte, stateVer trie.Version, out *nodeOutput) error {
+	var leafErr error
+	if err := trie.VerifyNodeBlob(task.path, task.hash, blob,
+		func(path []byte, hash thor.Bytes32, ver trie.Version) {
+			if ver.Compare(task.ver) > 0 {
+				leafErr = fmt.Errorf("node version %v exceeds the parent", ver)
+				return
+			}
+			out.tasks = append(out.tasks, &nodeTask{task.name, path, hash, ver})
+		},
+		func(key []byte, leaf *trie.Leaf) {
+			if task.name != state.AccountTrieName || leafErr != nil {
+				return
+			}
+			out.accounts++
+			leafErr = verifyAccount(key, leaf, stateVer, out)
+		},
+	); err != nil {
+		return err
+	}
+	return leafErr
+}
+
+// verifyAccount collects the storage trie and code the account refers to into out.
+func verifyAccount(key []byte, leaf *trie.Leaf, stateVer trie.Version, out *nodeOutput) error {
+	var acc state.Account
+	if err := rlp.DecodeBytes(leaf.Value, &acc); err != nil {
+		return errors.Wrapf(err, "decode account %x", key)
+	}
+	if len(acc.StorageRoot) > 0 {
+		var meta state.AccountMetadata
+		if err := rlp.DecodeBytes(leaf.Meta, &meta); err != nil {
+			return errors.Wrapf(err, "decode account metadata %x", key)
+		}
+		if meta.Forked {
+			return fmt.Errorf("account %x has storage in the forked state", key)
+		}
+		if len(meta.StorageID) == 0 {
+			return fmt.Errorf("account %x has storage without id", key)
+		}
+		ver := trie.Version{Major: meta.StorageMajorVer, Minor: meta.StorageMinorVer}
+		if ver.Compare(stateVer) > 0 {
+			return fmt.Errorf("storage version %v of account %x exceeds the state", ver, key)
+		}
+		out.storageIDs = append(out.storageIDs, meta.StorageID)
+		out.tasks = append(out.tasks, &nodeTask{
+			name: state.StorageTrieName(meta.StorageID),
+			hash: thor.BytesToBytes32(acc.StorageRoot),
+			ver:  ver,
+		})
+	}
+	if len(acc.CodeHash) > 0 {
+		out.codes = append(out.codes, thor.BytesToBytes32(acc.CodeHash))
+	}
+	return nil
+}
+
+// fetchCodes fetches contract codes and verifies them.
+func (s *stateSyncer) fetchCodes(hashes []thor.Bytes32, putter kv.Putter) error {
+	for len(hashes) > 0 {
+		batch := hashes[:min(len(hashes), maxCodesPerRequest)]
+
+		var codes [][]byte
+		if err := s.request(func(peer *Peer) (err error) {
+			if codes, err = proto.GetCodes(s.ctx, peer, batch); err != nil {
+				return err
+			}
+			if len(codes) == 0 {
+				return errors.New("no codes")
+			}
+			if len(codes) > len(batch) {
+				return errors.New("too many codes")
+			}
+			for i, code := range codes {
+				if thor.Keccak256(code) != batch[i] {
+					return fmt.Errorf("code hash mismatch: %v", batch[i])
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for i, code := range codes {
+			if err := putter.Put(batch[i][:], code); err != nil {
+				return err
+			}
+		}
+		hashes = hashes[len(codes):]
+	}
+	return nil
+}
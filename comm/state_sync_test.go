@@ -0,0 +1,302 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package comm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/comm/proto"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+	"github.com/vechain/thor/v2/tx"
+	"github.com/vechain/thor/v2/txpool"
+)
+
+type testBFT struct {
+	finalized thor.Bytes32
+}
+
+func (b *testBFT) Finalized() thor.Bytes32          { return b.finalized }
+func (b *testBFT) Justified() (thor.Bytes32, error) { return b.finalized, nil }
+
+type testNode struct {
+	db     *muxdb.MuxDB
+	repo   *chain.Repository
+	stater *state.Stater
+	bft    *testBFT
+	comm   *Communicator
+}
+
+func newTestNode(t *testing.T) *testNode {
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	b0, _, _, err := genesis.NewDevnet().Build(stater)
+	require.NoError(t, err)
+	repo, err := chain.NewRepository(db, b0)
+	require.NoError(t, err)
+
+	pool := txpool.New(repo, stater, txpool.Options{Limit: 100, LimitPerAccount: 16, MaxLifetime: time.Minute})
+	t.Cleanup(pool.Close)
+
+	bft := &testBFT{b0.Header().ID()}
+	comm := New(repo, pool, db, bft, thor.BlockInterval)
+	t.Cleanup(comm.Stop)
+	return &testNode{db, repo, stater, bft, comm}
+}
+
+func (n *testNode) mint(t *testing.T, txs ...*tx.Transaction) {
+	acc := genesis.DevAccounts()[0]
+	best := n.repo.BestBlockSummary()
+	flow, err := packer.New(n.repo, n.stater, acc.Address, &acc.Address, thor.NoFork).
+		Mock(best, best.Header.Timestamp()+thor.BlockInterval, best.Header.GasLimit())
+	require.NoError(t, err)
+	for _, trx := range txs {
+		require.NoError(t, flow.Adopt(trx))
+	}
+	blk, stage, receipts, err := flow.Pack(acc.PrivateKey, 0, false)
+	require.NoError(t, err)
+	_, err = stage.Commit()
+	require.NoError(t, err)
+	require.NoError(t, n.repo.AddBlock(blk, receipts, 0, true))
+}
+
+func newTransferTx(t *testing.T, repo *chain.Repository, nonce uint64) *tx.Transaction {
+	accs := genesis.DevAccounts()
+	trx := new(tx.Builder).
+		ChainTag(repo.ChainTag()).
+		Expiration(math.MaxUint32).
+		Gas(21000).
+		Nonce(nonce).
+		Clause(tx.NewClause(&accs[1].Address).WithValue(big.NewInt(1000))).
+		Build()
+	sig, err := crypto.Sign(trx.SigningHash().Bytes(), accs[0].PrivateKey)
+	require.NoError(t, err)
+	return trx.WithSignature(sig)
+}
+
+// msgPipe is a buffered message pipe, as p2p.MsgPipe blocks writers until messages consumed.
+type msgPipe struct {
+	in   <-chan p2p.Msg
+	out  chan<- p2p.Msg
+	done <-chan struct{}
+}
+
+func (p *msgPipe) ReadMsg() (p2p.Msg, error) {
+	select {
+	case msg := <-p.in:
+		return msg, nil
+	case <-p.done:
+		return p2p.Msg{}, io.EOF
+	}
+}
+
+func (p *msgPipe) WriteMsg(msg p2p.Msg) error {
+	data, err := io.ReadAll(msg.Payload)
+	if err != nil {
+		return err
+	}
+	msg.Payload = bytes.NewReader(data)
+	select {
+	case p.out <- msg:
+		return nil
+	case <-p.done:
+		return io.EOF
+	}
+}
+
+// connect connects two communicators by in-process peers.
+func connect(t *testing.T, a, b *Communicator, version uint) {
+	var ida, idb discover.NodeID
+	rand.Read(ida[:])
+	rand.Read(idb[:])
+
+	var (
+		ab, ba = make(chan p2p.Msg, 1024), make(chan p2p.Msg, 1024)
+		done   = make(chan struct{})
+	)
+	t.Cleanup(func() { close(done) })
+	go a.servePeer(p2p.NewPeer(idb, "b", nil), &msgPipe{ba, ab, done}, version)
+	go b.servePeer(p2p.NewPeer(ida, "a", nil), &msgPipe{ab, ba, done}, version)
+}
+
+// dumpTrie reads all leaves of the trie.
+func dumpTrie(t *testing.T, db *muxdb.MuxDB, name string, root trie.Root) map[string]*trie.Leaf {
+	leaves := make(map[string]*trie.Leaf)
+	it := trie.NewIterator(db.NewTrie(name, root).NodeIterator(nil, 0))
+	for it.Next() {
+		leaves[string(it.Key)] = &trie.Leaf{Value: it.Value, Meta: it.Meta}
+	}
+	require.NoError(t, it.Err)
+	return leaves
+}
+
+func TestSyncState(t *testing.T) {
+	stateSyncPeerWait = 0
+
+	server := newTestNode(t)
+	for i := range 30 {
+		if i%5 == 0 {
+			server.mint(t, newTransferTx(t, server.repo, uint64(i)))
+		} else {
+			server.mint(t)
+		}
+	}
+	finalized, err := server.repo.NewBestChain().GetBlockSummary(20)
+	require.NoError(t, err)
+	server.bft.finalized = finalized.Header.ID()
+
+	client := newTestNode(t)
+	connect(t, server.comm, client.comm, proto.Version)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// the checkpoint must be in the chain of peers
+	_, _, err = client.comm.SyncState(ctx, thor.NoFork, thor.BytesToBytes32([]byte("fake")))
+	assert.EqualError(t, err, "checkpoint should be after the genesis block")
+	fake := finalized.Header.ID()
+	fake[31] ^= 0xff
+	_, _, err = client.comm.SyncState(ctx, thor.NoFork, fake)
+	assert.EqualError(t, err, "sync blocks: no peers available")
+
+	client = newTestNode(t)
+	connect(t, server.comm, client.comm, proto.Version)
+	blk, conflicts, err := client.comm.SyncState(ctx, thor.NoFork, finalized.Header.ID())
+	require.NoError(t, err)
+	assert.Equal(t, finalized.Header.ID(), blk.Header().ID())
+	assert.Equal(t, uint32(0), conflicts)
+
+	// the best block is left unchanged
+	assert.Equal(t, uint32(0), client.repo.BestBlockSummary().Header.Number())
+
+	parent, err := client.repo.GetBlockSummary(blk.Header().ParentID())
+	require.NoError(t, err)
+	for i := uint32(1); i <= parent.Header.Number(); i++ {
+		id, err := server.repo.NewBestChain().GetBlockID(i)
+		require.NoError(t, err)
+		want, err := server.repo.GetBlockReceipts(id)
+		require.NoError(t, err)
+		have, err := client.repo.GetBlockReceipts(id)
+		require.NoError(t, err)
+		assert.Equal(t, want.RootHash(), have.RootHash())
+	}
+
+	// the state of the parent block is complete
+	accounts := dumpTrie(t, server.db, state.AccountTrieName, parent.Root())
+	assert.Equal(t, accounts, dumpTrie(t, client.db, state.AccountTrieName, parent.Root()))
+	storages := 0
+	for _, leaf := range accounts {
+		var acc state.Account
+		require.NoError(t, rlp.DecodeBytes(leaf.Value, &acc))
+		if len(acc.StorageRoot) == 0 {
+			continue
+		}
+		var meta state.AccountMetadata
+		require.NoError(t, rlp.DecodeBytes(leaf.Meta, &meta))
+		root := trie.Root{
+			Hash: thor.BytesToBytes32(acc.StorageRoot),
+			Ver:  trie.Version{Major: meta.StorageMajorVer, Minor: meta.StorageMinorVer},
+		}
+		name := state.StorageTrieName(meta.StorageID)
+		assert.Equal(t, dumpTrie(t, server.db, name, root), dumpTrie(t, client.db, name, root))
+		storages++
+	}
+	assert.Greater(t, storages, 0)
+
+	st := client.stater.NewState(parent.Root())
+	for _, acc := range genesis.DevAccounts() {
+		want, err := server.stater.NewState(parent.Root()).GetBalance(acc.Address)
+		require.NoError(t, err)
+		have, err := st.GetBalance(acc.Address)
+		require.NoError(t, err)
+		assert.Equal(t, want, have)
+	}
+	code, err := st.GetCode(builtin.Energy.Address)
+	require.NoError(t, err)
+	assert.Equal(t, builtin.Energy.RuntimeBytecodes(), code)
+
+	// synced again from the kept nodes, as if interrupted
+	blk, _, err = client.comm.SyncState(ctx, thor.NoFork, finalized.Header.ID())
+	require.NoError(t, err)
+	assert.Equal(t, finalized.Header.ID(), blk.Header().ID())
+}
+
+func TestSyncStateLegacyPeer(t *testing.T) {
+	stateSyncPeerWait = 0
+
+	server := newTestNode(t)
+	for range 3 {
+		server.mint(t)
+	}
+	server.bft.finalized = server.repo.BestBlockSummary().Header.ID()
+
+	client := newTestNode(t)
+	connect(t, server.comm, client.comm, proto.Version1)
+
+	// legacy peers are not used
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, _, err := client.comm.SyncState(ctx, thor.NoFork, server.bft.finalized)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, client.comm.PeerCount())
+}
+
+func TestServeStateSync(t *testing.T) {
+	server := newTestNode(t)
+	server.mint(t, newTransferTx(t, server.repo, 0))
+	best := server.repo.BestBlockSummary()
+	server.bft.finalized = best.Header.ID()
+
+	client := newTestNode(t)
+	connect(t, server.comm, client.comm, proto.Version)
+	require.Eventually(t, func() bool { return client.comm.PeerCount() == 1 }, time.Second*5, time.Millisecond*10)
+	peer := client.comm.peerSet.Slice()[0]
+	ctx := context.Background()
+
+	id, err := proto.GetFinalizedID(ctx, peer)
+	require.NoError(t, err)
+	assert.Equal(t, best.Header.ID(), id)
+
+	receipts, err := proto.GetReceipts(ctx, peer, []thor.Bytes32{best.Header.ID(), {}, best.Header.ID()})
+	require.NoError(t, err)
+	assert.Len(t, receipts, 1, "stops at unknown block")
+
+	blob, err := server.db.GetTrieNode(state.AccountTrieName, nil, best.Root().Ver)
+	require.NoError(t, err)
+	nodes, err := proto.GetTrieNodes(ctx, peer, []*proto.TrieNodeKey{
+		{Name: state.AccountTrieName, Major: best.Root().Ver.Major},
+		{Name: state.AccountTrieName, Major: 100},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{blob}, nodes)
+
+	hash := thor.Keccak256(builtin.Energy.RuntimeBytecodes())
+	codes, err := proto.GetCodes(ctx, peer, []thor.Bytes32{hash})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{builtin.Energy.RuntimeBytecodes()}, codes)
+
+	qualities, err := proto.GetQualities(ctx, peer, []thor.Bytes32{best.Header.ID()})
+	require.NoError(t, err)
+	assert.Empty(t, qualities)
+}
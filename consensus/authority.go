@@ -0,0 +1,41 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package consensus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/authority"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// VerifyAuthorityChanges verifies that the authority changes of a permissioned network are signed by
+// the executor set in the genesis block.
+func VerifyAuthorityChanges(repo *chain.Repository, stater *state.Stater, sc *authority.SignedChanges) error {
+	st := stater.NewState(trie.Root{Hash: repo.GenesisBlock().Header().StateRoot()})
+	value, err := builtin.Params.Native(st).Get(thor.KeyExecutorAddress)
+	if err != nil {
+		return err
+	}
+	executor := thor.BytesToAddress(value.Bytes())
+	if executor == builtin.Executor.Address {
+		return errors.New("genesis executor is the builtin executor contract, changes can not be signed")
+	}
+
+	signer, err := sc.Signer()
+	if err != nil {
+		return err
+	}
+	if signer != executor {
+		return fmt.Errorf("signer %v is not the genesis executor %v", signer, executor)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package consensus
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/builtin/authority"
+	"github.com/vechain/thor/v2/chain"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/packer"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func newAuthorityTestChain(t *testing.T, gene *genesis.Genesis) (*chain.Repository, *state.Stater) {
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	b0, _, _, err := gene.Build(stater)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := chain.NewRepository(db, b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo, stater
+}
+
+func TestVerifyAuthorityChanges(t *testing.T) {
+	devAccounts := genesis.DevAccounts()
+	sc := &authority.SignedChanges{Changes: []authority.ScheduledChange{
+		{BlockNumber: 1, Revoke: []thor.Address{devAccounts[1].Address}},
+	}}
+
+	repo, stater := newAuthorityTestChain(t, genesis.NewDevnet())
+	assert.EqualError(t, VerifyAuthorityChanges(repo, stater, sc), "invalid signature length")
+
+	assert.Nil(t, sc.Sign(devAccounts[1].PrivateKey))
+	assert.EqualError(t, VerifyAuthorityChanges(repo, stater, sc),
+		"signer "+devAccounts[1].Address.String()+" is not the genesis executor "+devAccounts[0].Address.String())
+
+	assert.Nil(t, sc.Sign(devAccounts[0].PrivateKey))
+	assert.Nil(t, VerifyAuthorityChanges(repo, stater, sc))
+
+	repo, stater = newAuthorityTestChain(t, genesis.NewMainnet())
+	assert.EqualError(t, VerifyAuthorityChanges(repo, stater, sc), "genesis executor is the builtin executor contract, changes can not be signed")
+}
+
+func TestScheduledAuthorityChanges(t *testing.T) {
+	devAccounts := genesis.DevAccounts()
+	launchTime := uint64(1526400000)
+	gene := new(genesis.Builder).
+		GasLimit(thor.InitialGasLimit).
+		Timestamp(launchTime).
+		State(func(state *state.State) error {
+			state.SetCode(builtin.Authority.Address, builtin.Authority.RuntimeBytecodes())
+			builtin.Params.Native(state).Set(thor.KeyExecutorAddress, new(big.Int).SetBytes(devAccounts[0].Address[:]))
+			for _, acc := range devAccounts {
+				builtin.Authority.Native(state).Add(acc.Address, acc.Address, thor.Bytes32{})
+			}
+			return nil
+		})
+
+	db := muxdb.NewMem()
+	stater := state.NewStater(db)
+	b0, _, _, err := gene.Build(stater)
+	assert.Nil(t, err)
+	repo, err := chain.NewRepository(db, b0)
+	assert.Nil(t, err)
+
+	schedule, err := authority.NewSchedule(&authority.SignedChanges{Changes: []authority.ScheduledChange{
+		{BlockNumber: 1, Revoke: []thor.Address{devAccounts[1].Address}},
+	}})
+	assert.Nil(t, err)
+	forkConfig := thor.NoFork
+	forkConfig.Authority = schedule
+
+	parent := repo.BestBlockSummary()
+	pack := func(forkConfig thor.ForkConfig, proposer genesis.DevAccount, parent *chain.BlockSummary) error {
+		p := packer.New(repo, stater, proposer.Address, &proposer.Address, forkConfig)
+		flow, err := p.Schedule(parent, parent.Header.Timestamp()+thor.BlockInterval)
+		if err != nil {
+			return err
+		}
+		blk, stage, receipts, err := flow.Pack(proposer.PrivateKey, 0, false)
+		if err != nil {
+			return err
+		}
+		if _, _, err := New(repo, stater, forkConfig).Process(parent, blk, flow.When(), 0); err != nil {
+			return err
+		}
+		if _, err := stage.Commit(); err != nil {
+			return err
+		}
+		return repo.AddBlock(blk, receipts, 0, true)
+	}
+
+	// the block without the scheduled changes is rejected
+	p := packer.New(repo, stater, devAccounts[0].Address, &devAccounts[0].Address, thor.NoFork)
+	flow, err := p.Schedule(parent, parent.Header.Timestamp()+thor.BlockInterval)
+	assert.Nil(t, err)
+	blk, _, _, err := flow.Pack(devAccounts[0].PrivateKey, 0, false)
+	assert.Nil(t, err)
+	_, _, err = New(repo, stater, forkConfig).Process(parent, blk, flow.When(), 0)
+	assert.ErrorContains(t, err, "state root mismatch")
+
+	assert.Nil(t, pack(forkConfig, devAccounts[0], parent))
+
+	// the revoked node is not allowed to propose any more
+	err = pack(forkConfig, devAccounts[1], repo.BestBlockSummary())
+	assert.EqualError(t, err, "unauthorized block proposer")
+	assert.Nil(t, pack(forkConfig, devAccounts[2], repo.BestBlockSummary()))
+}
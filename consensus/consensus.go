@@ -38,7 +38,7 @@ func New(repo *chain.Repository, stater *state.Stater, forkConfig thor.ForkConfi
 	return &Consensus{
 		repo:                 repo,
 		stater:               stater,
-		seeder:               poa.NewSeeder(repo),
+		seeder:               poa.NewSeeder(repo, forkConfig.EpochLength()),
 		forkConfig:           forkConfig,
 		correctReceiptsRoots: thor.LoadCorrectReceiptsRoots(),
 		candidatesCache:      candidatesCache,
@@ -86,7 +86,7 @@ func (c *Consensus) NewRuntimeForReplay(header *block.Header, skipPoA bool) (*ru
 		}
 	}
 
-	return runtime.New(
+	rt := runtime.New(
 		c.repo.NewChain(header.ParentID()),
 		state,
 		&xenv.BlockContext{
@@ -97,5 +97,9 @@ func (c *Consensus) NewRuntimeForReplay(header *block.Header, skipPoA bool) (*ru
 			GasLimit:    header.GasLimit(),
 			TotalScore:  header.TotalScore(),
 		},
-		c.forkConfig), nil
+		c.forkConfig)
+	if err := runtime.ApplyScheduledChanges(state, header.Number(), c.forkConfig); err != nil {
+		return nil, err
+	}
+	return rt, nil
 }
@@ -24,9 +24,10 @@ type Builder struct {
 	timestamp uint64
 	gasLimit  uint64
 
-	stateProcs []func(state *state.State) error
-	calls      []call
-	extraData  [28]byte
+	stateProcs     []func(state *state.State) error
+	calls          []call
+	postCallsProcs []func(state *state.State) error
+	extraData      [28]byte
 
 	forkConfig thor.ForkConfig
 }
@@ -54,6 +55,12 @@ func (b *Builder) State(proc func(state *state.State) error) *Builder {
 	return b
 }
 
+// PostCallsState add a state process, which runs after all contract calls.
+func (b *Builder) PostCallsState(proc func(state *state.State) error) *Builder {
+	b.postCallsProcs = append(b.postCallsProcs, proc)
+	return b
+}
+
 // Call add a contract call.
 func (b *Builder) Call(clause *tx.Clause, caller thor.Address) *Builder {
 	b.calls = append(b.calls, call{clause, caller})
@@ -111,6 +118,12 @@ func (b *Builder) Build(stater *state.Stater) (blk *block.Block, events tx.Event
 		transfers = append(transfers, out.Transfers...)
 	}
 
+	for _, proc := range b.postCallsProcs {
+		if err := proc(state); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "post calls state process")
+		}
+	}
+
 	stage, err := state.Stage(trie.Version{})
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "stage")
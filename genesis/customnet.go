@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/vechain/thor/v2/builtin"
 	"github.com/vechain/thor/v2/state"
 	"github.com/vechain/thor/v2/thor"
@@ -29,6 +30,9 @@ type CustomGenesis struct {
 	Params     Params           `json:"params"`
 	Executor   Executor         `json:"executor"`
 	ForkConfig *thor.ForkConfig `json:"forkConfig"`
+
+	ParamsSchedule []ScheduledParams `json:"paramsSchedule,omitempty"`
+	Timing         *thor.Timing      `json:"timing,omitempty"`
 }
 
 // NewCustomNet create custom network genesis.
@@ -38,6 +42,10 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 	if gen.GasLimit == 0 {
 		gen.GasLimit = thor.InitialGasLimit
 	}
+	forkConfig := thor.NoFork
+	if gen.ForkConfig != nil {
+		forkConfig = *gen.ForkConfig
+	}
 	var executor thor.Address
 	if gen.Params.ExecutorAddress != nil {
 		executor = *gen.Params.ExecutorAddress
@@ -48,7 +56,7 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 	builder := new(Builder).
 		Timestamp(launchTime).
 		GasLimit(gen.GasLimit).
-		ForkConfig(*gen.ForkConfig).
+		ForkConfig(forkConfig).
 		State(func(state *state.State) error {
 			// alloc builtin contracts
 			if err := state.SetCode(builtin.Authority.Address, builtin.Authority.RuntimeBytecodes()); err != nil {
@@ -111,6 +119,13 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 						state.SetStorage(a.Address, thor.MustParseBytes32(k), v)
 					}
 				}
+				for k, v := range a.RawStorage {
+					key, raw, err := parseRawStorage(k, v)
+					if err != nil {
+						return fmt.Errorf("%s: %v", a.Address, err)
+					}
+					state.SetRawStorage(a.Address, key, raw)
+				}
 			}
 
 			return builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
@@ -170,9 +185,28 @@ func NewCustomNet(gen *CustomGenesis) (*Genesis, error) {
 		return nil, errors.New("at least one authority node")
 	}
 	// add initial authority nodes
+	var inactive []thor.Address
 	for _, anode := range gen.Authority {
 		data := mustEncodeInput(builtin.Authority.ABI, "add", anode.MasterAddress, anode.EndorsorAddress, anode.Identity)
 		builder.Call(tx.NewClause(&builtin.Authority.Address).WithData(data), executor)
+		if anode.Active != nil && !*anode.Active {
+			inactive = append(inactive, anode.MasterAddress)
+		}
+	}
+	if len(inactive) > 0 {
+		// nodes are added as active, and there is no contract method to deactivate them
+		builder.PostCallsState(func(state *state.State) error {
+			aut := builtin.Authority.Native(state)
+			for _, master := range inactive {
+				// a sole node is not linked, and can't be deactivated
+				if ok, err := aut.Update(master, false); err != nil {
+					return err
+				} else if !ok {
+					return fmt.Errorf("authority node %v: can't be deactivated", master)
+				}
+			}
+			return nil
+		})
 	}
 
 	if len(gen.Executor.Approvers) > 0 {
@@ -203,6 +237,8 @@ type Account struct {
 	Energy  *HexOrDecimal256        `json:"energy"`
 	Code    string                  `json:"code"`
 	Storage map[string]thor.Bytes32 `json:"storage"`
+	// RawStorage is storage values in rlp raw, for values not representable by 32 bytes, e.g. those encoded by builtins
+	RawStorage map[string]string `json:"rawStorage,omitempty"`
 }
 
 // Authority is the authority node info
@@ -210,6 +246,28 @@ type Authority struct {
 	MasterAddress   thor.Address `json:"masterAddress"`
 	EndorsorAddress thor.Address `json:"endorsorAddress"`
 	Identity        thor.Bytes32 `json:"identity"`
+	Active          *bool        `json:"active,omitempty"` // true if omitted
+}
+
+// parseRawStorage parses the key and the rlp raw value of the raw storage entry.
+func parseRawStorage(k, v string) (thor.Bytes32, rlp.RawValue, error) {
+	key, err := thor.ParseBytes32(k)
+	if err != nil {
+		return thor.Bytes32{}, nil, fmt.Errorf("invalid raw storage key %q: %v", k, err)
+	}
+	raw, err := hexutil.Decode(v)
+	if err != nil {
+		return thor.Bytes32{}, nil, fmt.Errorf("invalid raw storage value of %v: %v", key, err)
+	}
+	// empty value means deletion, otherwise it must be a single rlp item
+	if len(raw) > 0 {
+		if _, _, rest, err := rlp.Split(raw); err != nil {
+			return thor.Bytes32{}, nil, fmt.Errorf("invalid raw storage value of %v: %v", key, err)
+		} else if len(rest) > 0 {
+			return thor.Bytes32{}, nil, fmt.Errorf("invalid raw storage value of %v: trailing data", key)
+		}
+	}
+	return key, raw, nil
 }
 
 // Executor is the params for executor info
@@ -232,6 +290,66 @@ type Params struct {
 	MaxBlockProposers   *uint64          `json:"maxBlockProposers"`
 }
 
+// ScheduledParams is the params to be set at the beginning of the block, the unspecified ones are unchanged.
+type ScheduledParams struct {
+	BlockNumber uint32 `json:"blockNumber"`
+	Params
+}
+
+// NewParamsSchedule converts the scheduled params of custom genesis to the schedule applied by the runtime.
+// It returns nil if nothing scheduled.
+func NewParamsSchedule(scheduled []ScheduledParams) (*thor.ParamsSchedule, error) {
+	if len(scheduled) == 0 {
+		return nil, nil
+	}
+
+	schedule := make(thor.ParamsSchedule)
+	for _, sp := range scheduled {
+		if sp.BlockNumber == 0 {
+			return nil, errors.New("params of genesis block should be set in params")
+		}
+		if _, ok := schedule[sp.BlockNumber]; ok {
+			return nil, fmt.Errorf("#%d: duplicated block number", sp.BlockNumber)
+		}
+
+		var changes []thor.ParamsChange
+		push := func(name string, key thor.Bytes32, value *HexOrDecimal256) error {
+			if value == nil {
+				return nil
+			}
+			if (*big.Int)(value).Sign() < 0 {
+				return fmt.Errorf("#%d: %s must be a non-negative integer", sp.BlockNumber, name)
+			}
+			changes = append(changes, thor.ParamsChange{Key: key, Value: new(big.Int).Set((*big.Int)(value))})
+			return nil
+		}
+		if err := push("rewardRatio", thor.KeyRewardRatio, sp.RewardRatio); err != nil {
+			return nil, err
+		}
+		if err := push("baseGasPrice", thor.KeyBaseGasPrice, sp.BaseGasPrice); err != nil {
+			return nil, err
+		}
+		if err := push("proposerEndorsement", thor.KeyProposerEndorsement, sp.ProposerEndorsement); err != nil {
+			return nil, err
+		}
+		if addr := sp.ExecutorAddress; addr != nil {
+			changes = append(changes, thor.ParamsChange{Key: thor.KeyExecutorAddress, Value: new(big.Int).SetBytes(addr[:])})
+		}
+		if m := sp.MaxBlockProposers; m != nil {
+			if *m == 0 {
+				return nil, fmt.Errorf("#%d: maxBlockProposers must be a positive integer", sp.BlockNumber)
+			}
+			changes = append(changes, thor.ParamsChange{Key: thor.KeyMaxBlockProposers, Value: new(big.Int).SetUint64(*m)})
+		}
+
+		if len(changes) == 0 {
+			return nil, fmt.Errorf("#%d: no params specified", sp.BlockNumber)
+		}
+		schedule[sp.BlockNumber] = changes
+	}
+	return &schedule, nil
+}
+
 // hexOrDecimal256 marshals big.Int as hex or decimal.
 // Copied from go-ethereum/common/math and implement json. Marshaler
 type HexOrDecimal256 math.HexOrDecimal256
@@ -72,6 +72,11 @@ func TestNewCustomNet(t *testing.T) {
 	genesisBlock, err := genesis.NewCustomNet(&customGenesis)
 	assert.NoError(t, err, "NewCustomNet should not return an error")
 	assert.NotNil(t, genesisBlock, "NewCustomNet should return a non-nil Genesis object")
+
+	// forks are disabled if the fork config is null
+	customGenesis.ForkConfig = nil
+	_, err = genesis.NewCustomNet(&customGenesis)
+	assert.NoError(t, err)
 }
 
 func TestNewCustomNetPanicInvalidApprovers(t *testing.T) {
@@ -182,3 +187,47 @@ func TestHexOrDecimal256MarshalUnmarshalWithNilError(t *testing.T) {
 	err := unmarshaledValue.UnmarshalJSON([]byte(originalHex))
 	assert.NoError(t, err, "Unmarshaling should not produce an error")
 }
+
+func TestNewParamsSchedule(t *testing.T) {
+	bgp := genesis.HexOrDecimal256(*big.NewInt(1))
+	negative := genesis.HexOrDecimal256(*big.NewInt(-1))
+	mbp := uint64(3)
+	zero := uint64(0)
+
+	schedule, err := genesis.NewParamsSchedule(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	schedule, err = genesis.NewParamsSchedule([]genesis.ScheduledParams{
+		{BlockNumber: 10, Params: genesis.Params{BaseGasPrice: &bgp, MaxBlockProposers: &mbp}},
+		{BlockNumber: 20, Params: genesis.Params{ExecutorAddress: &genesis.DevAccounts()[0].Address}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &thor.ParamsSchedule{
+		10: {
+			{Key: thor.KeyBaseGasPrice, Value: big.NewInt(1)},
+			{Key: thor.KeyMaxBlockProposers, Value: big.NewInt(3)},
+		},
+		20: {
+			{Key: thor.KeyExecutorAddress, Value: new(big.Int).SetBytes(genesis.DevAccounts()[0].Address.Bytes())},
+		},
+	}, schedule)
+
+	tests := []struct {
+		scheduled []genesis.ScheduledParams
+		err       string
+	}{
+		{[]genesis.ScheduledParams{{BlockNumber: 0, Params: genesis.Params{BaseGasPrice: &bgp}}}, "params of genesis block should be set in params"},
+		{[]genesis.ScheduledParams{{BlockNumber: 1}}, "#1: no params specified"},
+		{[]genesis.ScheduledParams{{BlockNumber: 1, Params: genesis.Params{RewardRatio: &negative}}}, "#1: rewardRatio must be a non-negative integer"},
+		{[]genesis.ScheduledParams{{BlockNumber: 1, Params: genesis.Params{MaxBlockProposers: &zero}}}, "#1: maxBlockProposers must be a positive integer"},
+		{[]genesis.ScheduledParams{
+			{BlockNumber: 1, Params: genesis.Params{BaseGasPrice: &bgp}},
+			{BlockNumber: 1, Params: genesis.Params{BaseGasPrice: &bgp}},
+		}, "#1: duplicated block number"},
+	}
+	for _, tt := range tests {
+		_, err := genesis.NewParamsSchedule(tt.scheduled)
+		assert.EqualError(t, err, tt.err)
+	}
+}
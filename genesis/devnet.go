@@ -56,16 +56,41 @@ func DevAccounts() []DevAccount {
 	return accs
 }
 
+// DevnetOptions customizes the devnet genesis.
+type DevnetOptions struct {
+	Accounts    []DevAccount // prefunded accounts, the first one is the executor
+	Balance     *big.Int     // initial VET of each account, in wei
+	Energy      *big.Int     // initial VTHO of each account, in wei
+	BlockSigner DevAccount   // the only authority node
+}
+
+// DefaultDevnetOptions returns the options of the default devnet, which funds DevAccounts
+// and is signed by the first one.
+func DefaultDevnetOptions() DevnetOptions {
+	bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	return DevnetOptions{
+		Accounts:    DevAccounts(),
+		Balance:     bal,
+		Energy:      bal,
+		BlockSigner: DevAccounts()[0],
+	}
+}
+
 // NewDevnet create genesis for solo mode.
 func NewDevnet() *Genesis {
 	return NewDevnetWithConfig(thor.SoloFork)
 }
 
 func NewDevnetWithConfig(config thor.ForkConfig) *Genesis {
+	return NewDevnetWithOptions(config, DefaultDevnetOptions())
+}
+
+// NewDevnetWithOptions create genesis for solo mode with customized accounts.
+func NewDevnetWithOptions(config thor.ForkConfig, opts DevnetOptions) *Genesis {
 	launchTime := uint64(1526400000) // 'Wed May 16 2018 00:00:00 GMT+0800 (CST)'
 
-	executor := DevAccounts()[0].Address
-	soloBlockSigner := DevAccounts()[0]
+	executor := opts.Accounts[0].Address
+	soloBlockSigner := opts.BlockSigner
 
 	builder := new(Builder).
 		GasLimit(thor.InitialGasLimit).
@@ -91,16 +116,15 @@ func NewDevnetWithConfig(config thor.ForkConfig) *Genesis {
 
 			tokenSupply := &big.Int{}
 			energySupply := &big.Int{}
-			for _, a := range DevAccounts() {
-				bal, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
-				if err := state.SetBalance(a.Address, bal); err != nil {
+			for _, a := range opts.Accounts {
+				if err := state.SetBalance(a.Address, opts.Balance); err != nil {
 					return err
 				}
-				if err := state.SetEnergy(a.Address, bal, launchTime); err != nil {
+				if err := state.SetEnergy(a.Address, opts.Energy, launchTime); err != nil {
 					return err
 				}
-				tokenSupply.Add(tokenSupply, bal)
-				energySupply.Add(energySupply, bal)
+				tokenSupply.Add(tokenSupply, opts.Balance)
+				energySupply.Add(energySupply, opts.Energy)
 			}
 			return builtin.Energy.Native(state, launchTime).SetInitialSupply(tokenSupply, energySupply)
 		}).
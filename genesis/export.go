@@ -0,0 +1,155 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// ExportAccount reads the account with its code and storage from the state, so that it can be
+// allocated in a custom genesis. The energy is evaluated at the given block time.
+func ExportAccount(st *state.State, addr thor.Address, blockTime uint64) (*Account, error) {
+	balance, err := st.GetBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := st.GetEnergy(addr, blockTime)
+	if err != nil {
+		return nil, err
+	}
+	code, err := st.GetCode(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &Account{
+		Address: addr,
+		Balance: (*HexOrDecimal256)(balance),
+		Energy:  (*HexOrDecimal256)(energy),
+	}
+	if len(code) > 0 {
+		acc.Code = hexutil.Encode(code)
+	}
+
+	storageTrie, err := st.BuildStorageTrie(addr)
+	if err != nil {
+		return nil, err
+	}
+	it := trie.NewIterator(storageTrie.NodeIterator(nil, 0))
+	for it.Next() {
+		// the meta of storage trie leaf is the preimage of the key
+		key := thor.BytesToBytes32(it.Meta).String()
+		value, ok, err := plainStorageValue(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if acc.Storage == nil {
+				acc.Storage = make(map[string]thor.Bytes32)
+			}
+			acc.Storage[key] = value
+		} else {
+			if acc.RawStorage == nil {
+				acc.RawStorage = make(map[string]string)
+			}
+			acc.RawStorage[key] = hexutil.Encode(it.Value)
+		}
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	return acc, nil
+}
+
+// plainStorageValue decodes the rlp raw storage value as 32 bytes, ok is false if the value is not
+// encoded by State.SetStorage, e.g. rlp lists or long strings encoded by builtins.
+func plainStorageValue(raw []byte) (value thor.Bytes32, ok bool, err error) {
+	kind, content, _, err := rlp.Split(raw)
+	if err != nil {
+		return thor.Bytes32{}, false, err
+	}
+	if kind != rlp.String || len(content) > 32 {
+		return thor.Bytes32{}, false, nil
+	}
+	value = thor.BytesToBytes32(content)
+	// re-encoded to check if it's the same as being set by State.SetStorage, e.g. no leading zeros
+	if enc, _ := rlp.EncodeToBytes(bytes.TrimLeft(value[:], "\x00")); !bytes.Equal(enc, raw) {
+		return thor.Bytes32{}, false, nil
+	}
+	return value, true, nil
+}
+
+// ExportParams reads the chain params from the state.
+func ExportParams(st *state.State) (*Params, error) {
+	params := builtin.Params.Native(st)
+
+	get := func(key thor.Bytes32) (*HexOrDecimal256, error) {
+		v, err := params.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		return (*HexOrDecimal256)(v), nil
+	}
+
+	var (
+		p   Params
+		err error
+	)
+	if p.RewardRatio, err = get(thor.KeyRewardRatio); err != nil {
+		return nil, err
+	}
+	if p.BaseGasPrice, err = get(thor.KeyBaseGasPrice); err != nil {
+		return nil, err
+	}
+	if p.ProposerEndorsement, err = get(thor.KeyProposerEndorsement); err != nil {
+		return nil, err
+	}
+
+	executor, err := params.Get(thor.KeyExecutorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if executor.Sign() != 0 {
+		addr := thor.BytesToAddress(executor.Bytes())
+		p.ExecutorAddress = &addr
+	}
+
+	maxBlockProposers, err := params.Get(thor.KeyMaxBlockProposers)
+	if err != nil {
+		return nil, err
+	}
+	if maxBlockProposers.Sign() != 0 && maxBlockProposers.IsUint64() {
+		m := maxBlockProposers.Uint64()
+		p.MaxBlockProposers = &m
+	}
+	return &p, nil
+}
+
+// ExportAuthority reads all authority nodes from the state with their active flags, the inactive ones are included.
+func ExportAuthority(st *state.State) ([]Authority, error) {
+	candidates, err := builtin.Authority.Native(st).AllCandidates()
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]Authority, 0, len(candidates))
+	for _, c := range candidates {
+		active := c.Active
+		nodes = append(nodes, Authority{
+			MasterAddress:   c.NodeMaster,
+			EndorsorAddress: c.Endorsor,
+			Identity:        c.Identity,
+			Active:          &active,
+		})
+	}
+	return nodes, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestExport(t *testing.T) {
+	db := muxdb.NewMem()
+	b0, _, _, err := genesis.NewDevnet().Build(state.NewStater(db))
+	require.NoError(t, err)
+	blockTime := b0.Header().Timestamp()
+
+	// a contract with storage
+	contract := thor.BytesToAddress([]byte("contract"))
+	key := thor.BytesToBytes32([]byte("key"))
+	value := thor.BytesToBytes32([]byte("value"))
+	st := state.New(db, trie.Root{Hash: b0.Header().StateRoot()})
+	require.NoError(t, st.SetBalance(contract, big.NewInt(100)))
+	require.NoError(t, st.SetCode(contract, []byte{0x60, 0x01}))
+	st.SetStorage(contract, key, value)
+	// values encoded by builtins are not representable by 32 bytes
+	rawKey := thor.BytesToBytes32([]byte("raw"))
+	raw, err := rlp.EncodeToBytes([]any{uint64(1), []byte("value")})
+	require.NoError(t, err)
+	st.SetRawStorage(contract, rawKey, raw)
+	longKey := thor.BytesToBytes32([]byte("long"))
+	long, err := rlp.EncodeToBytes(make([]byte, 33))
+	require.NoError(t, err)
+	st.SetRawStorage(contract, longKey, long)
+	// an inactive authority node
+	inactive := genesis.DevAccounts()[1].Address
+	added, err := builtin.Authority.Native(st).Add(inactive, inactive, thor.BytesToBytes32([]byte("inactive")))
+	require.NoError(t, err)
+	require.True(t, added)
+	updated, err := builtin.Authority.Native(st).Update(inactive, false)
+	require.NoError(t, err)
+	require.True(t, updated)
+	stage, err := st.Stage(trie.Version{Major: 1})
+	require.NoError(t, err)
+	root, err := stage.Commit()
+	require.NoError(t, err)
+	st = state.New(db, trie.Root{Hash: root, Ver: trie.Version{Major: 1}})
+
+	acc, err := genesis.ExportAccount(st, contract, blockTime)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), (*big.Int)(acc.Balance))
+	assert.Equal(t, "0x6001", acc.Code)
+	assert.Equal(t, map[string]thor.Bytes32{key.String(): value}, acc.Storage)
+	assert.Equal(t, map[string]string{rawKey.String(): hexutil.Encode(raw), longKey.String(): hexutil.Encode(long)}, acc.RawStorage)
+
+	dev := genesis.DevAccounts()[1].Address
+	devAcc, err := genesis.ExportAccount(st, dev, blockTime)
+	require.NoError(t, err)
+	assert.Empty(t, devAcc.Code)
+	assert.Nil(t, devAcc.Storage)
+
+	params, err := genesis.ExportParams(st)
+	require.NoError(t, err)
+	assert.Equal(t, thor.InitialRewardRatio, (*big.Int)(params.RewardRatio))
+	assert.Equal(t, genesis.DevAccounts()[0].Address, *params.ExecutorAddress)
+	assert.Nil(t, params.MaxBlockProposers)
+
+	authority, err := genesis.ExportAuthority(st)
+	require.NoError(t, err)
+	require.Len(t, authority, 2)
+	assert.Equal(t, genesis.DevAccounts()[0].Address, authority[0].MasterAddress)
+	assert.True(t, *authority[0].Active)
+	assert.Equal(t, inactive, authority[1].MasterAddress)
+	assert.False(t, *authority[1].Active)
+
+	// launch a custom net with the exported state
+	forkConfig := thor.SoloFork
+	gene, err := genesis.NewCustomNet(&genesis.CustomGenesis{
+		LaunchTime: blockTime,
+		Accounts:   []genesis.Account{*acc, *devAcc},
+		Authority:  authority,
+		Params:     *params,
+		ForkConfig: &forkConfig,
+	})
+	require.NoError(t, err)
+
+	db = muxdb.NewMem()
+	b0, _, _, err = gene.Build(state.NewStater(db))
+	require.NoError(t, err)
+	st = state.New(db, trie.Root{Hash: b0.Header().StateRoot()})
+
+	exported, err := genesis.ExportAccount(st, contract, blockTime)
+	require.NoError(t, err)
+	assert.Equal(t, acc, exported)
+	exported, err = genesis.ExportAccount(st, dev, blockTime)
+	require.NoError(t, err)
+	assert.Equal(t, devAcc, exported)
+	exportedAuthority, err := genesis.ExportAuthority(st)
+	require.NoError(t, err)
+	assert.Equal(t, authority, exportedAuthority)
+	bgp, err := builtin.Params.Native(st).Get(thor.KeyBaseGasPrice)
+	require.NoError(t, err)
+	assert.Equal(t, (*big.Int)(params.BaseGasPrice), bgp)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// NewFork create genesis of a chain forked from the given block of another chain.
+// The genesis state is built on top of the fallback, which provides the state of the forked
+// block, so the genesis must be built by a stater with the same fallback. Its ID is bound to
+// the forked block ID.
+func NewFork(forkedID thor.Bytes32, timestamp, gasLimit uint64, fallback state.Fallback) (*Genesis, error) {
+	var extra [28]byte
+	copy(extra[:], forkedID[4:])
+
+	builder := new(Builder).
+		GasLimit(gasLimit).
+		Timestamp(timestamp).
+		ExtraData(extra)
+
+	blk, _, _, err := builder.Build(state.NewStaterWithFallback(muxdb.NewMem(), fallback))
+	if err != nil {
+		return nil, errors.Wrap(err, "build forked genesis")
+	}
+	return &Genesis{builder, blk.Header().ID(), "fork"}, nil
+}
@@ -8,6 +8,7 @@ package genesis_test
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/v2/genesis"
 	"github.com/vechain/thor/v2/muxdb"
@@ -29,3 +30,29 @@ func TestTestnetGenesis(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, v)
 }
+
+type emptyFallback struct{}
+
+func (emptyFallback) GetAccount(thor.Address) (*state.Account, []byte, error) { return nil, nil, nil }
+func (emptyFallback) GetRawStorage(thor.Address, thor.Bytes32) (rlp.RawValue, error) {
+	return nil, nil
+}
+
+func TestNewFork(t *testing.T) {
+	forkedID := thor.MustParseBytes32("0x0000000a851caf3cfdb6e899cf5958bfb1ac3413d346d43539627e6be7ec1b4a")
+	gene, err := genesis.NewFork(forkedID, 1526400000, 40_000_000, emptyFallback{})
+	assert.Nil(t, err)
+	assert.Equal(t, "fork", gene.Name())
+
+	blk, _, _, err := gene.Build(state.NewStaterWithFallback(muxdb.NewMem(), emptyFallback{}))
+	assert.Nil(t, err)
+	assert.Equal(t, gene.ID(), blk.Header().ID())
+	assert.Equal(t, uint64(1526400000), blk.Header().Timestamp())
+	assert.Equal(t, uint64(40_000_000), blk.Header().GasLimit())
+	assert.Equal(t, forkedID[4:], blk.Header().ParentID().Bytes()[4:])
+
+	// bound to the forked block
+	other, err := genesis.NewFork(thor.Bytes32{}, 1526400000, 40_000_000, emptyFallback{})
+	assert.Nil(t, err)
+	assert.NotEqual(t, gene.ID(), other.ID())
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const hardened = 0x80000000
+
+// devPath is the BIP-44 path of VeChain accounts, m/44'/818'/0'/0.
+var devPath = []uint32{44 + hardened, 818 + hardened, 0 + hardened, 0}
+
+// englishWordlist is the BIP-39 english wordlist.
+//
+//go:embed bip39_english.txt
+var englishWordlist string
+
+// englishWordIndex maps words of the english wordlist to their indexes.
+var englishWordIndex = sync.OnceValue(func() map[string]int {
+	words := strings.Fields(englishWordlist)
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		index[w] = i
+	}
+	return index
+})
+
+// DeriveDevAccounts derives count accounts from the BIP-39 mnemonic, following the
+// BIP-44 path m/44'/818'/0'/0/i, which is compatible with VeChain wallets.
+// The mnemonic must be of the english wordlist with a valid checksum, and an empty passphrase is used.
+func DeriveDevAccounts(mnemonic string, count int) ([]DevAccount, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if err := validateMnemonic(words); err != nil {
+		return nil, errors.WithMessage(err, "invalid mnemonic")
+	}
+	if count <= 0 {
+		return nil, errors.New("invalid count of accounts")
+	}
+
+	seed := pbkdf2.Key([]byte(strings.Join(words, " ")), []byte("mnemonic"), 2048, 64, sha512.New)
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chainCode := sum[:32], sum[32:]
+	var err error
+	for _, index := range devPath {
+		if key, chainCode, err = deriveChild(key, chainCode, index); err != nil {
+			return nil, err
+		}
+	}
+
+	accs := make([]DevAccount, 0, count)
+	for i := range count {
+		childKey, _, err := deriveChild(key, chainCode, uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		pk, err := crypto.ToECDSA(childKey)
+		if err != nil {
+			return nil, err
+		}
+		accs = append(accs, DevAccount{thor.Address(crypto.PubkeyToAddress(pk.PublicKey)), pk})
+	}
+	return accs, nil
+}
+
+// validateMnemonic checks the words and the checksum of the mnemonic, which is the leading
+// len(words)/3 bits of the sha256 of the entropy.
+func validateMnemonic(words []string) error {
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return errors.Errorf("%d words", len(words))
+	}
+
+	bits := new(big.Int)
+	for _, w := range words {
+		i, ok := englishWordIndex()[w]
+		if !ok {
+			return errors.Errorf("unknown word %q", w)
+		}
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(int64(i)))
+	}
+	checksumBits := uint(len(words) / 3)
+	entropyBits := uint(len(words))*11 - checksumBits
+
+	checksum := new(big.Int).And(bits, new(big.Int).SetUint64(1<<checksumBits-1))
+	entropy := new(big.Int).Rsh(bits, checksumBits).FillBytes(make([]byte, entropyBits/8))
+	hash := sha256.Sum256(entropy)
+	if uint64(hash[0]>>(8-checksumBits)) != checksum.Uint64() {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+// deriveChild derives the BIP-32 child private key and chain code.
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= hardened {
+		data = append([]byte{0}, key...)
+	} else {
+		pk, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&pk.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, nil, errors.New("invalid derived key")
+	}
+	child := il.Add(il, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, errors.New("invalid derived key")
+	}
+	return child.FillBytes(make([]byte, 32)), sum[32:], nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/state"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestDeriveDevAccounts(t *testing.T) {
+	mnemonic := "ignore empty bird silly journey junior ripple have guard waste between tenant"
+	accs, err := genesis.DeriveDevAccounts(mnemonic, 3)
+	assert.Nil(t, err)
+	assert.Len(t, accs, 3)
+	// addresses derived by VeChain wallets
+	assert.Equal(t, "0x339fb3c438606519e2c75bbf531fb43a0f449a70", accs[0].Address.String())
+	assert.Equal(t, "0x5677099d06bc72f9da1113afa5e022feec424c8e", accs[1].Address.String())
+	assert.Equal(t, "0x86231b5cdcbfe751b9ddcd4bd981fc0a48afe921", accs[2].Address.String())
+
+	_, err = genesis.DeriveDevAccounts("ignore empty bird", 1)
+	assert.EqualError(t, err, "invalid mnemonic: 3 words")
+	_, err = genesis.DeriveDevAccounts("ignore empty bird silly journey junior ripple have guard waste between tenantt", 1)
+	assert.EqualError(t, err, `invalid mnemonic: unknown word "tenantt"`)
+	_, err = genesis.DeriveDevAccounts(strings.Repeat("abandon ", 12), 1)
+	assert.EqualError(t, err, "invalid mnemonic: checksum mismatch")
+	// test vectors of BIP-39
+	_, err = genesis.DeriveDevAccounts(strings.Repeat("abandon ", 11)+"about", 1)
+	assert.Nil(t, err)
+	_, err = genesis.DeriveDevAccounts(strings.Repeat("zoo ", 23)+"vote", 1)
+	assert.Nil(t, err)
+	_, err = genesis.DeriveDevAccounts(mnemonic, 0)
+	assert.EqualError(t, err, "invalid count of accounts")
+}
+
+func TestNewDevnetWithOptions(t *testing.T) {
+	accs, err := genesis.DeriveDevAccounts("ignore empty bird silly journey junior ripple have guard waste between tenant", 2)
+	assert.Nil(t, err)
+
+	opts := genesis.DevnetOptions{
+		Accounts:    accs,
+		Balance:     big.NewInt(1000),
+		Energy:      big.NewInt(2000),
+		BlockSigner: accs[1],
+	}
+	gene := genesis.NewDevnetWithOptions(thor.SoloFork, opts)
+	assert.NotEqual(t, genesis.NewDevnet().ID(), gene.ID())
+
+	db := muxdb.NewMem()
+	b0, _, _, err := gene.Build(state.NewStater(db))
+	assert.Nil(t, err)
+	st := state.New(db, trie.Root{Hash: b0.Header().StateRoot()})
+	for _, a := range accs {
+		bal, err := st.GetBalance(a.Address)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(1000), bal)
+		energy, err := st.GetEnergy(a.Address, b0.Header().Timestamp())
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(2000), energy)
+	}
+	listed, _, _, _, err := builtin.Authority.Native(st).Get(accs[1].Address)
+	assert.Nil(t, err)
+	assert.True(t, listed)
+
+	// the default options keep the devnet unchanged
+	assert.Equal(t, genesis.NewDevnet().ID(), genesis.NewDevnetWithOptions(thor.SoloFork, genesis.DefaultDevnetOptions()).ID())
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// forkSequence lists the forks in the order they are activated on the main and test networks,
+// a fork should not be activated before any of the former ones.
+var forkSequence = []struct {
+	name string
+	get  func(fc *thor.ForkConfig) uint32
+}{
+	{"VIP191", func(fc *thor.ForkConfig) uint32 { return fc.VIP191 }},
+	{"ETH_CONST", func(fc *thor.ForkConfig) uint32 { return fc.ETH_CONST }},
+	{"BLOCKLIST", func(fc *thor.ForkConfig) uint32 { return fc.BLOCKLIST }},
+	{"ETH_IST", func(fc *thor.ForkConfig) uint32 { return fc.ETH_IST }},
+	{"VIP214", func(fc *thor.ForkConfig) uint32 { return fc.VIP214 }},
+	{"FINALITY", func(fc *thor.ForkConfig) uint32 { return fc.FINALITY }},
+}
+
+// ValidateCustomNet builds the custom genesis in memory, and checks it for problems which do not
+// fail the building, but make the network unable to work as expected, e.g. no authority node is
+// endorsed. The genesis is nil if it fails to build, and the error is reported as a problem.
+func ValidateCustomNet(gen *CustomGenesis) (*Genesis, []error) {
+	var problems []error
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	forkConfig := thor.NoFork
+	if gen.ForkConfig != nil {
+		forkConfig = *gen.ForkConfig
+	}
+	for i := 1; i < len(forkSequence); i++ {
+		prev, next := forkSequence[i-1], forkSequence[i]
+		if prev.get(&forkConfig) > next.get(&forkConfig) {
+			report("forkConfig: %s(#%d) is activated after %s(#%d)", prev.name, prev.get(&forkConfig), next.name, next.get(&forkConfig))
+		}
+	}
+
+	if _, err := NewParamsSchedule(gen.ParamsSchedule); err != nil {
+		report("paramsSchedule: %v", err)
+	}
+
+	if gen.Timing != nil {
+		timing := thor.ForkConfig{Timing: *gen.Timing}
+		mbp := thor.InitialMaxBlockProposers
+		if m := gen.Params.MaxBlockProposers; m != nil && *m > 0 && *m < mbp {
+			mbp = *m
+		}
+		// a checkpoint is justified by votes of more than 2/3 of the block proposers in a round
+		if uint64(timing.CheckpointInterval()) <= mbp*2/3 {
+			report("timing: checkpointInterval %d is too short to collect votes of 2/3 of %d block proposers", timing.CheckpointInterval(), mbp)
+		}
+		// seed blocks are at epoch boundaries, which should be checkpoints to be finalized as a whole
+		if timing.EpochLength()%timing.CheckpointInterval() != 0 {
+			report("timing: epochLength %d is not a multiple of checkpointInterval %d", timing.EpochLength(), timing.CheckpointInterval())
+		}
+	}
+
+	balances := make(map[thor.Address]*big.Int)
+	for i, a := range gen.Accounts {
+		if _, ok := balances[a.Address]; ok {
+			report("accounts[%d]: duplicated address %v", i, a.Address)
+		}
+		balance := new(big.Int)
+		if a.Balance != nil {
+			balance = (*big.Int)(a.Balance)
+		}
+		balances[a.Address] = balance
+
+		if len(a.Code) > 0 {
+			if _, err := hexutil.Decode(a.Code); err != nil {
+				report("accounts[%d]: invalid code: %v", i, err)
+			}
+		}
+		for k := range a.Storage {
+			if _, err := thor.ParseBytes32(k); err != nil {
+				report("accounts[%d]: invalid storage key %q: %v", i, k, err)
+			}
+		}
+		for k, v := range a.RawStorage {
+			if _, _, err := parseRawStorage(k, v); err != nil {
+				report("accounts[%d]: %v", i, err)
+			} else if _, ok := a.Storage[k]; ok {
+				report("accounts[%d]: storage key %v is set in both storage and rawStorage", i, k)
+			}
+		}
+	}
+
+	endorsement := thor.InitialProposerEndorsement
+	if gen.Params.ProposerEndorsement != nil {
+		endorsement = (*big.Int)(gen.Params.ProposerEndorsement)
+	}
+	masters := make(map[thor.Address]bool)
+	endorsed := 0
+	for i, node := range gen.Authority {
+		if masters[node.MasterAddress] {
+			report("authority[%d]: duplicated master %v", i, node.MasterAddress)
+		}
+		masters[node.MasterAddress] = true
+		if node.Identity.IsZero() {
+			report("authority[%d]: empty identity", i)
+		}
+
+		balance := balances[node.EndorsorAddress]
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		if balance.Cmp(endorsement) < 0 {
+			report("authority[%d]: balance of endorsor %v is %v, less than proposerEndorsement %v", i, node.EndorsorAddress, balance, endorsement)
+		} else {
+			endorsed++
+		}
+	}
+	if len(gen.Authority) > 0 && endorsed == 0 {
+		report("authority: no node is endorsed, no block can be produced")
+	}
+
+	approvers := make(map[thor.Address]bool)
+	for i, approver := range gen.Executor.Approvers {
+		if approvers[approver.Address] {
+			report("executor.approvers[%d]: duplicated address %v", i, approver.Address)
+		}
+		approvers[approver.Address] = true
+		if approver.Identity.IsZero() {
+			report("executor.approvers[%d]: empty identity", i)
+		}
+	}
+	if len(approvers) > 0 && gen.Params.ExecutorAddress != nil && *gen.Params.ExecutorAddress != builtin.Executor.Address {
+		report("executor: approvers take no effect, since params.executorAddress is not the builtin executor")
+	}
+
+	gene, err := buildCustomNet(gen, forkConfig)
+	if err != nil {
+		report("build: %v", err)
+	}
+	return gene, problems
+}
+
+// buildCustomNet builds the custom genesis on a copy, recovering from panics of failed genesis calls.
+func buildCustomNet(gen *CustomGenesis, forkConfig thor.ForkConfig) (gene *Genesis, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			gene, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+
+	cpy := *gen
+	cpy.ForkConfig = &forkConfig
+	return NewCustomNet(&cpy)
+}
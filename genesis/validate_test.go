@@ -0,0 +1,80 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package genesis_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/builtin"
+	"github.com/vechain/thor/v2/genesis"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestValidateCustomNet(t *testing.T) {
+	endorsement := genesis.HexOrDecimal256(*big.NewInt(100))
+	gen := CustomNetWithParams(t, genesis.Executor{}, genesis.HexOrDecimal256{}, genesis.HexOrDecimal256{}, endorsement)
+	forkConfig := thor.SoloFork
+	gen.ForkConfig = &forkConfig
+	endorsor := thor.BytesToAddress([]byte("endorsor"))
+	for i := range gen.Authority {
+		gen.Authority[i].EndorsorAddress = endorsor
+	}
+	gen.Accounts[1].Address = endorsor
+	gen.Accounts[1].Balance = &endorsement
+
+	gene, problems := genesis.ValidateCustomNet(&gen)
+	assert.Empty(t, problems)
+	expected, err := genesis.NewCustomNet(&gen)
+	assert.Nil(t, err)
+	assert.Equal(t, expected.ID(), gene.ID())
+
+	// the genesis builds, but is problematic
+	forkConfig.VIP214 = 10
+	gen.Accounts[1].Balance = &genesis.HexOrDecimal256{}
+	gen.Accounts[0].Storage["0x01"] = thor.Bytes32{}
+	gen.Params.ExecutorAddress = &gen.Authority[0].MasterAddress
+	gen.Executor.Approvers = []genesis.Approver{{Address: gen.Authority[0].MasterAddress}}
+
+	gene, problems = genesis.ValidateCustomNet(&gen)
+	assert.Nil(t, gene)
+	var msgs []string
+	for _, p := range problems {
+		msgs = append(msgs, p.Error())
+	}
+	assert.Contains(t, msgs, "forkConfig: VIP214(#10) is activated after FINALITY(#0)")
+	assert.Equal(t, "forkConfig: VIP214(#10) is activated after FINALITY(#0)", msgs[0], "other forks are in order")
+	assert.NotContains(t, msgs[1], "forkConfig")
+	assert.Contains(t, msgs, `accounts[0]: invalid storage key "0x01": invalid length`)
+	assert.Contains(t, msgs, "authority: no node is endorsed, no block can be produced")
+	assert.Contains(t, msgs, "executor.approvers[0]: empty identity")
+	assert.Contains(t, msgs, "executor: approvers take no effect, since params.executorAddress is not the builtin executor")
+	assert.Contains(t, msgs, "build: invalid length")
+
+	// timing
+	gen.Timing = &thor.Timing{CheckpointInterval: 100, EpochLength: 150}
+	_, problems = genesis.ValidateCustomNet(&gen)
+	msgs = nil
+	for _, p := range problems {
+		msgs = append(msgs, p.Error())
+	}
+	assert.Contains(t, msgs, "timing: epochLength 150 is not a multiple of checkpointInterval 100")
+	gen.Timing = nil
+
+	// every fork of the sequence is checked
+	forkConfig.VIP214 = 0
+	forkConfig.VIP191 = 5
+	_, problems = genesis.ValidateCustomNet(&gen)
+	assert.Contains(t, problems[0].Error(), "forkConfig: VIP191(#5) is activated after ETH_CONST(#0)")
+
+	// approvers of the builtin executor
+	delete(gen.Accounts[0].Storage, "0x01")
+	gen.Params.ExecutorAddress = &builtin.Executor.Address
+	gene, problems = genesis.ValidateCustomNet(&gen)
+	assert.Nil(t, gene)
+	assert.Contains(t, problems[len(problems)-1].Error(), "build: vm:")
+}
@@ -482,6 +482,26 @@ func (w *Writer) Truncate(blockNum uint32) error {
 	return nil
 }
 
+// DeleteRange deletes logs of blocks numbered within [from, to].
+func (w *Writer) DeleteRange(from, to uint32) error {
+	start, err := newSequence(from, 0, 0)
+	if err != nil {
+		return err
+	}
+	end, err := newSequence(min(to, MaxBlockNumber), txIndexMask, logIndexMask)
+	if err != nil {
+		return err
+	}
+
+	if err := w.exec("DELETE FROM event WHERE seq >= ? AND seq <= ?", start, end); err != nil {
+		return err
+	}
+	if err := w.exec("DELETE FROM transfer WHERE seq >= ? AND seq <= ?", start, end); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Write writes all logs of the given block.
 func (w *Writer) Write(b *block.Block, receipts tx.Receipts) error {
 	var (
@@ -472,3 +472,47 @@ func TestRemoveLeadingZeros(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_DeleteRange(t *testing.T) {
+	db, err := NewMem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// blocks #2 to #6
+	w := db.NewWriter()
+	b := new(block.Builder).Build()
+	for range 5 {
+		b = new(block.Builder).
+			ParentID(b.Header().ID()).
+			Transaction(newTx()).
+			Build()
+		if err := w.Write(b, tx.Receipts{newReceipt()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.DeleteRange(3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := db.FilterEvents(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nums []uint32
+	for _, ev := range events {
+		nums = append(nums, ev.BlockNumber)
+	}
+	assert.Equal(t, []uint32{2, 5, 6}, nums)
+
+	transfers, err := db.FilterTransfers(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(transfers))
+	assert.Equal(t, uint32(5), transfers[1].BlockNumber)
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package engine
+
+import (
+	"context"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/vechain/thor/v2/kv"
+)
+
+// writes are not synced, the same as leveldb engine.
+var pebbleWriteOpt = pebble.NoSync
+
+type PebbleEngine struct {
+	db *pebble.DB
+}
+
+// NewPebbleEngine creates pebble instance which implements the Engine interface.
+func NewPebbleEngine(db *pebble.DB) Engine {
+	return &PebbleEngine{db}
+}
+
+func (pdb *PebbleEngine) Close() error {
+	return pdb.db.Close()
+}
+
+func (pdb *PebbleEngine) IsNotFound(err error) bool {
+	return err == pebble.ErrNotFound
+}
+
+// pebbleGet copies the value out, since it's only valid until the closer is closed.
+func pebbleGet(r pebble.Reader, key []byte) ([]byte, error) {
+	val, closer, err := r.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return append([]byte{}, val...), nil
+}
+
+func pebbleHas(r pebble.Reader, key []byte) (bool, error) {
+	_, closer, err := r.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, closer.Close()
+}
+
+func (pdb *PebbleEngine) Get(key []byte) ([]byte, error) {
+	return pebbleGet(pdb.db, key)
+}
+
+func (pdb *PebbleEngine) Has(key []byte) (bool, error) {
+	return pebbleHas(pdb.db, key)
+}
+
+func (pdb *PebbleEngine) Put(key, val []byte) error {
+	return pdb.db.Set(key, val, pebbleWriteOpt)
+}
+
+func (pdb *PebbleEngine) Delete(key []byte) error {
+	return pdb.db.Delete(key, pebbleWriteOpt)
+}
+
+func (pdb *PebbleEngine) Snapshot() kv.Snapshot {
+	s := pdb.db.NewSnapshot()
+	return &struct {
+		kv.GetFunc
+		kv.HasFunc
+		kv.IsNotFoundFunc
+		kv.ReleaseFunc
+	}{
+		func(key []byte) ([]byte, error) {
+			return pebbleGet(s, key)
+		},
+		func(key []byte) (bool, error) {
+			return pebbleHas(s, key)
+		},
+		pdb.IsNotFound,
+		func() {
+			s.Close()
+		},
+	}
+}
+
+func (pdb *PebbleEngine) Bulk() kv.Bulk {
+	const idealBatchSize = 128 * 1024
+	var batch *pebble.Batch
+
+	getBatch := func() *pebble.Batch {
+		if batch == nil {
+			batch = pdb.db.NewBatch()
+		}
+		return batch
+	}
+	flush := func(minSize int) error {
+		if batch != nil && batch.Len() >= minSize {
+			if batch.Count() > 0 {
+				if err := batch.Commit(pebbleWriteOpt); err != nil {
+					return err
+				}
+			}
+			if err := batch.Close(); err != nil {
+				return err
+			}
+			batch = nil
+		}
+		return nil
+	}
+	var autoFlush bool
+
+	return &struct {
+		kv.PutFunc
+		kv.DeleteFunc
+		kv.EnableAutoFlushFunc
+		kv.WriteFunc
+	}{
+		func(key, val []byte) error {
+			if err := getBatch().Set(key, val, nil); err != nil {
+				return err
+			}
+			if autoFlush {
+				return flush(idealBatchSize)
+			}
+			return nil
+		},
+		func(key []byte) error {
+			if err := getBatch().Delete(key, nil); err != nil {
+				return err
+			}
+			if autoFlush {
+				return flush(idealBatchSize)
+			}
+			return nil
+		},
+		func() { autoFlush = true },
+		func() error { return flush(0) },
+	}
+}
+
+func (pdb *PebbleEngine) Iterate(r kv.Range) kv.Iterator {
+	iter, err := pdb.db.NewIter(&pebble.IterOptions{
+		LowerBound: r.Start,
+		UpperBound: r.Limit,
+	})
+	return &pebbleIterator{iter: iter, err: err}
+}
+
+// DeleteRange deletes keys within the range by a range tombstone, which costs constant time.
+func (pdb *PebbleEngine) DeleteRange(ctx context.Context, r kv.Range) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	start, limit := r.Start, r.Limit
+	if start == nil {
+		start = []byte{}
+	}
+	if limit == nil {
+		// unbounded, find the upper bound by the last key
+		iter := pdb.Iterate(kv.Range{Start: start})
+		defer iter.Release()
+		if !iter.Last() {
+			return iter.Error()
+		}
+		if err := pdb.db.Delete(iter.Key(), pebbleWriteOpt); err != nil {
+			return err
+		}
+		limit = append([]byte{}, iter.Key()...)
+	}
+	return pdb.db.DeleteRange(start, limit, pebbleWriteOpt)
+}
+
+// Metrics returns the metrics of the underlying pebble db.
+func (pdb *PebbleEngine) Metrics() *pebble.Metrics {
+	return pdb.db.Metrics()
+}
+
+// pebbleIterator adapts pebble iterator to kv.Iterator.
+// Like leveldb iterator, an unpositioned iterator moves to the first(last) entry on Next(Prev).
+type pebbleIterator struct {
+	iter       *pebble.Iterator
+	err        error
+	positioned bool
+}
+
+func (i *pebbleIterator) First() bool {
+	if i.iter == nil {
+		return false
+	}
+	i.positioned = true
+	return i.iter.First()
+}
+
+func (i *pebbleIterator) Last() bool {
+	if i.iter == nil {
+		return false
+	}
+	i.positioned = true
+	return i.iter.Last()
+}
+
+func (i *pebbleIterator) Next() bool {
+	if i.iter == nil {
+		return false
+	}
+	if !i.positioned {
+		return i.First()
+	}
+	return i.iter.Next()
+}
+
+func (i *pebbleIterator) Prev() bool {
+	if i.iter == nil {
+		return false
+	}
+	if !i.positioned {
+		return i.Last()
+	}
+	return i.iter.Prev()
+}
+
+func (i *pebbleIterator) Key() []byte {
+	if i.iter == nil || !i.iter.Valid() {
+		return nil
+	}
+	return i.iter.Key()
+}
+
+func (i *pebbleIterator) Value() []byte {
+	if i.iter == nil || !i.iter.Valid() {
+		return nil
+	}
+	return i.iter.Value()
+}
+
+func (i *pebbleIterator) Release() {
+	if i.iter != nil {
+		if err := i.iter.Close(); err != nil && i.err == nil {
+			i.err = err
+		}
+		i.iter = nil
+	}
+}
+
+func (i *pebbleIterator) Error() error {
+	if i.err != nil {
+		return i.err
+	}
+	if i.iter != nil {
+		return i.iter.Error()
+	}
+	return nil
+}
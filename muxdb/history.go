@@ -0,0 +1,324 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+const (
+	historyStoreName = "muxdb.history"
+	historySinceKey  = "historySince"
+
+	historyAccountSpace = byte(0) // account changes keyed by address and version
+	historyStorageSpace = byte(1) // storage changes keyed by address, storage key and version
+	historyBlockSpace   = byte(2) // keys of changes of each version
+)
+
+// History is the flat index of account and storage changes, keyed by address and the version
+// of the block, i.e. (number, conflicts), in which the change is made. To read an account at a block,
+// the latest change at or before the block in the same chain is looked up, instead of walking
+// the historical state trie.
+//
+// Changes of blocks numbered from Since are indexed.
+type History struct {
+	store kv.Store
+	since uint32
+}
+
+// History returns the history index, or nil if it's not enabled.
+func (db *MuxDB) History() *History {
+	return db.history.Load()
+}
+
+// EnableHistory enables the history index, which indexes changes of blocks numbered from since.
+// If already enabled, the index is returned as is.
+func (db *MuxDB) EnableHistory(since uint32) (*History, error) {
+	if h := db.History(); h != nil {
+		return h, nil
+	}
+	if err := db.NewStore(propStoreName).Put([]byte(historySinceKey), binary.BigEndian.AppendUint32(nil, since)); err != nil {
+		return nil, err
+	}
+	h := &History{store: db.NewStore(historyStoreName), since: since}
+	db.history.Store(h)
+	return h, nil
+}
+
+// DisableHistory disables and deletes the history index.
+// Once enabled again, changes of blocks before are no longer indexed.
+func (db *MuxDB) DisableHistory(ctx context.Context) error {
+	if err := db.NewStore(propStoreName).Delete([]byte(historySinceKey)); err != nil {
+		return err
+	}
+	db.history.Store(nil)
+	return db.NewStore(historyStoreName).DeleteRange(ctx, kv.Range{})
+}
+
+// loadHistory loads the history index if enabled.
+func (db *MuxDB) loadHistory() error {
+	data, err := db.NewStore(propStoreName).Get([]byte(historySinceKey))
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(data) != 4 {
+		return errors.New("invalid history since")
+	}
+	db.history.Store(&History{
+		store: db.NewStore(historyStoreName),
+		since: binary.BigEndian.Uint32(data),
+	})
+	return nil
+}
+
+// Since returns the number of the first indexed block.
+func (h *History) Since() uint32 {
+	return h.since
+}
+
+// GetAccount returns the account data and metadata, as saved in the accounts trie, after the latest change
+// at or before the given version. inChain reports whether the version is in the chain being read.
+// It returns false if no change is indexed, and the account should be read from the trie.
+func (h *History) GetAccount(addr thor.Address, ver trie.Version, inChain func(trie.Version) (bool, error)) (data, meta []byte, found bool, err error) {
+	val, found, err := h.find(appendHistoryAccountKey(nil, addr), ver, inChain)
+	if err != nil || !found {
+		return nil, nil, false, err
+	}
+	var v struct{ Data, Meta []byte }
+	if err := rlp.DecodeBytes(val, &v); err != nil {
+		return nil, nil, false, err
+	}
+	return v.Data, v.Meta, true, nil
+}
+
+// GetStorage returns the storage value, its metadata and the id of the storage trie it's saved in, after the latest
+// change at or before the given version. The value is outdated if the storage trie of the account is no longer the same one.
+// It returns false if no change is indexed, and the value should be read from the trie.
+func (h *History) GetStorage(addr thor.Address, key thor.Bytes32, ver trie.Version, inChain func(trie.Version) (bool, error)) (storageID, value, meta []byte, found bool, err error) {
+	val, found, err := h.find(appendHistoryStorageKey(nil, addr, key), ver, inChain)
+	if err != nil || !found {
+		return nil, nil, nil, false, err
+	}
+	var v struct{ StorageID, Value, Meta []byte }
+	if err := rlp.DecodeBytes(val, &v); err != nil {
+		return nil, nil, nil, false, err
+	}
+	return v.StorageID, v.Value, v.Meta, true, nil
+}
+
+// find finds the value of the latest change with the given key prefix, at or before the given version in the chain.
+func (h *History) find(prefix []byte, ver trie.Version, inChain func(trie.Version) (bool, error)) ([]byte, bool, error) {
+	if ver.Major < h.since {
+		return nil, false, nil
+	}
+	prefix = slices.Clip(prefix)
+	iter := h.store.Iterate(kv.Range{
+		Start: binary.BigEndian.AppendUint32(prefix, h.since),
+		// other versions of the same number are not in the chain, it's fine to include some of them
+		Limit: append(appendHistoryVersion(prefix, ver), 0),
+	})
+	defer iter.Release()
+
+	for ok := iter.Last(); ok; ok = iter.Prev() {
+		v, err := parseHistoryVersion(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, false, err
+		}
+		if in, err := inChain(v); err != nil {
+			return nil, false, err
+		} else if in {
+			return iter.Value(), true, nil
+		}
+	}
+	return nil, false, iter.Error()
+}
+
+// IterateAccount calls fn with changes of the account, made in blocks numbered within [from, to], in ascending order.
+// Changes of blocks not in the chain are included, and should be filtered by the caller.
+// The iteration stops if fn returns false.
+func (h *History) IterateAccount(addr thor.Address, from, to uint32, fn func(ver trie.Version, data, meta []byte) (bool, error)) error {
+	if from = max(from, h.since); from > to {
+		return nil
+	}
+	prefix := slices.Clip(appendHistoryAccountKey(nil, addr))
+	rng := kv.Range{
+		Start: binary.BigEndian.AppendUint32(prefix, from),
+	}
+	if to < math.MaxUint32 {
+		rng.Limit = binary.BigEndian.AppendUint32(prefix, to+1)
+	} else {
+		rng.Limit = util.BytesPrefix(prefix).Limit
+	}
+	iter := h.store.Iterate(rng)
+	defer iter.Release()
+
+	for iter.Next() {
+		v, err := parseHistoryVersion(iter.Key()[len(prefix):])
+		if err != nil {
+			return err
+		}
+		var val struct{ Data, Meta []byte }
+		if err := rlp.DecodeBytes(iter.Value(), &val); err != nil {
+			return err
+		}
+		if ok, err := fn(v, val.Data, val.Meta); err != nil || !ok {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// Truncate deletes changes of blocks numbered from limit.
+func (h *History) Truncate(ctx context.Context, limit uint32) error {
+	bulk := h.store.Bulk()
+	bulk.EnableAutoFlush()
+
+	iter := h.store.Iterate(kv.Range{
+		Start: binary.BigEndian.AppendUint32([]byte{historyBlockSpace}, limit),
+		Limit: []byte{historyBlockSpace + 1},
+	})
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := deleteHistoryBlock(bulk, iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return bulk.Write()
+}
+
+// deleteHistoryBlock deletes changes of a version, listed by the block entry.
+func deleteHistoryBlock(putter kv.Putter, blockKey, blockEntry []byte) error {
+	var prefixes [][]byte
+	if err := rlp.DecodeBytes(blockEntry, &prefixes); err != nil {
+		return err
+	}
+	suffix := blockKey[1:]
+	for _, prefix := range prefixes {
+		if err := putter.Delete(append(prefix, suffix...)); err != nil {
+			return err
+		}
+	}
+	return putter.Delete(blockKey)
+}
+
+// HistoryBatch collects changes of a block to write into the history index.
+type HistoryBatch struct {
+	h       *History
+	ver     trie.Version
+	entries []historyEntry
+}
+
+type historyEntry struct {
+	prefix []byte
+	value  []byte
+}
+
+// NewBatch creates a batch for changes of the block with the given version.
+func (h *History) NewBatch(ver trie.Version) *HistoryBatch {
+	return &HistoryBatch{h: h, ver: ver}
+}
+
+// PutAccount puts the account data and metadata as saved in the accounts trie, or empty data if deleted.
+func (b *HistoryBatch) PutAccount(addr thor.Address, data, meta []byte) error {
+	val, err := rlp.EncodeToBytes(&struct{ Data, Meta []byte }{data, meta})
+	if err != nil {
+		return err
+	}
+	b.entries = append(b.entries, historyEntry{appendHistoryAccountKey(nil, addr), val})
+	return nil
+}
+
+// PutStorage puts the storage value, its metadata if any needs to be kept, and the id of the storage trie it's saved in.
+func (b *HistoryBatch) PutStorage(addr thor.Address, key thor.Bytes32, storageID, value, meta []byte) error {
+	val, err := rlp.EncodeToBytes(&struct{ StorageID, Value, Meta []byte }{storageID, value, meta})
+	if err != nil {
+		return err
+	}
+	b.entries = append(b.entries, historyEntry{appendHistoryStorageKey(nil, addr, key), val})
+	return nil
+}
+
+// Write writes changes into the index. Changes previously written with the same version,
+// e.g. by a block committed but not finally saved, are replaced.
+// Blocks before the first indexed block are ignored.
+func (b *HistoryBatch) Write() error {
+	if b.ver.Major < b.h.since {
+		return nil
+	}
+	var (
+		store    = b.h.store
+		bulk     = store.Bulk()
+		blockKey = appendHistoryVersion([]byte{historyBlockSpace}, b.ver)
+		suffix   = blockKey[1:]
+	)
+	if prev, err := store.Get(blockKey); err == nil {
+		if err := deleteHistoryBlock(bulk, blockKey, prev); err != nil {
+			return err
+		}
+	} else if !store.IsNotFound(err) {
+		return err
+	}
+
+	prefixes := make([][]byte, 0, len(b.entries))
+	for _, e := range b.entries {
+		if err := bulk.Put(append(e.prefix, suffix...), e.value); err != nil {
+			return err
+		}
+		prefixes = append(prefixes, e.prefix)
+	}
+	entry, err := rlp.EncodeToBytes(prefixes)
+	if err != nil {
+		return err
+	}
+	if err := bulk.Put(blockKey, entry); err != nil {
+		return err
+	}
+	return bulk.Write()
+}
+
+func appendHistoryAccountKey(buf []byte, addr thor.Address) []byte {
+	return append(append(buf, historyAccountSpace), addr[:]...)
+}
+
+func appendHistoryStorageKey(buf []byte, addr thor.Address, key thor.Bytes32) []byte {
+	return append(append(append(buf, historyStorageSpace), addr[:]...), key[:]...)
+}
+
+func appendHistoryVersion(buf []byte, ver trie.Version) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, ver.Major)
+	return binary.AppendUvarint(buf, uint64(ver.Minor))
+}
+
+func parseHistoryVersion(b []byte) (trie.Version, error) {
+	if len(b) < 5 {
+		return trie.Version{}, errors.New("invalid history key")
+	}
+	minor, n := binary.Uvarint(b[4:])
+	if n <= 0 || 4+n != len(b) {
+		return trie.Version{}, errors.New("invalid history key")
+	}
+	return trie.Version{Major: binary.BigEndian.Uint32(b), Minor: uint32(minor)}, nil
+}
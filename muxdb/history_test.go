@@ -0,0 +1,121 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/kv"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestHistory(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	assert.Nil(t, db.History())
+	h, err := db.EnableHistory(10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10), h.Since())
+	h2, err := db.EnableHistory(20)
+	assert.Nil(t, err)
+	assert.Equal(t, h, h2)
+
+	var (
+		addr1 = thor.BytesToAddress([]byte("addr1"))
+		addr2 = thor.BytesToAddress([]byte("addr2"))
+		key   = thor.BytesToBytes32([]byte("key"))
+	)
+	write := func(ver trie.Version, val string) {
+		b := h.NewBatch(ver)
+		assert.Nil(t, b.PutAccount(addr1, []byte(val), []byte("meta")))
+		assert.Nil(t, b.PutStorage(addr1, key, []byte("sid"), []byte(val), []byte("smeta")))
+		assert.Nil(t, b.Write())
+	}
+	write(trie.Version{Major: 5}, "v5")
+	write(trie.Version{Major: 10}, "v10")
+	write(trie.Version{Major: 12}, "v12")
+	write(trie.Version{Major: 12, Minor: 1}, "v12x")
+	write(trie.Version{Major: 15}, "v15")
+
+	// the main chain contains versions with minor 0, the fork contains 12.1
+	mainChain := func(ver trie.Version) (bool, error) { return ver.Minor == 0, nil }
+	forkChain := func(ver trie.Version) (bool, error) {
+		return ver.Major < 12 || ver == trie.Version{Major: 12, Minor: 1}, nil
+	}
+
+	getAccount := func(ver trie.Version, inChain func(trie.Version) (bool, error)) string {
+		data, meta, found, err := h.GetAccount(addr1, ver, inChain)
+		assert.Nil(t, err)
+		if !found {
+			return ""
+		}
+		assert.Equal(t, []byte("meta"), meta)
+		return string(data)
+	}
+	assert.Equal(t, "", getAccount(trie.Version{Major: 9}, mainChain))
+	assert.Equal(t, "v10", getAccount(trie.Version{Major: 10}, mainChain))
+	assert.Equal(t, "v10", getAccount(trie.Version{Major: 11}, mainChain))
+	assert.Equal(t, "v12", getAccount(trie.Version{Major: 14}, mainChain))
+	assert.Equal(t, "v15", getAccount(trie.Version{Major: 100}, mainChain))
+	assert.Equal(t, "v12x", getAccount(trie.Version{Major: 12, Minor: 1}, forkChain))
+	assert.Equal(t, "v12x", getAccount(trie.Version{Major: 20}, forkChain))
+
+	sid, val, meta, found, err := h.GetStorage(addr1, key, trie.Version{Major: 13}, mainChain)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("sid"), sid)
+	assert.Equal(t, []byte("v12"), val)
+	assert.Equal(t, []byte("smeta"), meta)
+
+	_, _, found, err = h.GetAccount(addr2, trie.Version{Major: 20}, mainChain)
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	// changes of the same version are replaced
+	b := h.NewBatch(trie.Version{Major: 15})
+	assert.Nil(t, b.PutAccount(addr2, []byte("v15"), nil))
+	assert.Nil(t, b.Write())
+	assert.Equal(t, "v12", getAccount(trie.Version{Major: 15}, mainChain))
+
+	var iterated []trie.Version
+	assert.Nil(t, h.IterateAccount(addr1, 0, 12, func(ver trie.Version, data, meta []byte) (bool, error) {
+		iterated = append(iterated, ver)
+		return true, nil
+	}))
+	assert.Equal(t, []trie.Version{{Major: 10}, {Major: 12}, {Major: 12, Minor: 1}}, iterated)
+
+	assert.Nil(t, h.Truncate(context.Background(), 12))
+	assert.Equal(t, "v10", getAccount(trie.Version{Major: 20}, mainChain))
+	_, _, found, err = h.GetAccount(addr2, trie.Version{Major: 20}, mainChain)
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	assert.Nil(t, db.DisableHistory(context.Background()))
+	assert.Nil(t, db.History())
+	iter := db.NewStore(historyStoreName).Iterate(kv.Range{})
+	assert.False(t, iter.Next())
+	iter.Release()
+}
+
+func TestHistoryPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.db")
+	db, err := Open(path, &Options{})
+	assert.Nil(t, err)
+	_, err = db.EnableHistory(100)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	db, err = Open(path, &Options{})
+	assert.Nil(t, err)
+	defer db.Close()
+	assert.NotNil(t, db.History())
+	assert.Equal(t, uint32(100), db.History().Since())
+}
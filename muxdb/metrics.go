@@ -10,6 +10,7 @@ package muxdb
 import (
 	"strconv"
 
+	"github.com/cockroachdb/pebble"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/vechain/thor/v2/metrics"
 )
@@ -29,3 +30,16 @@ func registerCompactionMetrics(stats *leveldb.DBStats) {
 		metricCompaction().SetWithLabel(stats.LevelWrite[i], map[string]string{"level": lvl, "type": "write"})
 	}
 }
+
+// registerPebbleCompactionMetrics sets the same gauges as registerCompactionMetrics.
+// Pebble does not track compaction time per level, so the total time is set with level "all".
+func registerPebbleCompactionMetrics(m *pebble.Metrics) {
+	for i, l := range m.Levels {
+		lvl := strconv.Itoa(i)
+		metricCompaction().SetWithLabel(l.NumFiles, map[string]string{"level": lvl, "type": "tables"})
+		metricCompaction().SetWithLabel(l.Size, map[string]string{"level": lvl, "type": "size"})
+		metricCompaction().SetWithLabel(int64(l.BytesRead), map[string]string{"level": lvl, "type": "read"})
+		metricCompaction().SetWithLabel(int64(l.BytesCompacted+l.BytesFlushed), map[string]string{"level": lvl, "type": "write"})
+	}
+	metricCompaction().SetWithLabel(int64(m.Compact.Duration.Seconds()), map[string]string{"level": "all", "type": "time"})
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vechain/thor/v2/kv"
+)
+
+const (
+	migrationKey = "migration"
+
+	migrateBatchSize   = 4 * 1024 * 1024 // bytes of a bulk write, progress is saved after each
+	migrateSampleEvery = 1000            // compare values of every n-th key when verifying
+)
+
+// MigrateProgress reports the progress of migration.
+type MigrateProgress struct {
+	Verifying bool   // false while copying, true while verifying
+	Count     uint64 // count of keys copied or verified
+	Key       []byte // the last key copied or verified
+}
+
+// migration is the progress saved in the target database.
+type migration struct {
+	LastKey []byte
+	Count   uint64
+}
+
+// Migrate copies all key spaces of the database at srcPath, whatever the engine, into a new database
+// at dstPath which uses the engine set in options. Then keys are verified in order, with sampled values compared.
+// The progress is saved in the target database, so an interrupted migration resumes by calling Migrate again.
+// It's an offline operation, and both databases must not be opened by others.
+func Migrate(ctx context.Context, srcPath, dstPath string, options *Options, onProgress func(*MigrateProgress)) (uint64, error) {
+	srcEngine := detectEngine(srcPath)
+	if srcEngine == "" {
+		return 0, fmt.Errorf("database %v not found", srcPath)
+	}
+	dstEngine := options.Engine
+	if dstEngine == "" {
+		dstEngine = EngineLevelDB
+	}
+	if srcEngine == dstEngine {
+		return 0, fmt.Errorf("database is already using engine %v", dstEngine)
+	}
+	dstExists := detectEngine(dstPath) != ""
+
+	srcOpts := *options
+	srcOpts.Engine = srcEngine
+	src, err := Open(srcPath, &srcOpts)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	// the target must be partitioned the same as the source
+	dstOpts := *options
+	dstOpts.Engine = dstEngine
+	dstOpts.TrieHistPartitionFactor = src.trieBackend.HistPtnFactor
+	dstOpts.TrieDedupedPartitionFactor = src.trieBackend.DedupedPtnFactor
+	dst, err := Open(dstPath, &dstOpts)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	props := dst.NewStore(propStoreName)
+	var m migration
+	if data, err := props.Get([]byte(migrationKey)); err == nil {
+		if err := json.Unmarshal(data, &m); err != nil {
+			return 0, err
+		}
+	} else if !props.IsNotFound(err) {
+		return 0, err
+	} else if dstExists {
+		return 0, fmt.Errorf("database %v exists and is not being migrated", dstPath)
+	}
+
+	if err := migrate(ctx, src.engine, dst.engine, props, &m, onProgress); err != nil {
+		return 0, err
+	}
+	count, err := verifyMigration(ctx, src.engine, dst.engine, onProgress)
+	if err != nil {
+		return 0, err
+	}
+	if err := props.Delete([]byte(migrationKey)); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// isPropKey returns whether the key belongs to the props store, which is maintained by each database itself.
+func isPropKey(key []byte) bool {
+	return bytes.HasPrefix(key, []byte(string(namedStoreSpace)+propStoreName))
+}
+
+func migrate(ctx context.Context, src, dst kv.Store, props kv.Store, m *migration, onProgress func(*MigrateProgress)) error {
+	var r kv.Range
+	if m.LastKey != nil {
+		r.Start = append(append([]byte{}, m.LastKey...), 0)
+	}
+	iter := src.Iterate(r)
+	defer iter.Release()
+
+	var (
+		bulk = dst.Bulk()
+		size = 0
+	)
+	commit := func() error {
+		if err := bulk.Write(); err != nil {
+			return err
+		}
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if err := props.Put([]byte(migrationKey), data); err != nil {
+			return err
+		}
+		if onProgress != nil {
+			onProgress(&MigrateProgress{Count: m.Count, Key: m.LastKey})
+		}
+		size = 0
+		return nil
+	}
+
+	for iter.Next() {
+		key := iter.Key()
+		if isPropKey(key) {
+			continue
+		}
+		if err := bulk.Put(key, iter.Value()); err != nil {
+			return err
+		}
+		m.LastKey = append(m.LastKey[:0], key...)
+		m.Count++
+		size += len(key) + len(iter.Value())
+
+		if size >= migrateBatchSize {
+			if err := commit(); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return commit()
+}
+
+// verifyMigration iterates both databases in order, to ensure that keys are identical,
+// and values of sampled keys are equal.
+func verifyMigration(ctx context.Context, src, dst kv.Store, onProgress func(*MigrateProgress)) (uint64, error) {
+	srcIter := src.Iterate(kv.Range{})
+	defer srcIter.Release()
+	dstIter := dst.Iterate(kv.Range{})
+	defer dstIter.Release()
+
+	next := func(iter kv.Iterator) bool {
+		for iter.Next() {
+			if !isPropKey(iter.Key()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var count uint64
+	for {
+		srcOK, dstOK := next(srcIter), next(dstIter)
+		if !srcOK || !dstOK {
+			if err := srcIter.Error(); err != nil {
+				return 0, err
+			}
+			if err := dstIter.Error(); err != nil {
+				return 0, err
+			}
+			if srcOK || dstOK {
+				return 0, fmt.Errorf("key count mismatch after %d keys", count)
+			}
+			break
+		}
+		if !bytes.Equal(srcIter.Key(), dstIter.Key()) {
+			return 0, fmt.Errorf("key mismatch: want %x, have %x", srcIter.Key(), dstIter.Key())
+		}
+		if count%migrateSampleEvery == 0 && !bytes.Equal(srcIter.Value(), dstIter.Value()) {
+			return 0, fmt.Errorf("value mismatch of key %x", srcIter.Key())
+		}
+		count++
+		if count%(migrateSampleEvery*1000) == 0 {
+			if onProgress != nil {
+				onProgress(&MigrateProgress{Verifying: true, Count: count, Key: srcIter.Key()})
+			}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			default:
+			}
+		}
+	}
+	if count == 0 {
+		return 0, errors.New("nothing migrated")
+	}
+	return count, nil
+}
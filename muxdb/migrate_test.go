@@ -0,0 +1,93 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package muxdb
+
+import (
+	"context"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestMigrate(t *testing.T) {
+	opts := Options{
+		TrieNodeCacheSizeMB:        128,
+		TrieHistPartitionFactor:    1000,
+		TrieDedupedPartitionFactor: 2000,
+		OpenFilesCacheCapacity:     16,
+		ReadCacheMB:                32,
+		WriteBufferMB:              16,
+	}
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.db")
+	dstPath := filepath.Join(dir, "dst.db")
+
+	db, err := Open(srcPath, &opts)
+	assert.Nil(t, err)
+
+	tr := db.NewTrie("test", trie.Root{})
+	assert.Nil(t, tr.Update([]byte("key"), []byte("value"), nil))
+	ver := trie.Version{Major: 1}
+	assert.Nil(t, tr.Commit(ver, false))
+	root := tr.Hash()
+
+	// more than a batch, so the first batch is committed before cancelled
+	store := db.NewStore("test")
+	bulk := store.Bulk()
+	for i := range 6000 {
+		var key [4]byte
+		binary.BigEndian.PutUint32(key[:], uint32(i))
+		assert.Nil(t, bulk.Put(key[:], make([]byte, 1024)))
+	}
+	assert.Nil(t, bulk.Write())
+	assert.Nil(t, db.Close())
+
+	pebbleOpts := opts
+	pebbleOpts.Engine = EnginePebble
+
+	_, err = Migrate(context.Background(), srcPath, dstPath, &opts, nil)
+	assert.EqualError(t, err, "database is already using engine leveldb")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var progress []MigrateProgress
+	_, err = Migrate(ctx, srcPath, dstPath, &pebbleOpts, func(p *MigrateProgress) {
+		progress = append(progress, *p)
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, progress, 1)
+
+	// resume
+	count, err := Migrate(context.Background(), srcPath, dstPath, &pebbleOpts, func(p *MigrateProgress) {
+		assert.Greater(t, p.Count, progress[0].Count)
+	})
+	assert.Nil(t, err)
+	assert.Greater(t, count, uint64(6000))
+
+	_, err = Migrate(context.Background(), srcPath, dstPath, &pebbleOpts, nil)
+	assert.EqualError(t, err, "database "+dstPath+" exists and is not being migrated")
+
+	// the migrated database is partitioned as the source
+	db, err = Open(dstPath, &Options{Engine: EnginePebble, TrieHistPartitionFactor: 1})
+	assert.Nil(t, err)
+	defer db.Close()
+	assert.Equal(t, uint32(1000), db.trieBackend.HistPtnFactor)
+	assert.Equal(t, uint32(2000), db.trieBackend.DedupedPtnFactor)
+
+	val, _, err := db.NewTrie("test", trie.Root{Hash: root, Ver: ver}).Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), val)
+
+	val, err = db.NewStore("test").Get([]byte{0, 0, 0x17, 0x6f})
+	assert.Nil(t, err)
+	assert.Equal(t, make([]byte, 1024), val)
+
+	_, err = db.NewStore(propStoreName).Get([]byte(migrationKey))
+	assert.True(t, db.IsNotFound(err))
+}
@@ -10,8 +10,14 @@ package muxdb
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
 	"github.com/syndtr/goleveldb/leveldb"
 	dberrors "github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/filter"
@@ -36,6 +42,13 @@ const (
 	configKey     = "config"
 )
 
+const (
+	// EngineLevelDB is the LevelDB engine, the default one.
+	EngineLevelDB = "leveldb"
+	// EnginePebble is the Pebble engine.
+	EnginePebble = "pebble"
+)
+
 var logger = log.WithContext("pkg", "muxdb")
 
 // Options optional parameters for MuxDB.
@@ -57,18 +70,87 @@ type Options struct {
 	ReadCacheMB int
 	// WriteBufferMB is the size of write buffer for underlying database.
 	WriteBufferMB int
+	// Engine is the underlying K-V engine, EngineLevelDB if empty.
+	Engine string
 }
 
 // MuxDB is the database to efficiently store state trie and block-chain data.
 type MuxDB struct {
 	engine      engine.Engine
 	trieBackend *backend
+	history     atomic.Pointer[History]
 
 	done chan struct{}
 }
 
 // Open opens or creates DB at the given path.
 func Open(path string, options *Options) (*MuxDB, error) {
+	engineName := options.Engine
+	if engineName == "" {
+		engineName = EngineLevelDB
+	}
+	// check before open, since opening by the wrong engine may write the database
+	if created := detectEngine(path); created != "" && created != engineName {
+		return nil, fmt.Errorf("database was created by engine %v, but opened by %v", created, engineName)
+	}
+
+	var (
+		engine engine.Engine
+		err    error
+	)
+	switch engineName {
+	case EngineLevelDB:
+		engine, err = openLevelEngine(path, options)
+	case EnginePebble:
+		engine, err = openPebbleEngine(path, options)
+	default:
+		return nil, fmt.Errorf("unsupported engine %q", engineName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	propStore := kv.Bucket(string(namedStoreSpace) + propStoreName).NewStore(engine)
+	// persists critical options to avoid corruption when tweaked.
+	cfg := config{
+		HistPtnFactor:    options.TrieHistPartitionFactor,
+		DedupedPtnFactor: options.TrieDedupedPartitionFactor,
+		Engine:           engineName,
+	}
+	if err := cfg.LoadOrSave(propStore); err != nil {
+		engine.Close()
+		return nil, err
+	}
+	// the engine is not recorded by databases created before pebble was supported
+	if cfg.Engine == "" {
+		cfg.Engine = EngineLevelDB
+	}
+	if cfg.Engine != engineName {
+		engine.Close()
+		return nil, fmt.Errorf("database was created by engine %v, but opened by %v", cfg.Engine, engineName)
+	}
+
+	db := &MuxDB{
+		engine: engine,
+		trieBackend: &backend{
+			Store: engine,
+			Cache: newCache(
+				options.TrieNodeCacheSizeMB,
+				uint32(options.TrieCachedNodeTTL)),
+			HistPtnFactor:    cfg.HistPtnFactor,
+			DedupedPtnFactor: cfg.DedupedPtnFactor,
+			CachedNodeTTL:    options.TrieCachedNodeTTL,
+		},
+		done: make(chan struct{}),
+	}
+	if err := db.loadHistory(); err != nil {
+		engine.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func openLevelEngine(path string, options *Options) (engine.Engine, error) {
 	// prepare leveldb options
 	ldbOpts := opt.Options{
 		OpenFilesCacheCapacity: options.OpenFilesCacheCapacity,
@@ -93,34 +175,72 @@ func Open(path string, options *Options) (*MuxDB, error) {
 	if err != nil {
 		return nil, err
 	}
+	return engine.NewLevelEngine(ldb), nil
+}
 
-	// as engine
-	engine := engine.NewLevelEngine(ldb)
+func openPebbleEngine(path string, options *Options) (engine.Engine, error) {
+	cache := pebble.NewCache(int64(options.ReadCacheMB) * opt.MiB)
+	defer cache.Unref()
 
-	propStore := kv.Bucket(string(namedStoreSpace) + propStoreName).NewStore(engine)
-	// persists critical options to avoid corruption when tweaked.
-	cfg := config{
-		HistPtnFactor:    options.TrieHistPartitionFactor,
-		DedupedPtnFactor: options.TrieDedupedPartitionFactor,
+	pdbOpts := &pebble.Options{
+		Cache:              cache,
+		MaxOpenFiles:       options.OpenFilesCacheCapacity,
+		MemTableSize:       uint64(options.WriteBufferMB) * opt.MiB,
+		FormatMajorVersion: pebble.FormatNewest,
+		Logger:             pebbleLogger{},
 	}
-	if err := cfg.LoadOrSave(propStore); err != nil {
-		ldb.Close()
+	for i := range pdbOpts.Levels {
+		l := &pdbOpts.Levels[i]
+		l.BlockSize = 1024 * 32 // balance performance of point reads and compression ratio.
+		l.FilterPolicy = bloom.FilterPolicy(10)
+		l.TargetFileSize = 4 * opt.MiB << i
+	}
+	pdbOpts.EnsureDefaults()
+
+	pdb, err := pebble.Open(path, pdbOpts)
+	if err != nil {
 		return nil, err
 	}
+	return engine.NewPebbleEngine(pdb), nil
+}
 
-	return &MuxDB{
-		engine: engine,
-		trieBackend: &backend{
-			Store: engine,
-			Cache: newCache(
-				options.TrieNodeCacheSizeMB,
-				uint32(options.TrieCachedNodeTTL)),
-			HistPtnFactor:    cfg.HistPtnFactor,
-			DedupedPtnFactor: cfg.DedupedPtnFactor,
-			CachedNodeTTL:    options.TrieCachedNodeTTL,
-		},
-		done: make(chan struct{}),
-	}, nil
+// detectEngine returns the engine which created the database at the given path,
+// or empty string if the database does not exist.
+func detectEngine(path string) string {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return ""
+	}
+	var current bool
+	for _, e := range entries {
+		name := e.Name()
+		// pebble always writes OPTIONS file, which leveldb never does
+		if strings.HasPrefix(name, "OPTIONS-") || strings.HasPrefix(name, "marker.") {
+			return EnginePebble
+		}
+		if name == "CURRENT" {
+			current = true
+		}
+	}
+	if current {
+		return EngineLevelDB
+	}
+	return ""
+}
+
+// pebbleLogger redirects pebble logs to muxdb logger.
+type pebbleLogger struct{}
+
+func (pebbleLogger) Infof(format string, args ...any) {
+	logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (pebbleLogger) Errorf(format string, args ...any) {
+	logger.Warn(fmt.Sprintf(format, args...))
+}
+
+func (pebbleLogger) Fatalf(format string, args ...any) {
+	logger.Crit(fmt.Sprintf(format, args...))
 }
 
 // NewMem creates a memory-backed DB.
@@ -163,6 +283,30 @@ func (db *MuxDB) DeleteTrieHistoryNodes(ctx context.Context, startMajorVer, limi
 	return db.trieBackend.DeleteHistoryNodes(ctx, startMajorVer, limitMajorVer)
 }
 
+// GetTrieNode returns the blob of the trie node stored at the given path and version,
+// from either history or deduped space. It's used to serve trie nodes to peers.
+func (db *MuxDB) GetTrieNode(name string, path []byte, ver trie.Version) ([]byte, error) {
+	snapshot := db.trieBackend.Store.Snapshot()
+	defer snapshot.Release()
+
+	blob, err := snapshot.Get(db.trieBackend.AppendHistNodeKey(nil, name, path, ver))
+	if err == nil || !snapshot.IsNotFound(err) {
+		return blob, err
+	}
+	return snapshot.Get(db.trieBackend.AppendDedupedNodeKey(nil, name, path, ver))
+}
+
+// NewTrieNodeWriter creates a writer to save trie node blobs into history space,
+// as if they were committed by tries.
+func (db *MuxDB) NewTrieNodeWriter() *TrieNodeWriter {
+	bulk := db.trieBackend.Store.Bulk()
+	bulk.EnableAutoFlush()
+	return &TrieNodeWriter{
+		back: db.trieBackend,
+		bulk: bulk,
+	}
+}
+
 // NewStore creates named kv-store.
 func (db *MuxDB) NewStore(name string) kv.Store {
 	return kv.Bucket(string(namedStoreSpace) + name).NewStore(db.engine)
@@ -178,21 +322,18 @@ func (db *MuxDB) EnableMetrics() {
 		ticker := time.NewTicker(metricsSampleInterval)
 		defer ticker.Stop()
 
-		var (
-			stats leveldb.DBStats
-			err   error
-		)
+		var stats leveldb.DBStats
 		for {
 			select {
 			case <-ticker.C:
-				// we only have one engine implementation for now, put the type assertion just for safety
-				lvl, ok := db.engine.(*engine.LevelEngine)
-				if ok {
-					err = lvl.Stats(&stats)
-					if err != nil {
+				switch eng := db.engine.(type) {
+				case *engine.LevelEngine:
+					if err := eng.Stats(&stats); err != nil {
 						logger.Warn("Failed to get LevelDB stats: %v", err)
 					}
 					registerCompactionMetrics(&stats)
+				case *engine.PebbleEngine:
+					registerPebbleCompactionMetrics(eng.Metrics())
 				}
 			case <-db.done:
 				return
@@ -204,13 +345,15 @@ func (db *MuxDB) EnableMetrics() {
 type config struct {
 	HistPtnFactor    uint32
 	DedupedPtnFactor uint32
+	Engine           string `json:",omitempty"`
 }
 
 func (c *config) LoadOrSave(store kv.Store) error {
 	// try to load
 	data, err := store.Get([]byte(configKey))
 	if err == nil {
-		// and decode
+		// and decode, fields missing in the saved config are left empty
+		*c = config{}
 		return json.Unmarshal(data, c)
 	}
 
@@ -253,3 +253,165 @@ func TestDeleteTrieHistory(t *testing.T) {
 	err = db.DeleteTrieHistoryNodes(context.Background(), 0, 2)
 	assert.Nil(t, err)
 }
+
+func TestPebbleEngine(t *testing.T) {
+	opts := &Options{
+		TrieNodeCacheSizeMB:        128,
+		TrieHistPartitionFactor:    1000,
+		TrieDedupedPartitionFactor: 2000,
+		OpenFilesCacheCapacity:     16,
+		ReadCacheMB:                32,
+		WriteBufferMB:              16,
+		Engine:                     EnginePebble,
+	}
+	path := filepath.Join(t.TempDir(), "pebble.db")
+
+	db, err := Open(path, opts)
+	assert.Nil(t, err)
+
+	store := db.NewStore("test")
+	bulk := store.Bulk()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		assert.Nil(t, bulk.Put([]byte(k), []byte("v"+k)))
+	}
+	assert.Nil(t, bulk.Write())
+
+	snapshot := store.Snapshot()
+	assert.Nil(t, store.Delete([]byte("a")))
+	val, err := snapshot.Get([]byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("va"), val)
+	snapshot.Release()
+
+	_, err = store.Get([]byte("a"))
+	assert.True(t, db.IsNotFound(err))
+	has, err := store.Has([]byte("b"))
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	var keys []string
+	iter := store.Iterate(kv.Range{Start: []byte("b"), Limit: []byte("d")})
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	assert.Nil(t, iter.Error())
+	iter.Release()
+	assert.Equal(t, []string{"b", "c"}, keys)
+
+	assert.Nil(t, store.DeleteRange(context.Background(), kv.Range{Start: []byte("c")}))
+	_, err = store.Get([]byte("d"))
+	assert.True(t, db.IsNotFound(err))
+	val, err = store.Get([]byte("b"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("vb"), val)
+
+	db.EnableMetrics()
+	assert.Nil(t, db.Close())
+
+	// reopen
+	db, err = Open(path, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1000), db.trieBackend.HistPtnFactor)
+	val, err = db.NewStore("test").Get([]byte("b"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("vb"), val)
+	assert.Nil(t, db.Close())
+}
+
+func TestEngineMismatch(t *testing.T) {
+	opts := Options{
+		TrieNodeCacheSizeMB:     128,
+		TrieHistPartitionFactor: 1000,
+		OpenFilesCacheCapacity:  16,
+		ReadCacheMB:             32,
+		WriteBufferMB:           16,
+	}
+	dir := t.TempDir()
+
+	levelPath := filepath.Join(dir, "level.db")
+	db, err := Open(levelPath, &opts)
+	assert.Nil(t, err)
+	db.Close()
+
+	pebbleOpts := opts
+	pebbleOpts.Engine = EnginePebble
+	_, err = Open(levelPath, &pebbleOpts)
+	assert.EqualError(t, err, "database was created by engine leveldb, but opened by pebble")
+
+	pebblePath := filepath.Join(dir, "pebble.db")
+	db, err = Open(pebblePath, &pebbleOpts)
+	assert.Nil(t, err)
+	db.Close()
+
+	_, err = Open(pebblePath, &opts)
+	assert.EqualError(t, err, "database was created by engine pebble, but opened by leveldb")
+
+	badOpts := opts
+	badOpts.Engine = "rocksdb"
+	_, err = Open(filepath.Join(dir, "bad.db"), &badOpts)
+	assert.EqualError(t, err, `unsupported engine "rocksdb"`)
+}
+
+func TestConfigEngine(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	store := db.NewStore(propStoreName)
+	// config saved before the engine is recorded
+	assert.Nil(t, store.Put([]byte(configKey), []byte(`{"HistPtnFactor":1,"DedupedPtnFactor":2}`)))
+
+	cfg := config{Engine: EnginePebble}
+	assert.Nil(t, cfg.LoadOrSave(store))
+	assert.Equal(t, config{HistPtnFactor: 1, DedupedPtnFactor: 2}, cfg)
+}
+
+func TestTrieNodeReadWrite(t *testing.T) {
+	db := NewMem()
+	defer db.Close()
+
+	tr := db.NewTrie("test", trie.Root{})
+	for i := range 100 {
+		assert.Nil(t, tr.Update([]byte{byte(i)}, []byte{byte(i), 1}, nil))
+	}
+	ver := trie.Version{Major: 1, Minor: 2}
+	assert.Nil(t, tr.Commit(ver, false))
+	root := trie.Root{Hash: tr.Hash(), Ver: ver}
+
+	// copy nodes into another db
+	db2 := NewMem()
+	defer db2.Close()
+	w := db2.NewTrieNodeWriter()
+
+	it := db.NewTrie("test", root).NodeIterator(nil, 0)
+	n := 0
+	for it.Next(true) {
+		blob, ver, err := it.Blob()
+		assert.Nil(t, err)
+		if len(blob) > 0 {
+			got, err := db.GetTrieNode("test", it.Path(), ver)
+			assert.Nil(t, err)
+			assert.Equal(t, blob, got)
+			assert.Nil(t, w.Put("test", it.Path(), ver, blob))
+			n++
+		}
+	}
+	assert.Nil(t, it.Error())
+	assert.Greater(t, n, 1)
+	assert.Nil(t, w.Flush())
+
+	tr2 := db2.NewTrie("test", root)
+	for i := range 100 {
+		val, _, err := tr2.Get([]byte{byte(i)})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{byte(i), 1}, val)
+	}
+
+	// served from deduped space once history deleted
+	assert.Nil(t, db.NewTrie("test", root).Checkpoint(context.Background(), 0, nil))
+	assert.Nil(t, db.DeleteTrieHistoryNodes(context.Background(), 0, 2))
+	_, err := db.GetTrieNode("test", nil, ver)
+	assert.Nil(t, err)
+
+	_, err = db.GetTrieNode("test", nil, trie.Version{Major: 3})
+	assert.True(t, db.IsNotFound(err))
+}
@@ -8,6 +8,7 @@ package muxdb
 import (
 	"context"
 
+	"github.com/vechain/thor/v2/kv"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/trie"
 )
@@ -195,6 +196,24 @@ func (t *Trie) Checkpoint(ctx context.Context, baseMajorVer uint32, handleLeaf f
 	return bulk.Write()
 }
 
+// TrieNodeWriter writes trie node blobs in bulk. The blobs are not validated.
+type TrieNodeWriter struct {
+	back   *backend
+	bulk   kv.Bulk
+	keyBuf []byte
+}
+
+// Put puts the node blob of the named trie at the given path and version.
+func (w *TrieNodeWriter) Put(name string, path []byte, ver trie.Version, blob []byte) error {
+	w.keyBuf = w.back.AppendHistNodeKey(w.keyBuf[:0], name, path, ver)
+	return w.bulk.Put(w.keyBuf, blob)
+}
+
+// Flush writes nodes not yet flushed.
+func (w *TrieNodeWriter) Flush() error {
+	return w.bulk.Write()
+}
+
 // individual functions of trie database interface.
 type (
 	databaseGetFunc func(path []byte, ver trie.Version) ([]byte, error)
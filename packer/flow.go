@@ -51,6 +51,11 @@ func (f *Flow) ParentHeader() *block.Header {
 	return f.parentHeader
 }
 
+// Runtime returns the runtime of the new block.
+func (f *Flow) Runtime() *runtime.Runtime {
+	return f.runtime
+}
+
 // Number returns new block number.
 func (f *Flow) Number() uint32 {
 	return f.runtime.Context().Number
@@ -91,64 +96,92 @@ func (f *Flow) hasTx(txid thor.Bytes32, txBlockRef uint32) (bool, error) {
 // If the tx is valid and can be executed on current state (regardless of VM error),
 // it will be adopted by the new block.
 func (f *Flow) Adopt(tx *tx.Transaction) error {
+	_, err := f.AdoptWithOutputs(tx)
+	return err
+}
+
+// AdoptWithOutputs is like Adopt, but also returns outputs of the executed clauses, which are not kept by receipts.
+func (f *Flow) AdoptWithOutputs(tx *tx.Transaction) ([]*runtime.Output, error) {
 	origin, _ := tx.Origin()
 	if f.Number() >= f.packer.forkConfig.BLOCKLIST && thor.IsOriginBlocked(origin) {
-		return badTxError{"tx origin blocked"}
+		return nil, badTxError{"tx origin blocked"}
 	}
 
 	if err := tx.TestFeatures(f.features); err != nil {
-		return badTxError{err.Error()}
+		return nil, badTxError{err.Error()}
 	}
 
 	switch {
 	case tx.ChainTag() != f.packer.repo.ChainTag():
-		return badTxError{"chain tag mismatch"}
+		return nil, badTxError{"chain tag mismatch"}
 	case f.Number() < tx.BlockRef().Number():
-		return errTxNotAdoptableNow
+		return nil, errTxNotAdoptableNow
 	case tx.IsExpired(f.Number()):
-		return badTxError{"expired"}
+		return nil, badTxError{"expired"}
 	case f.gasUsed+tx.Gas() > f.runtime.Context().GasLimit:
 		// has enough space to adopt minimum tx
 		if f.gasUsed+thor.TxGas+thor.ClauseGas <= f.runtime.Context().GasLimit {
 			// try to find a lower gas tx
-			return errTxNotAdoptableNow
+			return nil, errTxNotAdoptableNow
 		}
-		return errGasLimitReached
+		return nil, errGasLimitReached
 	}
 
 	// check if tx already there
 	if found, err := f.hasTx(tx.ID(), tx.BlockRef().Number()); err != nil {
-		return err
+		return nil, err
 	} else if found {
-		return errKnownTx
+		return nil, errKnownTx
 	}
 
 	if dependsOn := tx.DependsOn(); dependsOn != nil {
 		// check if deps exists
 		found, reverted, err := f.findDep(*dependsOn)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if !found {
-			return errTxNotAdoptableNow
+			return nil, errTxNotAdoptableNow
 		}
 		if reverted {
-			return errTxNotAdoptableForever
+			return nil, errTxNotAdoptableForever
 		}
 	}
 
 	checkpoint := f.runtime.State().NewCheckpoint()
-	receipt, err := f.runtime.ExecuteTransaction(tx)
+	receipt, outputs, err := executeTransaction(f.runtime, tx)
 	if err != nil {
 		// skip and revert state
 		f.runtime.State().RevertTo(checkpoint)
-		return badTxError{err.Error()}
+		return nil, badTxError{err.Error()}
 	}
 	f.processedTxs[tx.ID()] = receipt.Reverted
 	f.gasUsed += receipt.GasUsed
 	f.receipts = append(f.receipts, receipt)
 	f.txs = append(f.txs, tx)
-	return nil
+	return outputs, nil
+}
+
+// executeTransaction is like Runtime.ExecuteTransaction, but also returns outputs of the executed clauses.
+func executeTransaction(rt *runtime.Runtime, tx *tx.Transaction) (*tx.Receipt, []*runtime.Output, error) {
+	executor, err := rt.PrepareTransaction(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var outputs []*runtime.Output
+	for executor.HasNextClause() {
+		exec, _ := executor.PrepareNext()
+		_, output, err := exec()
+		if err != nil {
+			return nil, nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	receipt, err := executor.Finalize()
+	if err != nil {
+		return nil, nil, err
+	}
+	return receipt, outputs, nil
 }
 
 // Pack build and sign the new block.
@@ -26,6 +26,7 @@ type Packer struct {
 	targetGasLimit uint64
 	forkConfig     thor.ForkConfig
 	seeder         *poa.Seeder
+	impersonation  bool
 }
 
 // New create a new Packer instance.
@@ -44,7 +45,8 @@ func New(
 		beneficiary,
 		0,
 		forkConfig,
-		poa.NewSeeder(repo),
+		poa.NewSeeder(repo, forkConfig.EpochLength()),
+		false,
 	}
 }
 
@@ -99,14 +101,14 @@ func (p *Packer) Schedule(parent *chain.BlockSummary, nowTimestamp uint64) (flow
 	// calc the time when it's turn to produce block
 	var sched poa.Scheduler
 	if parent.Header.Number()+1 < p.forkConfig.VIP214 {
-		sched, err = poa.NewSchedulerV1(p.nodeMaster, proposers, parent.Header.Number(), parent.Header.Timestamp())
+		sched, err = poa.NewSchedulerV1(p.nodeMaster, proposers, parent.Header.Number(), parent.Header.Timestamp(), p.forkConfig.BlockInterval())
 	} else {
 		var seed []byte
 		seed, err = p.seeder.Generate(parent.Header.ID())
 		if err != nil {
 			return nil, err
 		}
-		sched, err = poa.NewSchedulerV2(p.nodeMaster, proposers, parent.Header.Number(), parent.Header.Timestamp(), seed)
+		sched, err = poa.NewSchedulerV2(p.nodeMaster, proposers, parent.Header.Number(), parent.Header.Timestamp(), seed, p.forkConfig.BlockInterval())
 	}
 	if err != nil {
 		return nil, err
@@ -132,7 +134,10 @@ func (p *Packer) Schedule(parent *chain.BlockSummary, nowTimestamp uint64) (flow
 			GasLimit:    p.gasLimit(parent.Header.GasLimit()),
 			TotalScore:  parent.Header.TotalScore() + score,
 		},
-		p.forkConfig)
+		p.forkConfig).SetImpersonation(p.impersonation)
+	if err := runtime.ApplyScheduledChanges(state, rt.Context().Number, p.forkConfig); err != nil {
+		return nil, err
+	}
 
 	return newFlow(p, parent.Header, rt, features), nil
 }
@@ -153,18 +158,26 @@ func (p *Packer) Mock(parent *chain.BlockSummary, targetTime uint64, gasLimit ui
 		gl = p.gasLimit(parent.Header.GasLimit())
 	}
 
+	beneficiary := p.nodeMaster
+	if p.beneficiary != nil {
+		beneficiary = *p.beneficiary
+	}
+
 	rt := runtime.New(
 		p.repo.NewChain(parent.Header.ID()),
 		state,
 		&xenv.BlockContext{
-			Beneficiary: p.nodeMaster,
+			Beneficiary: beneficiary,
 			Signer:      p.nodeMaster,
 			Number:      parent.Header.Number() + 1,
 			Time:        targetTime,
 			GasLimit:    gl,
 			TotalScore:  parent.Header.TotalScore() + 1,
 		},
-		p.forkConfig)
+		p.forkConfig).SetImpersonation(p.impersonation)
+	if err := runtime.ApplyScheduledChanges(state, rt.Context().Number, p.forkConfig); err != nil {
+		return nil, err
+	}
 
 	return newFlow(p, parent.Header, rt, features), nil
 }
@@ -181,3 +194,9 @@ func (p *Packer) gasLimit(parentGasLimit uint64) uint64 {
 func (p *Packer) SetTargetGasLimit(gl uint64) {
 	p.targetGasLimit = gl
 }
+
+// SetImpersonation sets whether txs signed by impersonated signatures can be packed.
+// It must only be enabled for development networks, e.g. solo, whose blocks are not validated by others.
+func (p *Packer) SetImpersonation(enabled bool) {
+	p.impersonation = enabled
+}
@@ -25,6 +25,7 @@ type SchedulerV1 struct {
 	actives           []Proposer
 	parentBlockNumber uint32
 	parentBlockTime   uint64
+	blockInterval     uint64
 }
 
 var _ Scheduler = (*SchedulerV1)(nil)
@@ -37,6 +38,7 @@ func NewSchedulerV1(
 	proposers []Proposer,
 	parentBlockNumber uint32,
 	parentBlockTime uint64,
+	blockInterval uint64,
 ) (*SchedulerV1, error) {
 	actives := make([]Proposer, 0, len(proposers))
 	listed := false
@@ -60,6 +62,7 @@ func NewSchedulerV1(
 		actives,
 		parentBlockNumber,
 		parentBlockTime,
+		blockInterval,
 	}, nil
 }
 
@@ -71,7 +74,7 @@ func (s *SchedulerV1) whoseTurn(t uint64) Proposer {
 // Schedule to determine time of the proposer to produce a block, according to `nowTime`.
 // `newBlockTime` is promised to be >= nowTime and > parentBlockTime
 func (s *SchedulerV1) Schedule(nowTime uint64) (newBlockTime uint64) {
-	const T = thor.BlockInterval
+	T := s.blockInterval
 
 	newBlockTime = s.parentBlockTime + T
 
@@ -98,7 +101,7 @@ func (s *SchedulerV1) IsTheTime(newBlockTime uint64) bool {
 		return false
 	}
 
-	if (newBlockTime-s.parentBlockTime)%thor.BlockInterval != 0 {
+	if (newBlockTime-s.parentBlockTime)%s.blockInterval != 0 {
 		// invalid block time
 		return false
 	}
@@ -110,13 +113,13 @@ func (s *SchedulerV1) IsTheTime(newBlockTime uint64) bool {
 func (s *SchedulerV1) Updates(newBlockTime uint64) (updates []Proposer, score uint64) {
 	toDeactivate := make(map[thor.Address]Proposer)
 
-	t := newBlockTime - thor.BlockInterval
+	t := newBlockTime - s.blockInterval
 	for i := uint64(0); i < thor.InitialMaxBlockProposers && t > s.parentBlockTime; i++ {
 		p := s.whoseTurn(t)
 		if p.Address != s.proposer.Address {
 			toDeactivate[p.Address] = p
 		}
-		t -= thor.BlockInterval
+		t -= s.blockInterval
 	}
 
 	updates = make([]Proposer, 0, len(toDeactivate)+1)
@@ -34,10 +34,10 @@ var (
 )
 
 func TestSchedule(t *testing.T) {
-	_, err := poa.NewSchedulerV1(thor.BytesToAddress([]byte("px")), proposers, 1, parentTime)
+	_, err := poa.NewSchedulerV1(thor.BytesToAddress([]byte("px")), proposers, 1, parentTime, thor.BlockInterval)
 	assert.NotNil(t, err)
 
-	sched, _ := poa.NewSchedulerV1(p1, proposers, 1, parentTime)
+	sched, _ := poa.NewSchedulerV1(p1, proposers, 1, parentTime, thor.BlockInterval)
 
 	for i := uint64(0); i < 100; i++ {
 		now := parentTime + i*thor.BlockInterval/2
@@ -48,7 +48,7 @@ func TestSchedule(t *testing.T) {
 }
 
 func TestIsTheTime(t *testing.T) {
-	sched, _ := poa.NewSchedulerV1(p2, proposers, 1, parentTime)
+	sched, _ := poa.NewSchedulerV1(p2, proposers, 1, parentTime, thor.BlockInterval)
 
 	tests := []struct {
 		now  uint64
@@ -65,7 +65,7 @@ func TestIsTheTime(t *testing.T) {
 }
 
 func TestUpdates(t *testing.T) {
-	sched, _ := poa.NewSchedulerV1(p1, proposers, 1, parentTime)
+	sched, _ := poa.NewSchedulerV1(p1, proposers, 1, parentTime, thor.BlockInterval)
 
 	tests := []struct {
 		newBlockTime uint64
@@ -86,10 +86,10 @@ func TestScheduleV2(t *testing.T) {
 	binary.BigEndian.PutUint32(parentID[:], 0)
 	parent := new(block.Builder).ParentID(parentID).Timestamp(parentTime).Build()
 
-	_, err := poa.NewSchedulerV2(thor.BytesToAddress([]byte("p6")), proposers, parent.Header().Number(), parent.Header().Timestamp(), nil)
+	_, err := poa.NewSchedulerV2(thor.BytesToAddress([]byte("p6")), proposers, parent.Header().Number(), parent.Header().Timestamp(), nil, thor.BlockInterval)
 	assert.NotNil(t, err)
 
-	sched, _ := poa.NewSchedulerV2(p2, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil)
+	sched, _ := poa.NewSchedulerV2(p2, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil, thor.BlockInterval)
 
 	for i := uint64(0); i < 100; i++ {
 		now := parentTime + i*thor.BlockInterval/2
@@ -104,7 +104,7 @@ func TestIsTheTimeV2(t *testing.T) {
 	binary.BigEndian.PutUint32(parentID[:], 0)
 	parent := new(block.Builder).ParentID(parentID).Timestamp(parentTime).Build()
 
-	sched, err := poa.NewSchedulerV2(p2, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil)
+	sched, err := poa.NewSchedulerV2(p2, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil, thor.BlockInterval)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -123,12 +123,31 @@ func TestIsTheTimeV2(t *testing.T) {
 	}
 }
 
+func TestScheduleV2CustomInterval(t *testing.T) {
+	const interval = 3
+
+	sched, err := poa.NewSchedulerV2(p2, proposers, 0, parentTime, nil, interval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := uint64(0); i < 100; i++ {
+		now := parentTime + i
+		nbt := sched.Schedule(now)
+		assert.True(t, nbt >= now)
+		assert.Zero(t, (nbt-parentTime)%interval)
+		assert.True(t, sched.IsTheTime(nbt))
+	}
+	assert.True(t, sched.IsTheTime(parentTime+interval))
+	assert.False(t, sched.IsTheTime(parentTime+thor.BlockInterval))
+}
+
 func TestUpdatesV2(t *testing.T) {
 	var parentID thor.Bytes32
 	binary.BigEndian.PutUint32(parentID[:], 0)
 	parent := new(block.Builder).ParentID(parentID).Timestamp(parentTime).Build()
 
-	sched, err := poa.NewSchedulerV2(p2, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil)
+	sched, err := poa.NewSchedulerV2(p2, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil, thor.BlockInterval)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -152,7 +171,7 @@ func TestActivateInV2(t *testing.T) {
 	binary.BigEndian.PutUint32(parentID[:], 0)
 	parent := new(block.Builder).ParentID(parentID).Timestamp(parentTime).Build()
 
-	sched, err := poa.NewSchedulerV2(p1, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil)
+	sched, err := poa.NewSchedulerV2(p1, proposers, parent.Header().Number(), parent.Header().Timestamp(), nil, thor.BlockInterval)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -19,6 +19,7 @@ import (
 type SchedulerV2 struct {
 	proposer        Proposer
 	parentBlockTime uint64
+	blockInterval   uint64
 	shuffled        []thor.Address
 }
 
@@ -32,7 +33,8 @@ func NewSchedulerV2(
 	proposers []Proposer,
 	parentBlockNumber uint32,
 	parentBlockTime uint64,
-	seed []byte) (*SchedulerV2, error) {
+	seed []byte,
+	blockInterval uint64) (*SchedulerV2, error) {
 	var (
 		listed   = false
 		proposer Proposer
@@ -76,6 +78,7 @@ func NewSchedulerV2(
 	return &SchedulerV2{
 		proposer,
 		parentBlockTime,
+		blockInterval,
 		shuffled,
 	}, nil
 }
@@ -83,7 +86,7 @@ func NewSchedulerV2(
 // Schedule to determine time of the proposer to produce a block, according to `nowTime`.
 // `newBlockTime` is promised to be >= nowTime and > parentBlockTime
 func (s *SchedulerV2) Schedule(nowTime uint64) (newBlockTime uint64) {
-	const T = thor.BlockInterval
+	T := s.blockInterval
 
 	newBlockTime = s.parentBlockTime + T
 	if nowTime > newBlockTime {
@@ -115,7 +118,7 @@ func (s *SchedulerV2) IsScheduled(blockTime uint64, proposer thor.Address) bool
 		return false
 	}
 
-	T := thor.BlockInterval
+	T := s.blockInterval
 	if (blockTime-s.parentBlockTime)%T != 0 {
 		// invalid block time
 		return false
@@ -127,7 +130,7 @@ func (s *SchedulerV2) IsScheduled(blockTime uint64, proposer thor.Address) bool
 
 // Updates returns proposers whose status are changed, and the score when new block time is assumed to be newBlockTime.
 func (s *SchedulerV2) Updates(newBlockTime uint64) (updates []Proposer, score uint64) {
-	T := thor.BlockInterval
+	T := s.blockInterval
 
 	for i := uint64(0); i < uint64(len(s.shuffled)); i++ {
 		if s.parentBlockTime+T+i*T >= newBlockTime {
@@ -74,6 +74,7 @@ func TestSchedulerV2_Updates(t *testing.T) {
 			s := &SchedulerV2{
 				proposer:        tt.fields.proposer,
 				parentBlockTime: tt.fields.parentBlockTime,
+				blockInterval:   thor.BlockInterval,
 				shuffled:        tt.fields.shuffled,
 			}
 			gotUpdates, gotScore := s.Updates(tt.args.newBlockTime)
@@ -140,7 +141,7 @@ func TestNewSchedulerV2(t *testing.T) {
 				parentTime,
 				seed,
 			},
-			&SchedulerV2{Proposer{p1, true}, parentTime, []thor.Address{p1, p4, p3, p2, p5}},
+			&SchedulerV2{Proposer{p1, true}, parentTime, thor.BlockInterval, []thor.Address{p1, p4, p3, p2, p5}},
 			false,
 		},
 		{
@@ -152,7 +153,7 @@ func TestNewSchedulerV2(t *testing.T) {
 				parentTime,
 				seed,
 			},
-			&SchedulerV2{Proposer{p1, false}, parentTime, []thor.Address{p1, p4, p3, p2, p5}},
+			&SchedulerV2{Proposer{p1, false}, parentTime, thor.BlockInterval, []thor.Address{p1, p4, p3, p2, p5}},
 			false,
 		},
 		{
@@ -170,7 +171,7 @@ func TestNewSchedulerV2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewSchedulerV2(tt.args.addr, tt.args.proposers, tt.args.parentBlockNumber, tt.args.parentBlockTime, tt.args.seed)
+			got, err := NewSchedulerV2(tt.args.addr, tt.args.proposers, tt.args.parentBlockNumber, tt.args.parentBlockTime, tt.args.seed, thor.BlockInterval)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewSchedulerV2() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -223,6 +224,7 @@ func TestSchedulerV2_Schedule(t *testing.T) {
 			s := &SchedulerV2{
 				proposer:        tt.fields.proposer,
 				parentBlockTime: tt.fields.parentBlockTime,
+				blockInterval:   thor.BlockInterval,
 				shuffled:        tt.fields.shuffled,
 			}
 			if gotNewBlockTime := s.Schedule(tt.args.nowTime); gotNewBlockTime != tt.wantNewBlockTime {
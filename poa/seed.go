@@ -11,27 +11,19 @@ import (
 	"github.com/vechain/thor/v2/thor"
 )
 
-var epochInterval uint32 = thor.SeederInterval
-
-// mockEpochInterval mocks the epoch interval。
-// TEST ONLY
-//
-//nolint:unused
-func mockEpochInterval(interval uint32) {
-	epochInterval = interval
-}
-
 // Seeder generates seed for poa scheduler.
 type Seeder struct {
-	repo  *chain.Repository
-	cache map[thor.Bytes32][]byte
+	repo        *chain.Repository
+	cache       map[thor.Bytes32][]byte
+	epochLength uint32
 }
 
-// NewSeeder creates a seeder
-func NewSeeder(repo *chain.Repository) *Seeder {
+// NewSeeder creates a seeder, the seed changes every `epochLength` blocks.
+func NewSeeder(repo *chain.Repository, epochLength uint32) *Seeder {
 	return &Seeder{
 		repo,
 		make(map[thor.Bytes32][]byte),
+		epochLength,
 	}
 }
 
@@ -39,11 +31,11 @@ func NewSeeder(repo *chain.Repository) *Seeder {
 func (seeder *Seeder) Generate(parentID thor.Bytes32) (seed []byte, err error) {
 	blockNum := block.Number(parentID) + 1
 
-	epoch := blockNum / epochInterval
+	epoch := blockNum / seeder.epochLength
 	if epoch <= 1 {
 		return
 	}
-	seedNum := (epoch - 1) * epochInterval
+	seedNum := (epoch - 1) * seeder.epochLength
 
 	seedID, err := seeder.repo.NewChain(parentID).GetBlockID(seedNum)
 	if err != nil {
@@ -22,7 +22,7 @@ import (
 )
 
 func TestSeeder_Generate(t *testing.T) {
-	mockEpochInterval(10)
+	const epochInterval uint32 = 10
 	db := muxdb.NewMem()
 	g := genesis.NewDevnet()
 	b0, _, _, _ := g.Build(state.NewStater(db))
@@ -77,8 +77,9 @@ func TestSeeder_Generate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			seeder := &Seeder{
-				repo:  tt.fields.repo,
-				cache: tt.fields.cache,
+				repo:        tt.fields.repo,
+				cache:       tt.fields.cache,
+				epochLength: epochInterval,
 			}
 			got, err := seeder.Generate(tt.args.parentID)
 			if (err != nil) != tt.wantErr {
@@ -182,8 +183,9 @@ func TestSeeder_Generate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			seeder := &Seeder{
-				repo:  tt.fields.repo,
-				cache: tt.fields.cache,
+				repo:        tt.fields.repo,
+				cache:       tt.fields.cache,
+				epochLength: epochInterval,
 			}
 			got, err := seeder.Generate(tt.args.parentID)
 			if (err != nil) != tt.wantErr {
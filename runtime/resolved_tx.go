@@ -28,10 +28,32 @@ type ResolvedTransaction struct {
 
 // ResolveTransaction resolves the transaction and performs basic validation.
 func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
+	return resolveTransaction(tx, false)
+}
+
+// resolveTransaction is like ResolveTransaction, but signers of impersonated txs are
+// resolved by their claims if impersonation is enabled.
+func resolveTransaction(tx *tx.Transaction, impersonation bool) (*ResolvedTransaction, error) {
+	if impersonation && tx.IsImpersonated() {
+		origin, delegator, err := tx.ImpersonatedSigners()
+		if err != nil {
+			return nil, err
+		}
+		return resolveSignedTransaction(tx, origin, delegator)
+	}
+
 	origin, err := tx.Origin()
 	if err != nil {
 		return nil, err
 	}
+	delegator, err := tx.Delegator()
+	if err != nil {
+		return nil, err
+	}
+	return resolveSignedTransaction(tx, origin, delegator)
+}
+
+func resolveSignedTransaction(tx *tx.Transaction, origin thor.Address, delegator *thor.Address) (*ResolvedTransaction, error) {
 	intrinsicGas, err := tx.IntrinsicGas()
 	if err != nil {
 		return nil, err
@@ -39,10 +61,6 @@ func ResolveTransaction(tx *tx.Transaction) (*ResolvedTransaction, error) {
 	if tx.Gas() < intrinsicGas {
 		return nil, errors.New("intrinsic gas exceeds provided gas")
 	}
-	delegator, err := tx.Delegator()
-	if err != nil {
-		return nil, err
-	}
 
 	clauses := tx.Clauses()
 	sumValue := new(big.Int)
@@ -81,11 +81,12 @@ type TransactionExecutor struct {
 
 // Runtime bases on EVM and VeChain Thor builtins.
 type Runtime struct {
-	vmConfig    vm.Config
-	chain       *chain.Chain
-	state       *state.State
-	ctx         *xenv.BlockContext
-	chainConfig vm.ChainConfig
+	vmConfig      vm.Config
+	chain         *chain.Chain
+	state         *state.State
+	ctx           *xenv.BlockContext
+	chainConfig   vm.ChainConfig
+	impersonation bool
 }
 
 // New create a Runtime object.
@@ -135,6 +136,32 @@ func New(
 	return &rt
 }
 
+// ApplyScheduledChanges applies the params changes and the authority changes of permissioned networks
+// scheduled at the block. It must only be called when the block is executed on its parent state, before
+// any tx, otherwise the changes are applied again over the state they have taken effect.
+func ApplyScheduledChanges(state *state.State, blockNum uint32, forkConfig thor.ForkConfig) error {
+	for _, change := range forkConfig.ParamsChanges(blockNum) {
+		if err := builtin.Params.Native(state).Set(change.Key, change.Value); err != nil {
+			return err
+		}
+	}
+
+	if changes := forkConfig.AuthorityChanges(blockNum); changes != nil {
+		authority := builtin.Authority.Native(state)
+		for _, master := range changes.Revoke {
+			if _, err := authority.Revoke(master); err != nil {
+				return err
+			}
+		}
+		for _, node := range changes.Add {
+			if _, err := authority.Add(node.Master, node.Endorsor, node.Identity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (rt *Runtime) Chain() *chain.Chain         { return rt.chain }
 func (rt *Runtime) State() *state.State         { return rt.state }
 func (rt *Runtime) Context() *xenv.BlockContext { return rt.ctx }
@@ -146,6 +173,14 @@ func (rt *Runtime) SetVMConfig(config vm.Config) *Runtime {
 	return rt
 }
 
+// SetImpersonation sets whether txs signed by impersonated signatures are executed as their claimed origins.
+// It must only be enabled for development networks, e.g. solo, whose blocks are not validated by others.
+// Returns this runtime.
+func (rt *Runtime) SetImpersonation(enabled bool) *Runtime {
+	rt.impersonation = enabled
+	return rt
+}
+
 func (rt *Runtime) newEVM(stateDB *statedb.StateDB, clauseIndex uint32, txCtx *xenv.TransactionContext) *vm.EVM {
 	var lastNonNativeCallGas uint64
 	return vm.NewEVM(vm.Context{
@@ -389,7 +424,7 @@ func (rt *Runtime) ExecuteTransaction(tx *tx.Transaction) (receipt *tx.Receipt,
 
 // PrepareTransaction prepare to execute tx.
 func (rt *Runtime) PrepareTransaction(tx *tx.Transaction) (*TransactionExecutor, error) {
-	resolvedTx, err := ResolveTransaction(tx)
+	resolvedTx, err := resolveTransaction(tx, rt.impersonation)
 	if err != nil {
 		return nil, err
 	}
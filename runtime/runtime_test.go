@@ -504,3 +504,32 @@ func TestExecuteTransactionFailure(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestScheduledParams(t *testing.T) {
+	db := muxdb.NewMem()
+
+	g := genesis.NewDevnet()
+	b0, _, _, err := g.Build(state.NewStater(db))
+	assert.Nil(t, err)
+
+	repo, _ := chain.NewRepository(db, b0)
+
+	forkConfig := thor.NoFork
+	forkConfig.Params = &thor.ParamsSchedule{
+		10: {{Key: thor.KeyBaseGasPrice, Value: big.NewInt(1)}},
+	}
+
+	for _, num := range []uint32{9, 11} {
+		state := state.New(db, trie.Root{Hash: b0.Header().StateRoot()})
+		assert.Nil(t, runtime.ApplyScheduledChanges(state, num, forkConfig))
+		assert.Equal(t, M(thor.InitialBaseGasPrice, nil), M(builtin.Params.Native(state).Get(thor.KeyBaseGasPrice)))
+	}
+
+	// not applied by runtimes, which might be created on the state they have taken effect
+	state := state.New(db, trie.Root{Hash: b0.Header().StateRoot()})
+	runtime.New(repo.NewChain(b0.Header().ID()), state, &xenv.BlockContext{Number: 10}, forkConfig)
+	assert.Equal(t, M(thor.InitialBaseGasPrice, nil), M(builtin.Params.Native(state).Get(thor.KeyBaseGasPrice)))
+
+	assert.Nil(t, runtime.ApplyScheduledChanges(state, 10, forkConfig))
+	assert.Equal(t, M(big.NewInt(1), nil), M(builtin.Params.Native(state).Get(thor.KeyBaseGasPrice)))
+}
@@ -118,6 +118,23 @@ func (sm *StackedMap) Journal(cb func(key, value any) bool) {
 	}
 }
 
+// JournalFrom is like Journal, but traverses journal entries from the given offset.
+func (sm *StackedMap) JournalFrom(offset int, cb func(key, value any) bool) {
+	for _, lvl := range sm.mapStack {
+		journal := lvl.(*level).journal
+		if offset >= len(journal) {
+			offset -= len(journal)
+			continue
+		}
+		for _, entry := range journal[offset:] {
+			if !cb(entry.key, entry.value) {
+				return
+			}
+		}
+		offset = 0
+	}
+}
+
 // stack ops
 type stack []any
 
@@ -93,4 +93,12 @@ func TestStackedMapPuts(t *testing.T) {
 	})
 
 	assert.Equal(1, i, "Journal traverse should abort")
+
+	i = 2
+	sm.JournalFrom(2, func(k, v any) bool {
+		assert.Equal(k, kvs[i].k)
+		i++
+		return true
+	})
+	assert.Equal(len(kvs), i)
 }
@@ -7,6 +7,7 @@ package state
 
 import (
 	"bytes"
+	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -19,6 +20,44 @@ type AccountMetadata struct {
 	StorageID       []byte // the unique id of the storage trie.
 	StorageMajorVer uint32 // the major version of the last storage update.
 	StorageMinorVer uint32 // the minor version of the last storage update.
+	Forked          bool   // storage not found locally falls back to the forked state.
+}
+
+// accountMetadataRLP is the rlp layout of AccountMetadata.
+// The fork flag is appended only if set, so that the encoding of ordinary metadata is unchanged.
+type accountMetadataRLP struct {
+	StorageID       []byte
+	StorageMajorVer uint32
+	StorageMinorVer uint32
+	Forked          []bool `rlp:"tail"`
+}
+
+// EncodeRLP implements rlp.Encoder.
+func (am *AccountMetadata) EncodeRLP(w io.Writer) error {
+	v := accountMetadataRLP{
+		StorageID:       am.StorageID,
+		StorageMajorVer: am.StorageMajorVer,
+		StorageMinorVer: am.StorageMinorVer,
+	}
+	if am.Forked {
+		v.Forked = []bool{true}
+	}
+	return rlp.Encode(w, &v)
+}
+
+// DecodeRLP implements rlp.Decoder.
+func (am *AccountMetadata) DecodeRLP(s *rlp.Stream) error {
+	var v accountMetadataRLP
+	if err := s.Decode(&v); err != nil {
+		return err
+	}
+	*am = AccountMetadata{
+		StorageID:       v.StorageID,
+		StorageMajorVer: v.StorageMajorVer,
+		StorageMinorVer: v.StorageMinorVer,
+		Forked:          len(v.Forked) > 0 && v.Forked[0],
+	}
+	return nil
 }
 
 // Account is the Thor consensus representation of an account.
@@ -74,13 +113,30 @@ func secureKey(k []byte) []byte { return thor.Blake2b(k).Bytes() }
 // loadAccount load an account object and its metadata by address in trie.
 // It returns empty account is no account found at the address.
 func loadAccount(trie *muxdb.Trie, addr thor.Address) (*Account, *AccountMetadata, error) {
-	data, meta, err := trie.Get(secureKey(addr[:]))
+	a, am, found, err := findAccount(trie, addr)
 	if err != nil {
 		return nil, nil, err
 	}
-	if len(data) == 0 {
+	if !found {
 		return emptyAccount(), &AccountMetadata{}, nil
 	}
+	return a, am, nil
+}
+
+// findAccount is like loadAccount, but reports whether the address is present in trie.
+func findAccount(trie *muxdb.Trie, addr thor.Address) (*Account, *AccountMetadata, bool, error) {
+	data, meta, err := trie.Get(secureKey(addr[:]))
+	if err != nil || len(data) == 0 {
+		return nil, nil, false, err
+	}
+	a, am, err := decodeAccount(data, meta)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return a, am, true, nil
+}
+
+func decodeAccount(data, meta []byte) (*Account, *AccountMetadata, error) {
 	var a Account
 	if err := rlp.DecodeBytes(data, &a); err != nil {
 		return nil, nil, err
@@ -96,31 +152,46 @@ func loadAccount(trie *muxdb.Trie, addr thor.Address) (*Account, *AccountMetadat
 }
 
 // saveAccount save account into trie at given address.
-// If the given account is empty, the value for given address is deleted.
-func saveAccount(trie *muxdb.Trie, addr thor.Address, a *Account, am *AccountMetadata) error {
-	if a.IsEmpty() {
-		// delete if account is empty
-		return trie.Update(secureKey(addr[:]), nil, nil)
-	}
-
-	data, err := rlp.EncodeToBytes(a)
+// If the given account is empty, the value for given address is deleted, unless keepEmpty is set,
+// which is required by forked state to shadow the account in the fallback.
+func saveAccount(trie *muxdb.Trie, addr thor.Address, a *Account, am *AccountMetadata, keepEmpty bool) error {
+	data, mdata, err := encodeAccount(a, am, keepEmpty)
 	if err != nil {
 		return err
 	}
+	return trie.Update(secureKey(addr[:]), data, mdata)
+}
+
+// encodeAccount encodes the account and its metadata as saved in trie.
+// Nil data is returned if the account is to be deleted.
+func encodeAccount(a *Account, am *AccountMetadata, keepEmpty bool) (data, mdata []byte, err error) {
+	if a.IsEmpty() && !keepEmpty {
+		// delete if account is empty
+		return nil, nil, nil
+	}
 
-	var mdata []byte
-	if len(a.StorageRoot) > 0 { // discard metadata if storage root is empty
+	if data, err = rlp.EncodeToBytes(a); err != nil {
+		return nil, nil, err
+	}
+	if len(a.StorageRoot) > 0 || am.Forked { // discard metadata if storage root is empty
 		if mdata, err = rlp.EncodeToBytes(am); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
-	return trie.Update(secureKey(addr[:]), data, mdata)
+	return data, mdata, nil
 }
 
 // loadStorage load storage data for given key.
-func loadStorage(trie *muxdb.Trie, key thor.Bytes32) (rlp.RawValue, error) {
-	v, _, err := trie.Get(secureKey(key[:]))
-	return v, err
+// The deleted flag is set, with empty data returned, if a tombstone is saved, see storageTombstone.
+func loadStorage(trie *muxdb.Trie, key thor.Bytes32) (rlp.RawValue, bool, error) {
+	v, meta, err := trie.Get(secureKey(key[:]))
+	if err != nil {
+		return nil, false, err
+	}
+	if isStorageTombstoneMeta(meta) {
+		return nil, true, nil
+	}
+	return v, false, nil
 }
 
 // saveStorage save value for given key.
@@ -132,3 +203,8 @@ func saveStorage(trie *muxdb.Trie, key thor.Bytes32, data rlp.RawValue) error {
 		bytes.TrimLeft(key[:], "\x00"), // key preimage as metadata
 	)
 }
+
+// saveStorageTombstone saves the tombstone for given key, see storageTombstone.
+func saveStorageTombstone(trie *muxdb.Trie, key thor.Bytes32) error {
+	return trie.Update(secureKey(key[:]), storageTombstone, storageTombstoneMeta(key))
+}
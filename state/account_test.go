@@ -62,12 +62,12 @@ func TestTrie(t *testing.T) {
 		StorageMajorVer: 1,
 		StorageMinorVer: 2,
 	}
-	saveAccount(tr, addr, &acc1, &meta1)
+	saveAccount(tr, addr, &acc1, &meta1, false)
 	assert.Equal(t,
 		M(loadAccount(tr, addr)),
 		M(&acc1, &meta1, nil))
 
-	saveAccount(tr, addr, emptyAccount(), &meta1)
+	saveAccount(tr, addr, emptyAccount(), &meta1, false)
 	assert.Equal(t,
 		M(tr.Get(addr[:])),
 		M([]byte(nil), []byte(nil), nil),
@@ -81,13 +81,18 @@ func TestStorageTrie(t *testing.T) {
 	key := thor.BytesToBytes32([]byte("key"))
 	assert.Equal(t,
 		M(loadStorage(tr, key)),
-		M(rlp.RawValue(nil), nil))
+		M(rlp.RawValue(nil), false, nil))
 
 	value := rlp.RawValue("value")
 	saveStorage(tr, key, value)
 	assert.Equal(t,
 		M(loadStorage(tr, key)),
-		M(value, nil))
+		M(value, false, nil))
+
+	saveStorageTombstone(tr, key)
+	assert.Equal(t,
+		M(loadStorage(tr, key)),
+		M(rlp.RawValue(nil), true, nil))
 
 	saveStorage(tr, key, nil)
 	assert.Equal(t,
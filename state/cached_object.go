@@ -22,6 +22,9 @@ type cachedObject struct {
 	data Account
 	meta AccountMetadata
 
+	fallback Fallback       // set if the account is forked
+	history  *historyReader // set if the state reads the history index
+
 	cache struct {
 		code        []byte
 		storageTrie *muxdb.Trie
@@ -70,15 +73,35 @@ func (co *cachedObject) GetStorage(key thor.Bytes32) (rlp.RawValue, error) {
 	}
 	// not found in cache
 
-	trie := co.getOrCreateStorageTrie()
-	if trie == nil {
-		return nil, nil
+	var (
+		v       rlp.RawValue
+		deleted bool
+	)
+	if trie := co.getOrCreateStorageTrie(); trie != nil {
+		var (
+			found bool
+			err   error
+		)
+		// load from the history index, or trie
+		if co.history != nil {
+			if v, deleted, found, err = co.history.getStorage(co.addr, co.meta.StorageID, key); err != nil {
+				return nil, err
+			}
+		}
+		if !found {
+			if v, deleted, err = loadStorage(trie, key); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// load from trie
-	v, err := loadStorage(trie, key)
-	if err != nil {
-		return nil, err
+	if co.fallback != nil && !deleted {
+		if len(v) == 0 {
+			var err error
+			if v, err = co.fallback.GetRawStorage(co.addr, key); err != nil {
+				return nil, err
+			}
+		}
 	}
 	// put into cache
 	cache.storage[key] = v
@@ -99,7 +122,7 @@ func (co *cachedObject) GetCode() ([]byte, error) {
 			return code.([]byte), nil
 		}
 
-		code, err := co.db.NewStore(codeStoreName).Get(co.data.CodeHash)
+		code, err := co.db.NewStore(CodeStoreName).Get(co.data.CodeHash)
 		if err != nil {
 			return nil, err
 		}
@@ -45,7 +45,7 @@ func TestCachedObject(t *testing.T) {
 	rand.Read(code)
 
 	codeHash := thor.Keccak256(code).Bytes()
-	db.NewStore(codeStoreName).Put(codeHash, code)
+	db.NewStore(CodeStoreName).Put(codeHash, code)
 
 	account := Account{
 		Balance:     &big.Int{},
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// AccountSnapshot is the value of an account at some point of the journal.
+// Storage only contains slots touched in the range of journal inspected.
+type AccountSnapshot struct {
+	Balance *big.Int
+	Energy  *big.Int
+	Code    []byte
+	Storage map[thor.Bytes32]thor.Bytes32
+}
+
+// MaxClearedKeys is the max count of slots in the storage trie reported as cleared by deleting an account.
+const MaxClearedKeys = 10000
+
+// AccountChange describes an account touched in a range of journal,
+// with its value at the beginning(Pre) and the end(Post) of the range.
+type AccountChange struct {
+	Pre  AccountSnapshot
+	Post AccountSnapshot
+	// StorageCleared is set if the account is deleted with more than MaxClearedKeys slots in the storage trie,
+	// which are not included in the snapshots then.
+	StorageCleared bool
+}
+
+// ChangeTracker collects changes of the state made between successive calls of Changes.
+// Each journal entry is inspected once, so that collecting changes of every transaction in a block
+// costs linear time.
+// It's unreliable if the state is reverted to a checkpoint made before the last call.
+type ChangeTracker struct {
+	s      *State
+	offset int         // count of journal entries inspected
+	values map[any]any // latest values of keys in inspected journal entries
+}
+
+// NewChangeTracker creates a tracker collecting changes made from now on.
+func (s *State) NewChangeTracker() *ChangeTracker {
+	t := &ChangeTracker{s: s, values: make(map[any]any)}
+	s.sm.Journal(func(k, v any) bool {
+		t.values[k] = v
+		t.offset++
+		return true
+	})
+	return t
+}
+
+// Changes collects accounts and storage slots touched since the last call, or the creation of the tracker.
+// Storage slots cleared by deleting the account are included, up to MaxClearedKeys slots of the storage trie.
+// The energy is calculated at the given block time.
+func (t *ChangeTracker) Changes(ctx context.Context, blockTime uint64) (map[thor.Address]*AccountChange, error) {
+	var (
+		s       = t.s
+		entries []any
+		touched = make(map[thor.Address]map[thor.Bytes32]struct{})
+	)
+	touch := func(addr thor.Address) map[thor.Bytes32]struct{} {
+		keys, ok := touched[addr]
+		if !ok {
+			keys = make(map[thor.Bytes32]struct{})
+			touched[addr] = keys
+		}
+		return keys
+	}
+
+	s.sm.JournalFrom(t.offset, func(k, v any) bool {
+		switch key := k.(type) {
+		case thor.Address:
+			touch(key)
+		case codeKey:
+			touch(thor.Address(key))
+		case storageKey:
+			touch(key.addr)[key.key] = struct{}{}
+		case storageBarrierKey:
+			touch(thor.Address(key))
+		}
+		entries = append(entries, k, v)
+		return true
+	})
+
+	// getBefore returns the value at the last call.
+	getBefore := func(key any) (any, error) {
+		if v, ok := t.values[key]; ok {
+			return v, nil
+		}
+		v, _, err := s.cacheGetter(key)
+		return v, err
+	}
+	// getAfter returns the current value.
+	getAfter := func(key any) (any, error) {
+		v, _, err := s.sm.Get(key)
+		return v, err
+	}
+
+	snapshot := func(addr thor.Address, keys map[thor.Bytes32]struct{}, get func(any) (any, error)) (snap AccountSnapshot, err error) {
+		acc, err := get(addr)
+		if err != nil {
+			return
+		}
+		code, err := get(codeKey(addr))
+		if err != nil {
+			return
+		}
+		barrier, err := get(storageBarrierKey(addr))
+		if err != nil {
+			return
+		}
+		snap.Balance = acc.(*Account).Balance
+		snap.Energy = acc.(*Account).CalcEnergy(blockTime)
+		snap.Code = code.([]byte)
+		if len(keys) > 0 {
+			snap.Storage = make(map[thor.Bytes32]thor.Bytes32, len(keys))
+			for key := range keys {
+				raw, err := get(storageKey{addr, barrier.(int), key})
+				if err != nil {
+					return snap, err
+				}
+				if snap.Storage[key], err = decodeStorageValue(raw.(rlp.RawValue)); err != nil {
+					return snap, err
+				}
+			}
+		}
+		return
+	}
+
+	changes := make(map[thor.Address]*AccountChange, len(touched))
+	for addr, keys := range touched {
+		cleared, truncated, err := t.clearedKeys(ctx, addr, keys)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, &Error{err}
+		}
+		pre, err := snapshot(addr, keys, getBefore)
+		if err != nil {
+			return nil, &Error{err}
+		}
+		post, err := snapshot(addr, keys, getAfter)
+		if err != nil {
+			return nil, &Error{err}
+		}
+		// slots already empty are not cleared
+		for key := range cleared {
+			if pre.Storage[key].IsZero() {
+				delete(pre.Storage, key)
+				delete(post.Storage, key)
+			}
+		}
+		changes[addr] = &AccountChange{Pre: pre, Post: post, StorageCleared: truncated}
+	}
+
+	for i := 0; i < len(entries); i += 2 {
+		t.values[entries[i]] = entries[i+1]
+	}
+	t.offset += len(entries) / 2
+	return changes, nil
+}
+
+// clearedKeys adds keys of storage slots possibly cleared since the last call, if the account is deleted,
+// and returns the keys added. They are slots in the storage trie and slots written in inspected journal entries,
+// visible with the barrier at the last call.
+// Slots in the storage trie are skipped and truncated is set if there are more than MaxClearedKeys.
+func (t *ChangeTracker) clearedKeys(ctx context.Context, addr thor.Address, keys map[thor.Bytes32]struct{}) (cleared map[thor.Bytes32]struct{}, truncated bool, err error) {
+	var barrier int
+	if v, ok := t.values[storageBarrierKey(addr)]; ok {
+		barrier = v.(int)
+	}
+	if v, _, err := t.s.sm.Get(storageBarrierKey(addr)); err != nil {
+		return nil, false, err
+	} else if v.(int) == barrier {
+		return nil, false, nil
+	}
+
+	cleared = make(map[thor.Bytes32]struct{})
+	add := func(key thor.Bytes32) {
+		if _, ok := keys[key]; !ok {
+			keys[key] = struct{}{}
+			cleared[key] = struct{}{}
+		}
+	}
+	for k := range t.values {
+		if sk, ok := k.(storageKey); ok && sk.addr == addr && sk.barrier == barrier {
+			add(sk.key)
+		}
+	}
+	if barrier != 0 {
+		return cleared, false, nil
+	}
+	// slots in the storage trie are visible until the account is deleted the first time
+	obj, err := t.s.getCachedObject(addr)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(obj.data.StorageRoot) == 0 {
+		return cleared, false, nil
+	}
+	storageTrie := t.s.db.NewTrie(
+		StorageTrieName(obj.meta.StorageID),
+		trie.Root{
+			Hash: thor.BytesToBytes32(obj.data.StorageRoot),
+			Ver: trie.Version{
+				Major: obj.meta.StorageMajorVer,
+				Minor: obj.meta.StorageMinorVer,
+			},
+		},
+	)
+	var (
+		trieKeys []thor.Bytes32
+		it       = trie.NewIterator(storageTrie.NodeIterator(nil, 0))
+	)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		if len(trieKeys) == MaxClearedKeys {
+			return cleared, true, nil
+		}
+		// the meta of storage trie leaf is the preimage of the key
+		trieKeys = append(trieKeys, thor.BytesToBytes32(it.Meta))
+	}
+	if it.Err != nil {
+		return nil, false, it.Err
+	}
+	for _, key := range trieKeys {
+		add(key)
+	}
+	return cleared, false, nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestChanges(t *testing.T) {
+	db := muxdb.NewMem()
+	addr1 := thor.BytesToAddress([]byte("account1"))
+	addr2 := thor.BytesToAddress([]byte("account2"))
+	key1 := thor.BytesToBytes32([]byte("key1"))
+	key2 := thor.BytesToBytes32([]byte("key2"))
+
+	// committed base state
+	base := New(db, trie.Root{})
+	base.SetBalance(addr1, big.NewInt(100))
+	base.SetStorage(addr1, key1, thor.BytesToBytes32([]byte("v1")))
+	stage, err := base.Stage(trie.Version{Major: 1})
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	st := New(db, trie.Root{Hash: root, Ver: trie.Version{Major: 1}})
+
+	// changes made before the tracker created
+	st.SetBalance(addr1, big.NewInt(200))
+	tracker := st.NewChangeTracker()
+	assert.Equal(t, 1, tracker.offset)
+
+	changes, err := tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+
+	st.SetBalance(addr1, big.NewInt(300))
+	st.SetStorage(addr1, key1, thor.BytesToBytes32([]byte("v2")))
+	st.SetStorage(addr1, key2, thor.BytesToBytes32([]byte("v3")))
+	st.SetCode(addr2, []byte("code"))
+
+	// reverted changes are excluded
+	cp := st.NewCheckpoint()
+	st.SetEnergy(addr2, big.NewInt(1), 0)
+	st.RevertTo(cp)
+
+	changes, err = tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(changes))
+
+	c1 := changes[addr1]
+	assert.Equal(t, big.NewInt(200), c1.Pre.Balance)
+	assert.Equal(t, big.NewInt(300), c1.Post.Balance)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{
+		key1: thor.BytesToBytes32([]byte("v1")),
+		key2: {},
+	}, c1.Pre.Storage)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{
+		key1: thor.BytesToBytes32([]byte("v2")),
+		key2: thor.BytesToBytes32([]byte("v3")),
+	}, c1.Post.Storage)
+
+	c2 := changes[addr2]
+	assert.Nil(t, c2.Pre.Code)
+	assert.Equal(t, []byte("code"), c2.Post.Code)
+	assert.Equal(t, &big.Int{}, c2.Post.Energy)
+	assert.Nil(t, c2.Post.Storage)
+
+	// storage is wiped when account deleted
+	st.Delete(addr1)
+	changes, err = tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(300), changes[addr1].Pre.Balance)
+	assert.Equal(t, &big.Int{}, changes[addr1].Post.Balance)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{
+		key1: thor.BytesToBytes32([]byte("v2")),
+		key2: thor.BytesToBytes32([]byte("v3")),
+	}, changes[addr1].Pre.Storage)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{key1: {}, key2: {}}, changes[addr1].Post.Storage)
+
+	// recreated and deleted again, the storage trie is no longer visible
+	st.SetStorage(addr1, key2, thor.BytesToBytes32([]byte("v4")))
+	changes, err = tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{key2: thor.BytesToBytes32([]byte("v4"))}, changes[addr1].Post.Storage)
+
+	st.Delete(addr1)
+	changes, err = tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{key2: thor.BytesToBytes32([]byte("v4"))}, changes[addr1].Pre.Storage)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{key2: {}}, changes[addr1].Post.Storage)
+
+	changes, err = tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestChangesOfClearedStorage(t *testing.T) {
+	db := muxdb.NewMem()
+	addr := thor.BytesToAddress([]byte("account"))
+	key := thor.BytesToBytes32([]byte("key"))
+
+	newState := func(slots int) *State {
+		base := New(db, trie.Root{})
+		base.SetBalance(addr, big.NewInt(1))
+		for i := range slots {
+			base.SetStorage(addr, thor.BytesToBytes32(big.NewInt(int64(i+1)).Bytes()), thor.BytesToBytes32([]byte("v")))
+		}
+		stage, err := base.Stage(trie.Version{Major: 1})
+		assert.Nil(t, err)
+		root, err := stage.Commit()
+		assert.Nil(t, err)
+		return New(db, trie.Root{Hash: root, Ver: trie.Version{Major: 1}})
+	}
+
+	// all slots listed up to the limit
+	st := newState(MaxClearedKeys)
+	tracker := st.NewChangeTracker()
+	st.Delete(addr)
+	changes, err := tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.False(t, changes[addr].StorageCleared)
+	assert.Equal(t, MaxClearedKeys, len(changes[addr].Post.Storage))
+
+	// slots in the storage trie omitted beyond the limit, except written ones
+	st = newState(MaxClearedKeys + 1)
+	tracker = st.NewChangeTracker()
+	st.SetStorage(addr, key, thor.BytesToBytes32([]byte("v")))
+	st.Delete(addr)
+	changes, err = tracker.Changes(context.Background(), 0)
+	assert.Nil(t, err)
+	assert.True(t, changes[addr].StorageCleared)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{key: {}}, changes[addr].Pre.Storage)
+	assert.Equal(t, map[thor.Bytes32]thor.Bytes32{key: {}}, changes[addr].Post.Storage)
+
+	// canceled while iterating the storage trie
+	st = newState(2)
+	tracker = st.NewChangeTracker()
+	st.Delete(addr)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tracker.Changes(ctx, 0)
+	assert.Equal(t, context.Canceled, err)
+}
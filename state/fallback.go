@@ -0,0 +1,65 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Fallback provides accounts and storage absent in the local tries. It's typically the
+// state of a remote chain, which the local chain is forked from.
+//
+// An account is read from the fallback only if it's not found in the local accounts trie.
+// Once it's written back, the local copy shadows the fallback one, even if it becomes empty.
+// Storage of such account keeps falling back key by key until the account is deleted.
+type Fallback interface {
+	// GetAccount returns the account and its code, or nil account if it does not exist.
+	// The code hash and storage root of the returned account are ignored.
+	GetAccount(addr thor.Address) (*Account, []byte, error)
+	// GetRawStorage returns the storage value in rlp raw.
+	GetRawStorage(addr thor.Address, key thor.Bytes32) (rlp.RawValue, error)
+}
+
+// storageTombstone is saved in place of a deleted storage value of a forked account, to shadow
+// the value in the fallback. It's the rlp of empty string, so that it reads as zero by anyone
+// unaware of the fallback, and it's told from real values by the leaf metadata, see storageTombstoneMeta.
+var storageTombstone = rlp.RawValue{0x80}
+
+// storageTombstoneMeta returns the leaf metadata of the tombstone, which is the full key prefixed by 0xff.
+// Metadata of real values are trimmed keys, which are never longer than 32 bytes. The key is still the
+// trailing 32 bytes, as read by storage iterators.
+func storageTombstoneMeta(key thor.Bytes32) []byte {
+	return append([]byte{0xff}, key[:]...)
+}
+
+func isStorageTombstoneMeta(meta []byte) bool {
+	return len(meta) == 1+len(thor.Bytes32{})
+}
+
+// loadFallbackAccount loads the account from the fallback, and saves its code into the code store.
+func (s *State) loadFallbackAccount(addr thor.Address) (*Account, *AccountMetadata, error) {
+	a, code, err := s.fallback.GetAccount(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a == nil {
+		return emptyAccount(), &AccountMetadata{}, nil
+	}
+
+	cpy := *a
+	cpy.CodeHash, cpy.StorageRoot = nil, nil
+	if len(code) > 0 {
+		codeHash := thor.Keccak256(code).Bytes()
+		// code is content addressed, it's safe to save it in advance
+		if err := s.db.NewStore(CodeStoreName).Put(codeHash, code); err != nil {
+			return nil, nil, err
+		}
+		codeCache.Add(string(codeHash), code)
+		cpy.CodeHash = codeHash
+	}
+	return &cpy, &AccountMetadata{Forked: true}, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+type mapFallback struct {
+	accounts map[thor.Address]*Account
+	codes    map[thor.Address][]byte
+	storage  map[thor.Address]map[thor.Bytes32]rlp.RawValue
+	reads    int
+}
+
+func (f *mapFallback) GetAccount(addr thor.Address) (*Account, []byte, error) {
+	f.reads++
+	return f.accounts[addr], f.codes[addr], nil
+}
+
+func (f *mapFallback) GetRawStorage(addr thor.Address, key thor.Bytes32) (rlp.RawValue, error) {
+	f.reads++
+	return f.storage[addr][key], nil
+}
+
+func TestFallback(t *testing.T) {
+	var (
+		addr1 = thor.BytesToAddress([]byte("addr1"))
+		addr2 = thor.BytesToAddress([]byte("addr2"))
+		addr3 = thor.BytesToAddress([]byte("addr3"))
+		key1  = thor.BytesToBytes32([]byte("key1"))
+		key2  = thor.BytesToBytes32([]byte("key2"))
+		key3  = thor.BytesToBytes32([]byte("key3"))
+		v1, _ = rlp.EncodeToBytes([]byte("v1"))
+		v2, _ = rlp.EncodeToBytes([]byte("v2"))
+	)
+
+	fb := &mapFallback{
+		accounts: map[thor.Address]*Account{
+			addr1: {Balance: big.NewInt(100), Energy: big.NewInt(10), BlockTime: 1, CodeHash: []byte("ignored")},
+			addr2: {Balance: big.NewInt(200), Energy: &big.Int{}},
+		},
+		codes: map[thor.Address][]byte{addr1: []byte("code")},
+		storage: map[thor.Address]map[thor.Bytes32]rlp.RawValue{
+			addr1: {key1: v1, key2: v2},
+			addr2: {key1: v1},
+		},
+	}
+	db := muxdb.NewMem()
+	stater := NewStaterWithFallback(db, fb)
+
+	st := stater.NewState(trie.Root{})
+	assert.Equal(t, M(big.NewInt(100), nil), M(st.GetBalance(addr1)))
+	assert.Equal(t, M([]byte("code"), nil), M(st.GetCode(addr1)))
+	assert.Equal(t, M(thor.Keccak256([]byte("code")), nil), M(st.GetCodeHash(addr1)))
+	assert.Equal(t, M(rlp.RawValue(v1), nil), M(st.GetRawStorage(addr1, key1)))
+	assert.Equal(t, M(false, nil), M(st.Exists(addr3)))
+
+	// modify forked accounts
+	st.SetBalance(addr1, big.NewInt(101))
+	st.SetStorage(addr1, key1, thor.Bytes32{})
+	st.SetStorage(addr1, key3, thor.BytesToBytes32([]byte("v3")))
+	st.SetBalance(addr2, &big.Int{})
+	st.Delete(addr2)
+
+	stage, err := st.Stage(trie.Version{Major: 1})
+	assert.Nil(t, err)
+	root, err := stage.Commit()
+	assert.Nil(t, err)
+
+	st = stater.NewState(trie.Root{Hash: root, Ver: trie.Version{Major: 1}})
+	// local values shadow the fallback
+	assert.Equal(t, M(big.NewInt(101), nil), M(st.GetBalance(addr1)))
+	assert.Equal(t, M(rlp.RawValue(nil), nil), M(st.GetRawStorage(addr1, key1)))
+	assert.Equal(t, M(thor.BytesToBytes32([]byte("v3")), nil), M(st.GetStorage(addr1, key3)))
+	// untouched storage still falls back
+	assert.Equal(t, M(rlp.RawValue(v2), nil), M(st.GetRawStorage(addr1, key2)))
+	// code was saved locally
+	assert.Equal(t, M([]byte("code"), nil), M(st.GetCode(addr1)))
+	// deleted account and its storage are not read from the fallback
+	reads := fb.reads
+	assert.Equal(t, M(false, nil), M(st.Exists(addr2)))
+	assert.Equal(t, M(rlp.RawValue(nil), nil), M(st.GetRawStorage(addr2, key1)))
+	assert.Equal(t, reads, fb.reads)
+
+	// the same root without fallback sees local values only
+	st = New(db, trie.Root{Hash: root, Ver: trie.Version{Major: 1}})
+	assert.Equal(t, M(big.NewInt(101), nil), M(st.GetBalance(addr1)))
+	assert.Equal(t, M(rlp.RawValue(nil), nil), M(st.GetRawStorage(addr1, key2)))
+	// and the tombstone reads as zero
+	assert.Equal(t, M(thor.Bytes32{}, nil), M(st.GetStorage(addr1, key1)))
+	storageTrie, err := st.BuildStorageTrie(addr1)
+	assert.Nil(t, err)
+	it := trie.NewIterator(storageTrie.NodeIterator(nil, 0))
+	for it.Next() {
+		_, _, _, err := rlp.Split(it.Value)
+		assert.Nil(t, err, "values in the trie are canonical rlp")
+	}
+	assert.Nil(t, it.Err)
+}
+
+func TestAccountMetadataRLP(t *testing.T) {
+	meta := AccountMetadata{StorageID: []byte("sid"), StorageMajorVer: 1, StorageMinorVer: 2}
+
+	// encoding of ordinary metadata is unchanged
+	data, err := rlp.EncodeToBytes(&meta)
+	assert.Nil(t, err)
+	legacy, _ := rlp.EncodeToBytes([]any{meta.StorageID, meta.StorageMajorVer, meta.StorageMinorVer})
+	assert.Equal(t, legacy, data)
+
+	meta.Forked = true
+	data, err = rlp.EncodeToBytes(&meta)
+	assert.Nil(t, err)
+
+	var decoded AccountMetadata
+	assert.Nil(t, rlp.DecodeBytes(data, &decoded))
+	assert.Equal(t, meta, decoded)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+// historyReader reads accounts and storage of a state from the history index.
+type historyReader struct {
+	h       *muxdb.History
+	ver     trie.Version                     // version of the state
+	inChain func(trie.Version) (bool, error) // reports whether the version is in the chain of the state
+}
+
+// getStorage returns the storage value of the account, whose storage trie is identified by storageID.
+// It returns false if the value should be read from the trie.
+func (r *historyReader) getStorage(addr thor.Address, storageID []byte, key thor.Bytes32) (v rlp.RawValue, deleted, found bool, err error) {
+	id, v, meta, found, err := r.h.GetStorage(addr, key, r.ver, r.inChain)
+	if err != nil || !found {
+		return nil, false, false, err
+	}
+	// the storage trie is replaced since the change, and the key is never written since
+	if !bytes.Equal(id, storageID) {
+		return nil, false, true, nil
+	}
+	if isStorageTombstoneMeta(meta) {
+		return nil, true, true, nil
+	}
+	return v, false, true, nil
+}
+
+// findAccount finds the account in the history index if any, otherwise in the accounts trie.
+func (s *State) findAccount(addr thor.Address) (*Account, *AccountMetadata, bool, error) {
+	if s.history != nil {
+		data, meta, found, err := s.history.h.GetAccount(addr, s.history.ver, s.history.inChain)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if found {
+			if len(data) == 0 {
+				// deleted
+				return nil, nil, false, nil
+			}
+			a, am, err := decodeAccount(data, meta)
+			if err != nil {
+				return nil, nil, false, err
+			}
+			return a, am, true, nil
+		}
+	}
+	return findAccount(s.trie, addr)
+}
+
+// NewHistoricalState creates a state object like NewState, which reads accounts and storage from
+// the history index of the database if enabled, rather than walking the tries.
+// inChain reports whether the block of the version is in the chain of the state.
+func (s *Stater) NewHistoricalState(root trie.Root, inChain func(trie.Version) (bool, error)) *State {
+	st := s.NewState(root)
+	if h := s.db.History(); h != nil {
+		st.history = &historyReader{h: h, ver: root.Ver, inChain: inChain}
+	}
+	return st
+}
+
+// HistorySince returns the number of the first block indexed by the history index.
+// It returns false if the index is not enabled.
+func (s *Stater) HistorySince() (uint32, bool) {
+	if h := s.db.History(); h != nil {
+		return h.Since(), true
+	}
+	return 0, false
+}
+
+// AccountRecord is the account after a change made in a block, recorded by the history index.
+type AccountRecord struct {
+	Version trie.Version // version of the block, i.e. (number, conflicts)
+	Account *Account     // empty if the account is deleted
+}
+
+// AccountHistory returns changes of the account made in blocks numbered within [from, to], in ascending order,
+// and at most limit changes. inChain reports whether the block of the version is in the chain to query.
+// It requires the history index, and changes of blocks before HistorySince are not available.
+func (s *Stater) AccountHistory(addr thor.Address, from, to uint32, limit int, inChain func(trie.Version) (bool, error)) ([]*AccountRecord, error) {
+	h := s.db.History()
+	if h == nil {
+		return nil, errors.New("history index not enabled")
+	}
+	var changes []*AccountRecord
+	if limit <= 0 {
+		return changes, nil
+	}
+	err := h.IterateAccount(addr, from, to, func(ver trie.Version, data, meta []byte) (bool, error) {
+		if in, err := inChain(ver); err != nil || !in {
+			return err == nil, err
+		}
+		a := emptyAccount()
+		if len(data) > 0 {
+			var err error
+			if a, _, err = decodeAccount(data, meta); err != nil {
+				return false, err
+			}
+		}
+		changes = append(changes, &AccountRecord{Version: ver, Account: a})
+		return len(changes) < limit, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
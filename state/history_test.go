@@ -0,0 +1,112 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vechain/thor/v2/muxdb"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/trie"
+)
+
+func TestHistoricalState(t *testing.T) {
+	db := muxdb.NewMem()
+	_, err := db.EnableHistory(1)
+	assert.Nil(t, err)
+	stater := NewStater(db)
+
+	var (
+		addr1 = thor.BytesToAddress([]byte("addr1"))
+		addr2 = thor.BytesToAddress([]byte("addr2"))
+		key1  = thor.BytesToBytes32([]byte("key1"))
+		key2  = thor.BytesToBytes32([]byte("key2"))
+	)
+	blocks := []func(st *State){
+		// block 1
+		func(st *State) {
+			st.SetBalance(addr1, big.NewInt(1))
+			st.SetCode(addr1, []byte("code"))
+			st.SetStorage(addr1, key1, thor.BytesToBytes32([]byte("v1")))
+			st.SetStorage(addr1, key2, thor.BytesToBytes32([]byte("v2")))
+		},
+		// block 2
+		func(st *State) {
+			st.SetBalance(addr2, big.NewInt(2))
+			st.SetStorage(addr1, key1, thor.BytesToBytes32([]byte("v1.2")))
+		},
+		// block 3, recreated with new storage
+		func(st *State) {
+			st.Delete(addr1)
+			st.SetBalance(addr1, big.NewInt(3))
+			st.SetStorage(addr1, key2, thor.BytesToBytes32([]byte("v2.3")))
+		},
+		// block 4
+		func(st *State) {
+			st.SetEnergy(addr2, big.NewInt(4), 100)
+		},
+		// block 5
+		func(st *State) {
+			st.Delete(addr1)
+			st.Delete(addr2)
+		},
+	}
+
+	roots := []trie.Root{{}}
+	for i, fn := range blocks {
+		st := stater.NewState(roots[i])
+		fn(st)
+		ver := trie.Version{Major: uint32(i + 1)}
+		stage, err := st.Stage(ver)
+		assert.Nil(t, err)
+		root, err := stage.Commit()
+		assert.Nil(t, err)
+		roots = append(roots, trie.Root{Hash: root, Ver: ver})
+	}
+
+	inChain := func(ver trie.Version) (bool, error) { return ver.Minor == 0, nil }
+	for _, root := range roots[1:] {
+		expected := stater.NewState(root)
+		st := stater.NewHistoricalState(root, inChain)
+		assert.NotNil(t, st.history)
+		for _, addr := range []thor.Address{addr1, addr2} {
+			assert.Equal(t, M(expected.GetBalance(addr)), M(st.GetBalance(addr)), "block %v", root.Ver.Major)
+			assert.Equal(t, M(expected.GetEnergy(addr, 200)), M(st.GetEnergy(addr, 200)), "block %v", root.Ver.Major)
+			assert.Equal(t, M(expected.GetCode(addr)), M(st.GetCode(addr)), "block %v", root.Ver.Major)
+			for _, key := range []thor.Bytes32{key1, key2} {
+				assert.Equal(t, M(expected.GetStorage(addr, key)), M(st.GetStorage(addr, key)), "block %v", root.Ver.Major)
+			}
+		}
+	}
+
+	// read from the index without the trie
+	st := stater.NewHistoricalState(trie.Root{Hash: thor.Bytes32{1}, Ver: trie.Version{Major: 3}}, inChain)
+	assert.Equal(t, M(big.NewInt(3), nil), M(st.GetBalance(addr1)))
+	assert.Equal(t, M(thor.BytesToBytes32([]byte("v2.3")), nil), M(st.GetStorage(addr1, key2)))
+	assert.Equal(t, M(thor.Bytes32{}, nil), M(st.GetStorage(addr1, key1)))
+
+	since, ok := stater.HistorySince()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), since)
+
+	records, err := stater.AccountHistory(addr1, 2, 5, 10, inChain)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, trie.Version{Major: 2}, records[0].Version)
+	assert.Equal(t, big.NewInt(1), records[0].Account.Balance)
+	assert.Equal(t, big.NewInt(3), records[1].Account.Balance)
+	assert.True(t, records[2].Account.IsEmpty())
+
+	records, err = stater.AccountHistory(addr1, 0, 5, 1, inChain)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, trie.Version{Major: 1}, records[0].Version)
+
+	_, err = NewStater(muxdb.NewMem()).AccountHistory(addr1, 0, 5, 1, inChain)
+	assert.Error(t, err)
+}
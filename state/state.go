@@ -23,7 +23,8 @@ const (
 	AccountTrieName       = "a"
 	StorageTrieNamePrefix = "s"
 
-	codeStoreName = "state.code"
+	// CodeStoreName is the name of the store which saves contract codes keyed by code hash.
+	CodeStoreName = "state.code"
 )
 
 // StorageTrieName converts the storage id into the name of storage trie.
@@ -44,20 +45,34 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("state: %v", e.cause)
 }
 
+// Unwrap returns the underlying cause of the error.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
 // State manages the world state.
 type State struct {
-	db    *muxdb.MuxDB
-	trie  *muxdb.Trie                    // the accounts trie reader
-	cache map[thor.Address]*cachedObject // cache of accounts trie
-	sm    *stackedmap.StackedMap         // keeps revisions of accounts state
+	db       *muxdb.MuxDB
+	trie     *muxdb.Trie                    // the accounts trie reader
+	cache    map[thor.Address]*cachedObject // cache of accounts trie
+	sm       *stackedmap.StackedMap         // keeps revisions of accounts state
+	fallback Fallback                       // optional, provides accounts absent in the trie
+	history  *historyReader                 // optional, reads accounts and storage from the history index
 }
 
 // New create state object.
 func New(db *muxdb.MuxDB, root trie.Root) *State {
+	return NewWithFallback(db, root, nil)
+}
+
+// NewWithFallback create state object, which reads accounts and storage absent in
+// the local tries from the fallback.
+func NewWithFallback(db *muxdb.MuxDB, root trie.Root, fallback Fallback) *State {
 	state := State{
-		db:    db,
-		trie:  db.NewTrie(AccountTrieName, root),
-		cache: make(map[thor.Address]*cachedObject),
+		db:       db,
+		trie:     db.NewTrie(AccountTrieName, root),
+		cache:    make(map[thor.Address]*cachedObject),
+		fallback: fallback,
 	}
 
 	state.sm = stackedmap.New(func(key any) (any, bool, error) {
@@ -68,7 +83,7 @@ func New(db *muxdb.MuxDB, root trie.Root) *State {
 
 // Checkout checkouts to another state.
 func (s *State) Checkout(root trie.Root) *State {
-	return New(s.db, root)
+	return NewWithFallback(s.db, root, s.fallback)
 }
 
 // cacheGetter implements stackedmap.MapGetter.
@@ -116,11 +131,24 @@ func (s *State) getCachedObject(addr thor.Address) (*cachedObject, error) {
 	if co, ok := s.cache[addr]; ok {
 		return co, nil
 	}
-	a, am, err := loadAccount(s.trie, addr)
+	a, am, found, err := s.findAccount(addr)
 	if err != nil {
 		return nil, err
 	}
+	if !found {
+		if s.fallback != nil {
+			if a, am, err = s.loadFallbackAccount(addr); err != nil {
+				return nil, err
+			}
+		} else {
+			a, am = emptyAccount(), &AccountMetadata{}
+		}
+	}
 	co := newCachedObject(s.db, addr, a, am)
+	if am.Forked {
+		co.fallback = s.fallback
+	}
+	co.history = s.history
 	s.cache[addr] = co
 	return co, nil
 }
@@ -227,12 +255,21 @@ func (s *State) GetStorage(addr thor.Address, key thor.Bytes32) (thor.Bytes32, e
 	if err != nil {
 		return thor.Bytes32{}, &Error{err}
 	}
+	v, err := decodeStorageValue(raw)
+	if err != nil {
+		return thor.Bytes32{}, &Error{err}
+	}
+	return v, nil
+}
+
+// decodeStorageValue decodes the rlp raw storage value into bytes32.
+func decodeStorageValue(raw rlp.RawValue) (thor.Bytes32, error) {
 	if len(raw) == 0 {
 		return thor.Bytes32{}, nil
 	}
 	kind, content, _, err := rlp.Split(raw)
 	if err != nil {
-		return thor.Bytes32{}, &Error{err}
+		return thor.Bytes32{}, err
 	}
 	if kind == rlp.List {
 		// special case for rlp list, it should be customized storage value
@@ -416,7 +453,11 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 		codes   = make(map[thor.Bytes32][]byte)
 
 		storageTrieCreationCount uint64
+		historyBatch             *muxdb.HistoryBatch
 	)
+	if h := s.db.History(); h != nil {
+		historyBatch = h.NewBatch(newVer)
+	}
 
 	// get or create changed account
 	getChanged := func(addr thor.Address) (*changed, error) {
@@ -501,9 +542,20 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 						})
 				}
 				for k, v := range c.storage {
-					if err := saveStorage(sTrie, k, v); err != nil {
+					var meta []byte
+					if len(v) == 0 && c.meta.Forked {
+						v, meta = storageTombstone, storageTombstoneMeta(k)
+						if err := saveStorageTombstone(sTrie, k); err != nil {
+							return nil, &Error{err}
+						}
+					} else if err := saveStorage(sTrie, k, v); err != nil {
 						return nil, &Error{err}
 					}
+					if historyBatch != nil {
+						if err := historyBatch.PutStorage(addr, k, c.meta.StorageID, v, meta); err != nil {
+							return nil, &Error{err}
+						}
+					}
 				}
 				sRoot := sTrie.Hash()
 				c.data.StorageRoot = sRoot[:]
@@ -512,9 +564,18 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 				tries = append(tries, sTrie)
 			}
 		}
-		if err := saveAccount(trieCpy, addr, &c.data, &c.meta); err != nil {
+		if err := saveAccount(trieCpy, addr, &c.data, &c.meta, s.fallback != nil); err != nil {
 			return nil, &Error{err}
 		}
+		if historyBatch != nil {
+			data, mdata, err := encodeAccount(&c.data, &c.meta, s.fallback != nil)
+			if err != nil {
+				return nil, &Error{err}
+			}
+			if err := historyBatch.PutAccount(addr, data, mdata); err != nil {
+				return nil, &Error{err}
+			}
+		}
 	}
 	root := trieCpy.Hash()
 	tries = append(tries, trieCpy)
@@ -523,7 +584,7 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 		root: root,
 		commit: func() error {
 			if len(codes) > 0 {
-				bulk := s.db.NewStore(codeStoreName).Bulk()
+				bulk := s.db.NewStore(CodeStoreName).Bulk()
 				for hash, code := range codes {
 					if err := bulk.Put(hash[:], code); err != nil {
 						return err
@@ -538,6 +599,11 @@ func (s *State) Stage(newVer trie.Version) (*Stage, error) {
 					return err
 				}
 			}
+			if historyBatch != nil {
+				if err := historyBatch.Write(); err != nil {
+					return err
+				}
+			}
 			// Just once for the account trie.
 			metricAccountChanges().Add(int64(len(changes)))
 			return nil
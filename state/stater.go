@@ -12,15 +12,21 @@ import (
 
 // Stater is the state creator.
 type Stater struct {
-	db *muxdb.MuxDB
+	db       *muxdb.MuxDB
+	fallback Fallback
 }
 
 // NewStater create a new stater.
 func NewStater(db *muxdb.MuxDB) *Stater {
-	return &Stater{db}
+	return &Stater{db, nil}
+}
+
+// NewStaterWithFallback create a new stater, whose states read absent accounts from the fallback.
+func NewStaterWithFallback(db *muxdb.MuxDB, fallback Fallback) *Stater {
+	return &Stater{db, fallback}
 }
 
 // NewState create a new state object.
 func (s *Stater) NewState(root trie.Root) *State {
-	return New(s.db, root)
+	return NewWithFallback(s.db, root, s.fallback)
 }
@@ -161,7 +161,7 @@ func (c *Chain) MintBlock(account genesis.DevAccount, transactions ...*tx.Transa
 	// Create a new block
 	blkFlow, err := blkPacker.Mock(
 		c.Repo().BestBlockSummary(),
-		c.Repo().BestBlockSummary().Header.Timestamp()+thor.BlockInterval,
+		c.Repo().BestBlockSummary().Header.Timestamp()+c.forkConfig.BlockInterval(),
 		c.Repo().BestBlockSummary().Header.GasLimit(),
 	)
 	if err != nil {
@@ -6,8 +6,12 @@
 package thor
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"sort"
 	"strings"
 )
 
@@ -20,6 +24,121 @@ type ForkConfig struct {
 	ETH_IST   uint32
 	VIP214    uint32
 	FINALITY  uint32
+
+	// Params schedules changes of the builtin params, only custom networks may have it.
+	Params *ParamsSchedule `json:"-"`
+	// Timing of the consensus, only custom networks may change it.
+	Timing Timing `json:"-"`
+	// Authority schedules changes of authority nodes, only permissioned networks may have it.
+	Authority AuthoritySchedule `json:"-"`
+}
+
+// AuthorityChanges are the changes of authority nodes at a block. The nodes are revoked before the new ones added.
+type AuthorityChanges struct {
+	Revoke []Address
+	Add    []AuthorityNode
+}
+
+// AuthorityNode is an authority node to be added.
+type AuthorityNode struct {
+	Master   Address
+	Endorsor Address
+	Identity Bytes32
+}
+
+// AuthoritySchedule provides the authority changes scheduled at block numbers, which are applied
+// at the beginning of the block, before any tx is executed.
+type AuthoritySchedule interface {
+	AuthorityChanges(blockNum uint32) *AuthorityChanges
+}
+
+// Timing is the timing of the consensus, the zero values are taken as the defaults.
+type Timing struct {
+	BlockInterval      uint64 `json:"blockInterval,omitempty"`      // seconds between two consecutive blocks
+	CheckpointInterval uint32 `json:"checkpointInterval,omitempty"` // blocks between two bft checkpoints
+	EpochLength        uint32 `json:"epochLength,omitempty"`        // blocks between two seeder epochs
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Omitted fields take the defaults, but explicit zeros are rejected.
+func (t *Timing) UnmarshalJSON(data []byte) error {
+	var v struct {
+		BlockInterval      *uint64 `json:"blockInterval"`
+		CheckpointInterval *uint32 `json:"checkpointInterval"`
+		EpochLength        *uint32 `json:"epochLength"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*t = Timing{}
+	if v.BlockInterval != nil {
+		if *v.BlockInterval == 0 {
+			return errors.New("blockInterval: should be positive")
+		}
+		t.BlockInterval = *v.BlockInterval
+	}
+	if v.CheckpointInterval != nil {
+		if *v.CheckpointInterval == 0 {
+			return errors.New("checkpointInterval: should be positive")
+		}
+		t.CheckpointInterval = *v.CheckpointInterval
+	}
+	if v.EpochLength != nil {
+		if *v.EpochLength == 0 {
+			return errors.New("epochLength: should be positive")
+		}
+		t.EpochLength = *v.EpochLength
+	}
+	return nil
+}
+
+// AuthorityChanges returns the authority changes scheduled at the given block number, nil if nothing scheduled.
+func (fc ForkConfig) AuthorityChanges(blockNum uint32) *AuthorityChanges {
+	if fc.Authority == nil {
+		return nil
+	}
+	return fc.Authority.AuthorityChanges(blockNum)
+}
+
+// BlockInterval returns the time interval between two consecutive blocks.
+func (fc ForkConfig) BlockInterval() uint64 {
+	if fc.Timing.BlockInterval == 0 {
+		return BlockInterval
+	}
+	return fc.Timing.BlockInterval
+}
+
+// CheckpointInterval returns the number of blocks between two bft checkpoints.
+func (fc ForkConfig) CheckpointInterval() uint32 {
+	if fc.Timing.CheckpointInterval == 0 {
+		return CheckpointInterval
+	}
+	return fc.Timing.CheckpointInterval
+}
+
+// EpochLength returns the number of blocks between two seeder epochs.
+func (fc ForkConfig) EpochLength() uint32 {
+	if fc.Timing.EpochLength == 0 {
+		return SeederInterval
+	}
+	return fc.Timing.EpochLength
+}
+
+// ParamsChange is a change of the builtin params.
+type ParamsChange struct {
+	Key   Bytes32
+	Value *big.Int
+}
+
+// ParamsSchedule maps block numbers to the params changes, which are applied at the beginning
+// of the block, before any tx is executed.
+type ParamsSchedule map[uint32][]ParamsChange
+
+// ParamsChanges returns the params changes scheduled at the given block number.
+func (fc ForkConfig) ParamsChanges(blockNum uint32) []ParamsChange {
+	if fc.Params == nil {
+		return nil
+	}
+	return (*fc.Params)[blockNum]
 }
 
 func (fc ForkConfig) String() string {
@@ -37,6 +156,17 @@ func (fc ForkConfig) String() string {
 	push("VIP214", fc.VIP214)
 	push("FINALITY", fc.FINALITY)
 
+	if fc.Params != nil {
+		nums := make([]uint32, 0, len(*fc.Params))
+		for num := range *fc.Params {
+			nums = append(nums, num)
+		}
+		sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+		for _, num := range nums {
+			push("PARAMS", num)
+		}
+	}
+
 	return strings.Join(strs, ", ")
 }
 
@@ -6,7 +6,9 @@
 package thor
 
 import (
+	"encoding/json"
 	"math"
+	"math/big"
 	"testing"
 )
 
@@ -27,6 +29,29 @@ func TestForkConfigString(t *testing.T) {
 	}
 }
 
+func TestForkConfigParams(t *testing.T) {
+	change := ParamsChange{Key: KeyBaseGasPrice, Value: big.NewInt(1)}
+	fc := NoFork
+	fc.Params = &ParamsSchedule{
+		200: {change},
+		100: {change},
+	}
+
+	expectedStr := "PARAMS: #100, PARAMS: #200"
+	if fc.String() != expectedStr {
+		t.Errorf("ForkConfig.String() = %v, want %v", fc.String(), expectedStr)
+	}
+	if changes := fc.ParamsChanges(100); len(changes) != 1 || changes[0] != change {
+		t.Errorf("ForkConfig.ParamsChanges(100) = %v, want %v", changes, []ParamsChange{change})
+	}
+	if changes := fc.ParamsChanges(150); len(changes) != 0 {
+		t.Errorf("ForkConfig.ParamsChanges(150) = %v, want none", changes)
+	}
+	if changes := NoFork.ParamsChanges(100); len(changes) != 0 {
+		t.Errorf("NoFork.ParamsChanges(100) = %v, want none", changes)
+	}
+}
+
 // TestNoFork verifies the NoFork variable is correctly set up.
 func TestNoFork(t *testing.T) {
 	if NoFork.VIP191 != math.MaxUint32 || NoFork.BLOCKLIST != math.MaxUint32 {
@@ -57,3 +82,23 @@ func TestGetForkConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestTimingUnmarshalJSON(t *testing.T) {
+	var timing Timing
+	if err := json.Unmarshal([]byte(`{"blockInterval": 3, "epochLength": 360}`), &timing); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Timing{BlockInterval: 3, EpochLength: 360}); timing != want {
+		t.Errorf("Timing = %v, want %v", timing, want)
+	}
+	fc := ForkConfig{Timing: timing}
+	if fc.BlockInterval() != 3 || fc.CheckpointInterval() != CheckpointInterval {
+		t.Errorf("ForkConfig timing = %v, %v, want 3, %v", fc.BlockInterval(), fc.CheckpointInterval(), CheckpointInterval)
+	}
+
+	for _, data := range []string{`{"blockInterval": 0}`, `{"checkpointInterval": 0}`, `{"epochLength": 0}`} {
+		if err := json.Unmarshal([]byte(data), &timing); err == nil {
+			t.Errorf("Timing.UnmarshalJSON(%s) should fail", data)
+		}
+	}
+}
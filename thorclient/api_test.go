@@ -34,7 +34,7 @@ import (
 	"github.com/vechain/thor/v2/txpool"
 
 	// Force-load the tracer native engines to trigger registration
-	_ "github.com/vechain/thor/v2/tracers/js"
+	"github.com/vechain/thor/v2/tracers/js"
 	_ "github.com/vechain/thor/v2/tracers/logger"
 )
 
@@ -56,7 +56,7 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 
 	router := mux.NewRouter()
 
-	accounts.New(thorChain.Repo(), thorChain.Stater(), uint64(gasLimit), thor.NoFork, thorChain.Engine(), true).
+	accounts.New(thorChain.Repo(), thorChain.Stater(), uint64(gasLimit), thor.NoFork, thorChain.Engine(), true, nil).
 		Mount(router, "/accounts")
 
 	mempool := txpool.New(thorChain.Repo(), thorChain.Stater(), txpool.Options{Limit: 10000, LimitPerAccount: 16, MaxLifetime: 10 * time.Minute})
@@ -64,7 +64,7 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 
 	blocks.New(thorChain.Repo(), thorChain.Engine()).Mount(router, "/blocks")
 
-	debug.New(thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), gasLimit, true, thorChain.Engine(), []string{"all"}, false).
+	debug.New(thorChain.Repo(), thorChain.Stater(), thorChain.GetForkConfig(), gasLimit, true, js.Limits{}, thorChain.Engine(), []string{"all"}, false, nil).
 		Mount(router, "/debug")
 
 	logDb, err := logdb.NewMem()
@@ -78,6 +78,9 @@ func initAPIServer(t *testing.T) (*testchain.Chain, *httptest.Server) {
 			LimitPerAccount: 16,
 			MaxLifetime:     10 * time.Minute,
 		}),
+		thorChain.Database(),
+		thorChain.Engine(),
+		thor.BlockInterval,
 	)
 	node.New(communicator).Mount(router, "/node")
 
@@ -122,6 +122,27 @@ func (c *Client) GetAccountStorage(addr *thor.Address, key *thor.Bytes32, revisi
 	return &res, nil
 }
 
+// GetAccountRawStorage retrieves the storage value for the given address and key at the specified revision,
+// along with the rlp raw value.
+func (c *Client) GetAccountRawStorage(addr *thor.Address, key *thor.Bytes32, revision string) (*accounts.GetStorageResult, error) {
+	url := c.url + "/accounts/" + addr.String() + "/storage/" + key.String() + "?raw=true"
+	if revision != "" {
+		url += "&revision=" + revision
+	}
+
+	body, err := c.httpGET(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve account raw storage - %w", err)
+	}
+
+	var res accounts.GetStorageResult
+	if err = json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal storage result - %w", err)
+	}
+
+	return &res, nil
+}
+
 // GetTransaction retrieves the transaction details by the transaction ID, along with options for head and pending status.
 func (c *Client) GetTransaction(txID *thor.Bytes32, head string, isPending bool) (*transactions.Transaction, error) {
 	url := c.url + "/transactions/" + txID.String() + "?"
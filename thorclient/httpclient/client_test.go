@@ -213,6 +213,30 @@ func TestClient_GetStorage(t *testing.T) {
 	assert.Equal(t, expectedStorageRsp.Value, data.Value)
 }
 
+func TestClient_GetRawStorage(t *testing.T) {
+	addr := thor.Address{0x01}
+	key := thor.Bytes32{0x01}
+	expectedStorageRsp := &accounts.GetStorageResult{Value: thor.BytesToBytes32([]byte{0x01}).String(), Raw: "0x01"}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/"+addr.String()+"/storage/"+key.String(), r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("raw"))
+		assert.Equal(t, tccommon.BestRevision, r.URL.Query().Get("revision"))
+
+		marshal, err := json.Marshal(expectedStorageRsp)
+		require.NoError(t, err)
+
+		w.Write(marshal)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL)
+	data, err := client.GetAccountRawStorage(&addr, &key, tccommon.BestRevision)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStorageRsp, data)
+}
+
 func TestClient_GetExpandedBlock(t *testing.T) {
 	blockID := "123"
 	expectedBlock := &blocks.JSONExpandedBlock{}
@@ -154,6 +154,12 @@ func (c *Client) AccountStorage(addr *thor.Address, key *thor.Bytes32, opts ...O
 	return c.httpConn.GetAccountStorage(addr, key, options.revision)
 }
 
+// AccountRawStorage retrieves the storage value along with its rlp raw value for the given address and key.
+func (c *Client) AccountRawStorage(addr *thor.Address, key *thor.Bytes32, opts ...Option) (*accounts.GetStorageResult, error) {
+	options := applyOptions(opts)
+	return c.httpConn.GetAccountRawStorage(addr, key, options.revision)
+}
+
 // Transaction retrieves a transaction by its ID.
 func (c *Client) Transaction(id *thor.Bytes32, opts ...Option) (*transactions.Transaction, error) {
 	options := applyHeadOptions(opts)
@@ -110,6 +110,7 @@ type jsTracer struct {
 	gasLimit          uint64                // Amount of gas bought for the whole tx
 	err               error                 // Any error that should stop tracing
 	obj               *goja.Object          // Trace object
+	sandbox           *sandbox              // Resource usage and limits of the tracer
 
 	// Methods exposed by tracer
 	result goja.Callable
@@ -138,28 +139,49 @@ type jsTracer struct {
 // The methods `step`, `enter`, and `exit` are optional, but note that
 // `enter` and `exit` always go together.
 func newJsTracer(code string, cfg json.RawMessage) (tracers.Tracer, error) {
+	return newJsTracerWithLimits(code, cfg, Limits{})
+}
+
+// NewTracer instantiates a tracer from user-provided JS code, which is aborted
+// with a LimitError once any of the given limits is exceeded.
+func NewTracer(code string, cfg json.RawMessage, limits Limits) (tracers.Tracer, error) {
+	return newJsTracerWithLimits(code, cfg, limits)
+}
+
+// newJsTracerWithLimits instantiates a new JS tracer instance which is
+// aborted with a LimitError once any of the given limits is exceeded.
+func newJsTracerWithLimits(code string, cfg json.RawMessage, limits Limits) (tracers.Tracer, error) {
 	vm := goja.New()
 	// By default field names are exported to JS as is, i.e. capitalized.
 	vm.SetFieldNameMapper(goja.UncapFieldNameMapper())
+	vm.SetMaxCallStackSize(maxCallStackSize)
 	t := &jsTracer{
-		vm:  vm,
-		ctx: make(map[string]goja.Value),
+		vm:      vm,
+		ctx:     make(map[string]goja.Value),
+		sandbox: &sandbox{limits: limits},
 	}
 
 	t.setTypeConverters()
 	t.setBuiltinFunctions()
+	t.sandbox.start(vm)
 	ret, err := vm.RunString("(" + code + ")")
 	if err != nil {
+		t.sandbox.stop()
+		if limitErr := t.abortError(err); limitErr != nil {
+			return nil, limitErr
+		}
 		return nil, err
 	}
 	// Check tracer's interface for required and optional methods.
 	obj := ret.ToObject(vm)
 	result, ok := goja.AssertFunction(obj.Get("result"))
 	if !ok {
+		t.sandbox.stop()
 		return nil, errors.New("trace object must expose a function result()")
 	}
 	fault, ok := goja.AssertFunction(obj.Get("fault"))
 	if !ok {
+		t.sandbox.stop()
 		return nil, errors.New("trace object must expose a function fault()")
 	}
 	step, ok := goja.AssertFunction(obj.Get("step"))
@@ -167,6 +189,7 @@ func newJsTracer(code string, cfg json.RawMessage) (tracers.Tracer, error) {
 	enter, hasEnter := goja.AssertFunction(obj.Get("enter"))
 	exit, hasExit := goja.AssertFunction(obj.Get("exit"))
 	if hasEnter != hasExit {
+		t.sandbox.stop()
 		return nil, errors.New("trace object must expose either both or none of enter() and exit()")
 	}
 	t.traceFrame = hasEnter
@@ -184,6 +207,10 @@ func newJsTracer(code string, cfg json.RawMessage) (tracers.Tracer, error) {
 			cfgStr = string(cfg)
 		}
 		if _, err := setup(obj, vm.ToValue(cfgStr)); err != nil {
+			t.sandbox.stop()
+			if limitErr := t.abortError(err); limitErr != nil {
+				return nil, limitErr
+			}
 			return nil, err
 		}
 	}
@@ -262,6 +289,10 @@ func (t *jsTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, memor
 	log.refund = t.env.StateDB.GetRefund()
 	log.depth = depth
 	log.err = err
+	if err := t.sandbox.step(); err != nil {
+		t.onError("step", err)
+		return
+	}
 	if _, err := t.step(t.obj, t.logValue, t.dbValue); err != nil {
 		t.onError("step", err)
 	}
@@ -274,6 +305,10 @@ func (t *jsTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, memor
 	}
 	// Other log fields have been already set as part of the last CaptureState.
 	t.log.err = err
+	if err := t.sandbox.step(); err != nil {
+		t.onError("fault", err)
+		return
+	}
 	if _, err := t.fault(t.obj, t.logValue, t.dbValue); err != nil {
 		t.onError("fault", err)
 	}
@@ -306,6 +341,10 @@ func (t *jsTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Ad
 		t.frame.value = new(big.Int).SetBytes(value.Bytes())
 	}
 
+	if err := t.sandbox.step(); err != nil {
+		t.onError("enter", err)
+		return
+	}
 	if _, err := t.enter(t.obj, t.frameValue); err != nil {
 		t.onError("enter", err)
 	}
@@ -317,11 +356,18 @@ func (t *jsTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	if !t.traceFrame {
 		return
 	}
+	if t.err != nil {
+		return
+	}
 
 	t.frameResult.gasUsed = uint(gasUsed)
 	t.frameResult.output = common.CopyBytes(output)
 	t.frameResult.err = err
 
+	if err := t.sandbox.step(); err != nil {
+		t.onError("exit", err)
+		return
+	}
 	if _, err := t.exit(t.obj, t.frameResultValue); err != nil {
 		t.onError("exit", err)
 	}
@@ -346,34 +392,82 @@ func (t *jsTracer) SetContext(ctx *tracers.Context) {
 
 // GetResult calls the Javascript 'result' function and returns its value, or any accumulated error
 func (t *jsTracer) GetResult() (json.RawMessage, error) {
+	defer t.sandbox.stop()
+	if _, ok := AsLimitError(t.err); ok {
+		return nil, t.err
+	}
 	ctx := t.vm.ToValue(t.ctx)
 	res, err := t.result(t.obj, ctx, t.dbValue)
 	if err != nil {
+		if limitErr := t.abortError(err); limitErr != nil {
+			return nil, limitErr
+		}
 		return nil, wrapError("result", err)
 	}
 	encoded, err := json.Marshal(res)
 	if err != nil {
 		return nil, err
 	}
+	if err := t.sandbox.output(len(encoded)); err != nil {
+		return nil, t.abortError(err)
+	}
 	return json.RawMessage(encoded), t.err
 }
 
 // Stop terminates execution of the tracer at the first opportune moment.
 func (t *jsTracer) Stop(err error) {
+	t.release()
 	t.vm.Interrupt(err)
 }
 
+// release stops the watchdog of the tracer without interrupting it.
+func (t *jsTracer) release() {
+	t.sandbox.stop()
+}
+
+// Release stops the watchdog of a custom tracer which is not going to run or has already failed.
+// It's a no-op for other tracers.
+func Release(tracer tracers.Tracer) {
+	if t, ok := tracer.(*jsTracer); ok {
+		t.release()
+	}
+}
+
 // onError is called anytime the running JS code is interrupted
 // and returns an error. It in turn pings the EVM to cancel its
 // execution.
 func (t *jsTracer) onError(context string, err error) {
-	t.err = wrapError(context, err)
+	if limitErr := t.abortError(err); limitErr != nil {
+		t.err = limitErr
+	} else {
+		t.err = wrapError(context, err)
+	}
 	// `env` is set on CaptureStart which comes before any JS execution.
 	// So it should be non-nil.
 	t.env.Cancel()
 }
 
+// abortError returns the LimitError if the error is caused by exceeding a limit,
+// or nil otherwise. Limit errors and interruptions by Stop are counted by the abort metrics.
+func (t *jsTracer) abortError(err error) error {
+	limitErr, ok := AsLimitError(err)
+	if !ok {
+		var interrupted *goja.InterruptedError
+		if errors.As(err, &interrupted) {
+			metricTracerAbortCount().AddWithLabel(1, map[string]string{"reason": "interrupted"})
+		}
+		return nil
+	}
+	metricTracerAbortCount().AddWithLabel(1, map[string]string{"reason": string(limitErr.Kind)})
+	return limitErr
+}
+
 func wrapError(context string, err error) error {
+	// the exception carries no value
+	var stackErr *goja.StackOverflowError
+	if errors.As(err, &stackErr) {
+		err = fmt.Errorf("maximum call stack size %d exceeded", maxCallStackSize)
+	}
 	return fmt.Errorf("%v    in server-side tracer function '%v'", err, context)
 }
 
@@ -492,6 +586,9 @@ func (t *jsTracer) setTypeConverters() error {
 	// Cache uint8ArrayType once to be used every time for less overhead.
 	uint8ArrayType := t.vm.Get("Uint8Array")
 	toBufWrapper := func(vm *goja.Runtime, val []byte) (goja.Value, error) {
+		if err := t.sandbox.alloc(len(val)); err != nil {
+			return nil, err
+		}
 		return toBuf(vm, uint8ArrayType, val)
 	}
 	t.toBuf = toBufWrapper
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package js
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/vechain/thor/v2/metrics"
+)
+
+var metricTracerAbortCount = metrics.LazyLoadCounterVec("tracer_js_abort_count", []string{"reason"})
+
+// LimitKind names a limit enforced on custom tracers.
+type LimitKind string
+
+const (
+	LimitSteps  LimitKind = "steps"  // number of tracer hook invocations
+	LimitMemory LimitKind = "memory" // bytes of EVM data copied into the JS runtime
+	LimitOutput LimitKind = "output" // size of the JSON encoded result
+	LimitTime   LimitKind = "time"   // wall-clock time since the tracer was created
+)
+
+// Limits are the per-tracer limits of a custom JS tracer. Zero value of a field means unlimited.
+//
+// JS code can't be metered by instructions or allocations, code executed in a single hook, e.g. a loop, is bounded
+// by MaxDuration. MaxMemory meters the buffers copied into the JS runtime by the tracer itself, it's a budget over
+// the whole trace and copies released by JS are not refunded, so it's disabled by default.
+type Limits struct {
+	MaxSteps      uint64        // max count of JS hook invocations (step, fault, enter and exit)
+	MaxMemory     uint64        // max total bytes of buffers (memory, code, input, output, etc.) handed to JS
+	MaxOutputSize uint64        // max size of the JSON encoded result
+	MaxDuration   time.Duration // max wall-clock time of the tracer, including the result function
+}
+
+// DefaultLimits are the default limits of custom tracers served by the API.
+var DefaultLimits = Limits{
+	MaxSteps:      10_000_000,
+	MaxOutputSize: 32 * 1024 * 1024,
+	MaxDuration:   10 * time.Second,
+}
+
+// maxCallStackSize bounds the recursion depth of JS code, which would otherwise overflow the goroutine stack.
+const maxCallStackSize = 1000
+
+// watchdogInterval is the interval to check the duration limit.
+const watchdogInterval = 10 * time.Millisecond
+
+// LimitError is returned when a custom tracer is aborted for exceeding one of its limits.
+type LimitError struct {
+	Kind  LimitKind
+	Limit uint64
+}
+
+func (e *LimitError) Error() string {
+	switch e.Kind {
+	case LimitTime:
+		return fmt.Sprintf("tracer aborted: %s limit exceeded (%v)", e.Kind, time.Duration(e.Limit))
+	case LimitMemory, LimitOutput:
+		return fmt.Sprintf("tracer aborted: %s limit exceeded (%d bytes)", e.Kind, e.Limit)
+	default:
+		return fmt.Sprintf("tracer aborted: %s limit exceeded (%d)", e.Kind, e.Limit)
+	}
+}
+
+// AsLimitError returns the LimitError in the error chain, if any.
+func AsLimitError(err error) (*LimitError, bool) {
+	// goja.InterruptedError unwraps to the value passed to Interrupt.
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr, true
+	}
+	return nil, false
+}
+
+// sandbox tracks resource usage of a tracer against its limits.
+type sandbox struct {
+	limits   Limits
+	steps    uint64
+	memory   uint64
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// start runs the watchdog of the duration limit, which interrupts the runtime once it's exceeded.
+func (s *sandbox) start(vm *goja.Runtime) {
+	if s.limits.MaxDuration == 0 {
+		return
+	}
+	deadline := time.Now().Add(s.limits.MaxDuration)
+	s.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(watchdogInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.done:
+				return
+			case now := <-ticker.C:
+				if now.After(deadline) {
+					vm.Interrupt(&LimitError{Kind: LimitTime, Limit: uint64(s.limits.MaxDuration)})
+					return
+				}
+			}
+		}
+	}()
+}
+
+// stop stops the watchdog.
+func (s *sandbox) stop() {
+	s.stopOnce.Do(func() {
+		if s.done != nil {
+			close(s.done)
+		}
+	})
+}
+
+// step charges one hook invocation.
+func (s *sandbox) step() error {
+	if s.limits.MaxSteps == 0 {
+		return nil
+	}
+	s.steps++
+	if s.steps > s.limits.MaxSteps {
+		return &LimitError{Kind: LimitSteps, Limit: s.limits.MaxSteps}
+	}
+	return nil
+}
+
+// alloc charges n bytes handed to the JS runtime.
+func (s *sandbox) alloc(n int) error {
+	if s.limits.MaxMemory == 0 {
+		return nil
+	}
+	s.memory += uint64(n)
+	if s.memory > s.limits.MaxMemory {
+		return &LimitError{Kind: LimitMemory, Limit: s.limits.MaxMemory}
+	}
+	return nil
+}
+
+// output checks the size of the encoded result.
+func (s *sandbox) output(n int) error {
+	if s.limits.MaxOutputSize > 0 && uint64(n) > s.limits.MaxOutputSize {
+		return &LimitError{Kind: LimitOutput, Limit: s.limits.MaxOutputSize}
+	}
+	return nil
+}
@@ -194,6 +194,83 @@ func TestHaltBetweenSteps(t *testing.T) {
 	}
 }
 
+func TestLimits(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		code   string
+		limits Limits
+		kind   LimitKind
+	}{
+		{
+			name:   "steps",
+			code:   "{step: function() {}, fault: function() {}, result: function() { return null; }}",
+			limits: Limits{MaxSteps: 2},
+			kind:   LimitSteps,
+		}, {
+			name:   "memory",
+			code:   "{addrs: [], step: function(log) { this.addrs.push(log.contract.getAddress()); }, fault: function() {}, result: function() { return this.addrs.length; }}",
+			limits: Limits{MaxMemory: 50},
+			kind:   LimitMemory,
+		}, {
+			name:   "output",
+			code:   "{step: function() {}, fault: function() {}, result: function() { return 'x'.repeat(100); }}",
+			limits: Limits{MaxOutputSize: 50},
+			kind:   LimitOutput,
+		}, {
+			name:   "time",
+			code:   "{step: function() { while(1); }, fault: function() {}, result: function() { return null; }}",
+			limits: Limits{MaxDuration: 100 * time.Millisecond},
+			kind:   LimitTime,
+		}, {
+			name:   "setup time",
+			code:   "{setup: function() { while(1); }, step: function() {}, fault: function() {}, result: function() { return null; }}",
+			limits: Limits{MaxDuration: 100 * time.Millisecond},
+			kind:   LimitTime,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer, err := newJsTracerWithLimits(tt.code, nil, tt.limits)
+			if err == nil {
+				_, err = runTrace(tracer, testCtx(), &vm.ChainConfig{ChainConfig: *params.TestChainConfig}, nil)
+			}
+			limitErr, ok := AsLimitError(err)
+			if !ok {
+				t.Fatalf("expected limit error, got %v", err)
+			}
+			if limitErr.Kind != tt.kind {
+				t.Errorf("expected %s limit error, got %v", tt.kind, limitErr)
+			}
+		})
+	}
+
+	// deep recursion fails instead of overflowing the goroutine stack
+	tracer, err := newJsTracerWithLimits("{step: function() { var f = function() { return f(); }; f(); }, fault: function() {}, result: function() { return null; }}", nil, Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runTrace(tracer, testCtx(), &vm.ChainConfig{ChainConfig: *params.TestChainConfig}, nil); err == nil || !strings.Contains(err.Error(), "call stack") {
+		t.Errorf("expected call stack error, got %v", err)
+	}
+
+	// within limits
+	tracer, err = newJsTracerWithLimits("{step: function(log) { log.contract.getAddress(); }, fault: function() {}, result: function() { return 'ok'; }}", nil, Limits{
+		MaxSteps:      3,
+		MaxMemory:     60,
+		MaxOutputSize: 4,
+		MaxDuration:   time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := runTrace(tracer, testCtx(), &vm.ChainConfig{ChainConfig: *params.TestChainConfig}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != `"ok"` {
+		t.Errorf("unexpected result %s", res)
+	}
+}
+
 // testNoStepExec tests a regular value transfer (no exec), and accessing the statedb
 // in 'result'
 func TestNoStepExec(t *testing.T) {
@@ -0,0 +1,424 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package native
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tracers"
+	"github.com/vechain/thor/v2/vm"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("profilerTracer", newProfilerTracer, false)
+}
+
+// profilerTracer aggregates gas, execution count and memory growth per
+// (contract, pc) and per opcode. When a solc source map is supplied for a
+// contract, pcs are mapped to source locations. Besides the aggregated
+// tables, the result carries the profile in the folded stack format, which
+// can be fed into flame graph tools directly.
+//
+// Example:
+//
+//	> debug.traceClause("0x...", {tracer: "profilerTracer", config: {"contracts": {"0x...": {"sourceMap": "...", "sources": [{"name": "A.sol", "content": "..."}]}}}})
+//	{
+//	  "gas": 43127,
+//	  "locations": [...],
+//	  "opcodes": {...},
+//	  "folded": ["0x...;A.sol:12 22100", ...]
+//	}
+type profilerTracer struct {
+	noopTracer
+	contracts map[common.Address]*sourceMapping
+	locations map[profileLocationKey]*profileLocation
+	opcodes   map[string]*profileStat
+	folded    map[string]uint64
+	frames    []*profileFrame
+	gas       uint64
+	interrupt atomic.Value // Atomic flag to signal execution interruption
+	reason    error        // Textual reason for the interruption
+}
+
+type profilerTracerConfig struct {
+	Contracts map[common.Address]*profilerContract `json:"contracts"` // Source maps keyed by contract address
+}
+
+type profilerContract struct {
+	SourceMap string           `json:"sourceMap"` // The solc source map of the code, e.g. `evm.deployedBytecode.sourceMap`
+	Sources   []profilerSource `json:"sources"`   // Sources indexed by solc source id
+}
+
+type profilerSource struct {
+	Name    string `json:"name"`
+	Content string `json:"content,omitempty"` // Used to resolve line numbers, byte offsets are reported if empty
+}
+
+type profileStat struct {
+	Gas          uint64 `json:"gas"`
+	Count        uint64 `json:"count"`
+	MemoryGrowth uint64 `json:"memoryGrowth"`
+}
+
+type profileSource struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+}
+
+type profileLocationKey struct {
+	address common.Address
+	pc      uint64
+}
+
+type profileLocation struct {
+	Address common.Address `json:"address"`
+	PC      uint64         `json:"pc"`
+	Op      string         `json:"op"`
+	Source  *profileSource `json:"source,omitempty"`
+	profileStat
+}
+
+type profileResult struct {
+	Gas       uint64                  `json:"gas"`
+	Locations []*profileLocation      `json:"locations"`
+	Opcodes   map[string]*profileStat `json:"opcodes"`
+	Folded    []string                `json:"folded"`
+}
+
+// profileFrame tracks the last executed instruction of a call frame, so that
+// memory growth and forwarded gas can be attributed once known.
+type profileFrame struct {
+	address common.Address
+	last    *profileLocation
+	memLen  int
+}
+
+// newProfilerTracer returns a native go tracer which profiles the gas usage
+// of a clause, and implements vm.EVMLogger.
+func newProfilerTracer(cfg json.RawMessage) (tracers.Tracer, error) {
+	var config profilerTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	t := &profilerTracer{
+		contracts: make(map[common.Address]*sourceMapping),
+		locations: make(map[profileLocationKey]*profileLocation),
+		opcodes:   make(map[string]*profileStat),
+		folded:    make(map[string]uint64),
+	}
+	for addr, contract := range config.Contracts {
+		if contract == nil {
+			continue
+		}
+		mapping, err := newSourceMapping(contract)
+		if err != nil {
+			return nil, errors.Wrapf(err, "source map of %s", addr.Hex())
+		}
+		t.contracts[addr] = mapping
+	}
+	return t, nil
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *profilerTracer) CaptureStart(_ *vm.EVM, _ common.Address, to common.Address, _ bool, _ []byte, _ uint64, _ *big.Int) {
+	t.frames = append(t.frames[:0], &profileFrame{address: to})
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *profilerTracer) CaptureState(pc uint64, op vm.OpCode, _, cost uint64, memory *vm.Memory, _ *vm.Stack, contract *vm.Contract, _ []byte, _ int, err error) {
+	if err != nil {
+		return
+	}
+	// Skip if tracing was interrupted
+	if stop := t.interrupt.Load(); stop != nil && stop.(bool) {
+		return
+	}
+	if len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.settleMemory(frame, memory.Len())
+
+	addr := contract.Address()
+	if contract.CodeAddr != nil {
+		addr = *contract.CodeAddr
+	}
+	frame.address = addr
+
+	key := profileLocationKey{addr, pc}
+	loc := t.locations[key]
+	if loc == nil {
+		loc = &profileLocation{Address: addr, PC: pc, Op: op.String()}
+		if mapping := t.contracts[addr]; mapping != nil {
+			loc.Source = mapping.lookup(contract.Code, pc)
+		}
+		t.locations[key] = loc
+	}
+	loc.Gas += cost
+	loc.Count++
+
+	opStat := t.opcodes[loc.Op]
+	if opStat == nil {
+		opStat = &profileStat{}
+		t.opcodes[loc.Op] = opStat
+	}
+	opStat.Gas += cost
+	opStat.Count++
+
+	t.gas += cost
+	t.folded[t.stackPath(loc)] += cost
+
+	frame.last = loc
+	frame.memLen = memory.Len()
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *profilerTracer) CaptureEnter(typ vm.OpCode, _ common.Address, to common.Address, _ []byte, gas uint64, value *big.Int) {
+	// Skip if tracing was interrupted
+	if stop := t.interrupt.Load(); stop != nil && stop.(bool) {
+		return
+	}
+	if len(t.frames) == 0 {
+		return
+	}
+	// The cost of call ops includes the gas forwarded to the callee, which is
+	// accounted by the instructions of the callee. Keep only the self cost.
+	// Create ops use the forwarded gas while executed, so their cost excludes it.
+	switch typ {
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		parent := t.frames[len(t.frames)-1]
+		if parent.last == nil {
+			break
+		}
+		forwarded := gas
+		if (typ == vm.CALL || typ == vm.CALLCODE) && value != nil && value.Sign() != 0 {
+			forwarded -= params.CallStipend
+		}
+		path := t.stackPath(parent.last)
+		parent.last.Gas -= forwarded
+		t.opcodes[parent.last.Op].Gas -= forwarded
+		t.folded[path] -= forwarded
+		t.gas -= forwarded
+	}
+	t.frames = append(t.frames, &profileFrame{address: to})
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *profilerTracer) CaptureExit(_ []byte, _ uint64, _ error) {
+	if len(t.frames) > 1 {
+		t.frames = t.frames[:len(t.frames)-1]
+	}
+}
+
+// GetResult returns the json-encoded profile, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *profilerTracer) GetResult() (json.RawMessage, error) {
+	result := profileResult{
+		Gas:       t.gas,
+		Locations: make([]*profileLocation, 0, len(t.locations)),
+		Opcodes:   t.opcodes,
+		Folded:    make([]string, 0, len(t.folded)),
+	}
+	for _, loc := range t.locations {
+		result.Locations = append(result.Locations, loc)
+	}
+	sort.Slice(result.Locations, func(i, j int) bool {
+		a, b := result.Locations[i], result.Locations[j]
+		if a.Gas != b.Gas {
+			return a.Gas > b.Gas
+		}
+		if a.Address != b.Address {
+			return strings.Compare(a.Address.Hex(), b.Address.Hex()) < 0
+		}
+		return a.PC < b.PC
+	})
+	for path, gas := range t.folded {
+		if gas > 0 {
+			result.Folded = append(result.Folded, path+" "+strconv.FormatUint(gas, 10))
+		}
+	}
+	sort.Strings(result.Folded)
+
+	res, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *profilerTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// settleMemory attributes the memory growth since the last step of the frame
+// to the instruction executed in that step.
+func (t *profilerTracer) settleMemory(frame *profileFrame, memLen int) {
+	if frame.last == nil || memLen <= frame.memLen {
+		return
+	}
+	growth := uint64(memLen - frame.memLen)
+	frame.last.MemoryGrowth += growth
+	t.opcodes[frame.last.Op].MemoryGrowth += growth
+}
+
+// stackPath returns the folded stack of the given location, from the top
+// level call frame to the location itself.
+func (t *profilerTracer) stackPath(loc *profileLocation) string {
+	var b strings.Builder
+	for i, frame := range t.frames {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(frame.address.Hex())
+	}
+	b.WriteByte(';')
+	if loc.Source != nil {
+		b.WriteString(loc.Source.String())
+	} else {
+		b.WriteString(fmt.Sprintf("%s@%d", loc.Op, loc.PC))
+	}
+	return b.String()
+}
+
+func (s *profileSource) String() string {
+	if s.Line > 0 {
+		return fmt.Sprintf("%s:%d", s.File, s.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", s.File, s.Offset, s.Length)
+}
+
+// sourceMapEntry is a decompressed item of the solc source map.
+type sourceMapEntry struct {
+	offset int
+	length int
+	file   int
+}
+
+// sourceMapping maps the pcs of a contract to its source locations.
+type sourceMapping struct {
+	entries []sourceMapEntry
+	sources []profilerSource
+	lines   [][]int // start offsets of lines per source
+	indexes map[thor.Bytes32][]int
+}
+
+// newSourceMapping decompresses the solc source map, which is a list of
+// `s:l:f:j:m` entries separated by `;`, where empty fields inherit the
+// value of the previous entry.
+func newSourceMapping(contract *profilerContract) (*sourceMapping, error) {
+	m := &sourceMapping{
+		sources: contract.Sources,
+		lines:   make([][]int, len(contract.Sources)),
+		indexes: make(map[thor.Bytes32][]int),
+	}
+	var prev sourceMapEntry
+	for i, item := range strings.Split(contract.SourceMap, ";") {
+		entry := prev
+		for j, field := range strings.Split(item, ":") {
+			if field == "" || j > 2 {
+				continue
+			}
+			v, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, errors.Wrapf(err, "entry %d", i)
+			}
+			switch j {
+			case 0:
+				entry.offset = v
+			case 1:
+				entry.length = v
+			case 2:
+				entry.file = v
+			}
+		}
+		m.entries = append(m.entries, entry)
+		prev = entry
+	}
+
+	for i, src := range contract.Sources {
+		if src.Content == "" {
+			continue
+		}
+		lines := []int{0}
+		for j := range len(src.Content) {
+			if src.Content[j] == '\n' {
+				lines = append(lines, j+1)
+			}
+		}
+		m.lines[i] = lines
+	}
+	return m, nil
+}
+
+// lookup returns the source location of the instruction at pc, nil if the
+// instruction is not mapped to any source, e.g. generated code.
+func (m *sourceMapping) lookup(code []byte, pc uint64) *profileSource {
+	hash := thor.Keccak256(code)
+	index, ok := m.indexes[hash]
+	if !ok {
+		index = instructionIndexes(code)
+		m.indexes[hash] = index
+	}
+	if pc >= uint64(len(index)) {
+		return nil
+	}
+	i := index[pc]
+	if i < 0 || i >= len(m.entries) {
+		return nil
+	}
+	entry := m.entries[i]
+	if entry.file < 0 || entry.file >= len(m.sources) {
+		return nil
+	}
+
+	src := &profileSource{
+		File:   m.sources[entry.file].Name,
+		Offset: entry.offset,
+		Length: entry.length,
+	}
+	if lines := m.lines[entry.file]; lines != nil {
+		src.Line = sort.Search(len(lines), func(k int) bool { return lines[k] > entry.offset })
+	}
+	return src
+}
+
+// instructionIndexes maps each pc of the code to the index of the instruction,
+// the source map is indexed by instructions rather than pcs. Pcs inside push
+// data are mapped to -1.
+func instructionIndexes(code []byte) []int {
+	index := make([]int, len(code))
+	n := 0
+	for pc := 0; pc < len(code); pc++ {
+		index[pc] = n
+		op := vm.OpCode(code[pc])
+		if op >= vm.PUSH1 && op <= vm.PUSH32 {
+			size := int(op - vm.PUSH1 + 1)
+			for k := 1; k <= size && pc+k < len(code); k++ {
+				index[pc+k] = -1
+			}
+			pc += size
+		}
+		n++
+	}
+	return index
+}
@@ -18,15 +18,18 @@ package tracers_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
 	"github.com/vechain/thor/v2/block"
 	"github.com/vechain/thor/v2/chain"
@@ -112,6 +115,11 @@ type prestateTest struct {
 	diffState
 }
 
+type flatCallTest struct {
+	traceTest
+	Result json.RawMessage `json:"result"`
+}
+
 func RunTracerTest(t *testing.T, data *traceTest, tracerName string) json.RawMessage {
 	db := muxdb.NewMem()
 	gene, _, _, err := genesis.NewTestnet().Build(state.NewStater(db))
@@ -153,8 +161,11 @@ func RunTracerTest(t *testing.T, data *traceTest, tracerName string) json.RawMes
 	}
 
 	tr.SetContext(&tracers.Context{
-		BlockTime: rt.Context().Time,
-		State:     rt.State(),
+		BlockID:     data.Context.BlockID,
+		BlockTime:   rt.Context().Time,
+		TxID:        data.Context.TxID,
+		ClauseIndex: data.Context.ClauseIndex,
+		State:       rt.State(),
 	})
 	rt.SetVMConfig(vm.Config{Tracer: tr})
 
@@ -266,6 +277,188 @@ func TestPreStateTracers(t *testing.T) {
 	}
 }
 
+func TestFlatCallTracers(t *testing.T) {
+	files, err := os.ReadDir("testdata/flat_call")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		f := file
+		t.Run(strings.TrimSuffix(f.Name(), ".json"), func(t *testing.T) {
+			var testData flatCallTest
+
+			if blob, err := os.ReadFile(filepath.Join("testdata/flat_call", file.Name())); err != nil {
+				t.Fatalf("failed to read testcase: %v", err)
+			} else if err := json.Unmarshal(blob, &testData); err != nil {
+				t.Fatalf("failed to parse testcase: %v", err)
+			}
+
+			result := RunTracerTest(t, &testData.traceTest, "flatCallTracer")
+			assert.JSONEq(t, string(testData.Result), string(result))
+		})
+	}
+}
+
+func TestMuxTracer(t *testing.T) {
+	var testData callTest
+	if blob, err := os.ReadFile("testdata/calls.json"); err != nil {
+		t.Fatalf("failed to read testcase: %v", err)
+	} else if err := json.Unmarshal(blob, &testData); err != nil {
+		t.Fatalf("failed to parse testcase: %v", err)
+	}
+
+	names := []string{"callTracer", "prestateTracer", "4byteTracer"}
+	want := make(map[string]json.RawMessage)
+	for _, name := range names {
+		want[name] = RunTracerTest(t, &testData.traceTest, name)
+	}
+
+	testData.Config = json.RawMessage(`{"callTracer":{},"prestateTracer":null,"4byteTracer":{}}`)
+	result := RunTracerTest(t, &testData.traceTest, "muxTracer")
+
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(result, &got); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(names), len(got))
+	for _, name := range names {
+		assert.JSONEq(t, string(want[name]), string(got[name]), name)
+	}
+
+	_, err := tracers.DefaultDirectory.New("muxTracer", json.RawMessage(`{"muxTracer":{}}`), false)
+	assert.NotNil(t, err)
+	_, err = tracers.DefaultDirectory.New("muxTracer", json.RawMessage(`{"unknownTracer":{}}`), false)
+	assert.NotNil(t, err)
+}
+
+func TestProfilerTracer(t *testing.T) {
+	var (
+		to     = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		origin = common.HexToAddress("0x000000000000000000000000000000000000feed")
+		code   = []byte{
+			byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.MSTORE), // mem[0:32] = 1
+			byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), // in and outs zero, value=0
+			byte(vm.PUSH1), 0xff, byte(vm.GAS), byte(vm.CALL), // call 0xff
+			byte(vm.STOP),
+		}
+		content = "contract A {\n  uint x;\n  function f() {\n    call();\n  }\n}\n"
+	)
+	data := traceTest{
+		Clause: clause{To: (*thor.Address)(&to), Value: (*math.HexOrDecimal256)(big.NewInt(0))},
+		Context: context{
+			BlockTime: 5,
+			TxOrigin:  thor.Address(origin),
+			Gas:       80000,
+		},
+		State: map[common.Address]account{
+			to:     {Balance: (*math.HexOrDecimal256)(big.NewInt(0)), Energy: (*math.HexOrDecimal256)(big.NewInt(0)), Code: code},
+			origin: {Balance: (*math.HexOrDecimal256)(big.NewInt(0)), Energy: (*math.HexOrDecimal256)(big.NewInt(0))},
+		},
+	}
+
+	var call callFrame
+	if err := json.Unmarshal(RunTracerTest(t, &data, "callTracer"), &call); err != nil {
+		t.Fatal(err)
+	}
+
+	// 12 instructions, MSTORE maps to line 2, the call maps to line 4
+	data.Config, _ = json.Marshal(map[string]any{
+		"contracts": map[string]any{
+			to.Hex(): map[string]any{
+				"sourceMap": "0:56:0;;16:7;40:6;;;;;;;;0:56",
+				"sources":   []map[string]string{{"name": "A.sol", "content": content}},
+			},
+		},
+	})
+
+	type stat struct {
+		Gas          uint64 `json:"gas"`
+		Count        uint64 `json:"count"`
+		MemoryGrowth uint64 `json:"memoryGrowth"`
+	}
+	var profile struct {
+		Gas       uint64 `json:"gas"`
+		Locations []struct {
+			Address common.Address `json:"address"`
+			PC      uint64         `json:"pc"`
+			Op      string         `json:"op"`
+			Source  *struct {
+				File string `json:"file"`
+				Line int    `json:"line"`
+			} `json:"source"`
+			stat
+		} `json:"locations"`
+		Opcodes map[string]stat `json:"opcodes"`
+		Folded  []string        `json:"folded"`
+	}
+	if err := json.Unmarshal(RunTracerTest(t, &data, "profilerTracer"), &profile); err != nil {
+		t.Fatal(err)
+	}
+
+	// gas forwarded to the callee is not accounted to CALL
+	assert.Equal(t, uint64(call.GasUsed), profile.Gas)
+	assert.Equal(t, 12, len(profile.Locations))
+	assert.Equal(t, uint64(4), profile.Opcodes["DUP1"].Count)
+	assert.Equal(t, uint64(32), profile.Opcodes["MSTORE"].MemoryGrowth)
+
+	for _, loc := range profile.Locations {
+		assert.Equal(t, to, loc.Address)
+		assert.Equal(t, uint64(1), loc.Count)
+		switch loc.Op {
+		case "MSTORE":
+			assert.Equal(t, uint64(32), loc.MemoryGrowth)
+			assert.Equal(t, "A.sol", loc.Source.File)
+			assert.Equal(t, 2, loc.Source.Line)
+		case "CALL":
+			assert.Equal(t, 4, loc.Source.Line)
+		}
+	}
+
+	var folded uint64
+	for _, line := range profile.Folded {
+		idx := strings.LastIndexByte(line, ' ')
+		gas, err := strconv.ParseUint(line[idx+1:], 10, 64)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(line, to.Hex()+";"))
+		folded += gas
+	}
+	assert.Equal(t, profile.Gas, folded)
+	assert.Contains(t, profile.Folded, fmt.Sprintf("%s;A.sol:2 %d", to.Hex(), profile.Opcodes["MSTORE"].Gas))
+
+	// create ops use the forwarded gas while executed, so their cost is kept
+	data.Context.BlockID = thor.Bytes32{0x1} // after the fork enabling CREATE2
+	for _, op := range []vm.OpCode{vm.CREATE, vm.CREATE2} {
+		data.Config = nil
+		call = callFrame{}
+		data.State[to] = account{
+			Balance: (*math.HexOrDecimal256)(big.NewInt(0)),
+			Energy:  (*math.HexOrDecimal256)(big.NewInt(0)),
+			Code: []byte{
+				byte(vm.PUSH5), 0x60, 0x00, 0x60, 0x00, byte(vm.RETURN), byte(vm.PUSH1), 0x0, byte(vm.MSTORE), // mem[27:32] = init code returning nothing
+				byte(vm.PUSH1), 0x0, // salt, popped by CREATE2 only
+				byte(vm.PUSH1), 0x5, byte(vm.PUSH1), 27, byte(vm.PUSH1), 0x0, // size, offset, value
+				byte(op),
+				byte(vm.STOP),
+			},
+		}
+		if err := json.Unmarshal(RunTracerTest(t, &data, "callTracer"), &call); err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, call.Calls, 1, op.String())
+		if err := json.Unmarshal(RunTracerTest(t, &data, "profilerTracer"), &profile); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint64(call.GasUsed), profile.Gas, op.String())
+		assert.GreaterOrEqual(t, profile.Opcodes[op.String()].Gas, params.CreateGas, op.String())
+	}
+
+	_, err := tracers.DefaultDirectory.New("profilerTracer", json.RawMessage(`{"contracts":{"0x00000000000000000000000000000000deadbeef":{"sourceMap":"a:b"}}}`), false)
+	assert.NotNil(t, err)
+}
+
 func TestInternals(t *testing.T) {
 	var (
 		to     = thor.MustParseAddress("0x00000000000000000000000000000000deadbeef")
@@ -32,3 +32,22 @@ type MissingNodeError struct {
 func (err *MissingNodeError) Error() string {
 	return fmt.Sprintf("missing trie node (path %x hash %x #%v) reason: %v", err.Path, err.Ref.hash, err.Ref.ver, err.Err)
 }
+
+// IsMissingNodeError returns whether the error is caused by a MissingNodeError.
+// It follows both Cause and Unwrap chains of wrapped errors.
+func IsMissingNodeError(err error) bool {
+	for err != nil {
+		if _, ok := err.(*MissingNodeError); ok {
+			return true
+		}
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return false
+		}
+	}
+	return false
+}
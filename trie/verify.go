@@ -0,0 +1,83 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package trie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vechain/thor/v2/thor"
+)
+
+// VerifyNodeBlob verifies the blob of a standalone node stored at path against the expected hash,
+// which is usually fetched from an untrusted source and referenced by a verified parent node.
+//
+// For each child node stored standalone, onRef is called with its path, hash and version,
+// so that the whole trie can be verified top-down starting from the root hash.
+// For each leaf embedded in the node, onLeaf is called with the key of the leaf.
+// Note that versions and metadata are not covered by hashes, callers should check them if necessary.
+func VerifyNodeBlob(
+	path []byte,
+	hash thor.Bytes32,
+	blob []byte,
+	onRef func(path []byte, hash thor.Bytes32, ver Version),
+	onLeaf func(key []byte, leaf *Leaf),
+) (err error) {
+	// the decoder assumes well-formed input
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("malformed node: %v", e)
+		}
+	}()
+
+	n, rest, err := decodeNode(nil, blob, 0)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("malformed node: trailing bytes")
+	}
+	switch n.(type) {
+	case *fullNode, *shortNode:
+	default:
+		return errors.New("malformed node: not a standalone node")
+	}
+
+	h := hasherPool.Get().(*hasher)
+	defer hasherPool.Put(h)
+	if computed := thor.BytesToBytes32(h.hash(n, true)); computed != hash {
+		return fmt.Errorf("node hash mismatch: want %v, have %v", hash, computed)
+	}
+	return walkVerified(n, path, onRef, onLeaf)
+}
+
+// walkVerified walks through the node and its embedded children.
+func walkVerified(n node, path []byte, onRef func([]byte, thor.Bytes32, Version), onLeaf func([]byte, *Leaf)) error {
+	switch n := n.(type) {
+	case *fullNode:
+		for i, cn := range n.children {
+			if cn != nil {
+				if err := walkVerified(cn, concat(path, byte(i)), onRef, onLeaf); err != nil {
+					return err
+				}
+			}
+		}
+	case *shortNode:
+		return walkVerified(n.child, concat(path, n.key...), onRef, onLeaf)
+	case *refNode:
+		if len(n.hash) != 32 {
+			return errors.New("malformed node: child without hash")
+		}
+		if onRef != nil {
+			onRef(path, thor.BytesToBytes32(n.hash), n.ver)
+		}
+	case *valueNode:
+		if onLeaf != nil {
+			onLeaf(hexToKeybytes(path), &Leaf{Value: n.val, Meta: n.meta})
+		}
+	}
+	return nil
+}
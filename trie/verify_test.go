@@ -0,0 +1,97 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package trie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestVerifyNodeBlob(t *testing.T) {
+	db := newMemDatabase()
+	tr := New(Root{}, db)
+
+	content := make(map[string]*Leaf)
+	for i := range 500 {
+		key := thor.Blake2b([]byte{byte(i), byte(i >> 8)}).Bytes()
+		leaf := &Leaf{Value: []byte{byte(i), 1}}
+		if i%3 == 0 {
+			leaf.Meta = []byte{byte(i)}
+		}
+		content[string(key)] = leaf
+		require.NoError(t, tr.Update(key, leaf.Value, leaf.Meta))
+		if i == 250 {
+			require.NoError(t, tr.Commit(db, Version{Major: 1}, false))
+		}
+	}
+	// short keys to produce embedded nodes
+	for i := range 16 {
+		key := []byte{byte(i)}
+		leaf := &Leaf{Value: []byte{byte(i)}}
+		content[string(key)] = leaf
+		require.NoError(t, tr.Update(key, leaf.Value, nil))
+	}
+	require.NoError(t, tr.Commit(db, Version{Major: 2, Minor: 1}, false))
+	root := Root{Hash: tr.Hash(), Ver: Version{Major: 2, Minor: 1}}
+
+	type task struct {
+		path []byte
+		hash thor.Bytes32
+		ver  Version
+	}
+
+	// walk the trie top-down by blobs
+	var (
+		tasks  = []task{{nil, root.Hash, root.Ver}}
+		leaves = make(map[string]*Leaf)
+		nodes  int
+	)
+	for len(tasks) > 0 {
+		tk := tasks[len(tasks)-1]
+		tasks = tasks[:len(tasks)-1]
+
+		blob, err := db.Get(tk.path, tk.ver)
+		require.NoError(t, err)
+		require.NoError(t, VerifyNodeBlob(tk.path, tk.hash, blob,
+			func(path []byte, hash thor.Bytes32, ver Version) {
+				tasks = append(tasks, task{path, hash, ver})
+			},
+			func(key []byte, leaf *Leaf) {
+				leaves[string(key)] = leaf
+			}))
+		nodes++
+	}
+	assert.Greater(t, nodes, 1)
+	assert.Equal(t, content, leaves)
+
+	rootBlob, err := db.Get(nil, root.Ver)
+	require.NoError(t, err)
+
+	// wrong hash
+	assert.Error(t, VerifyNodeBlob(nil, thor.Bytes32{1}, rootBlob, nil, nil))
+
+	// tampered child hash
+	var childHash thor.Bytes32
+	require.NoError(t, VerifyNodeBlob(nil, root.Hash, rootBlob, func(_ []byte, hash thor.Bytes32, _ Version) {
+		childHash = hash
+	}, nil))
+	i := bytes.Index(rootBlob, childHash[:])
+	require.True(t, i > 0)
+	tampered := common.CopyBytes(rootBlob)
+	tampered[i] ^= 0x1
+	assert.Error(t, VerifyNodeBlob(nil, root.Hash, tampered, nil, nil))
+
+	// truncated
+	for i := range rootBlob {
+		assert.Error(t, VerifyNodeBlob(nil, root.Hash, rootBlob[:i], nil, nil))
+	}
+	assert.Error(t, VerifyNodeBlob(nil, root.Hash, append(common.CopyBytes(rootBlob), 0), nil, nil))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// impersonationRecoveryID marks impersonated signatures. Recovery ids of real signatures are 0 or 1,
+// so an impersonated signature never recovers to any address, and Origin always fails on it.
+const impersonationRecoveryID = 0xff
+
+// ImpersonatedSignature returns the signature part of the origin, for a tx sent as the origin
+// without its private key. The tx can only be executed by runtimes with impersonation enabled,
+// e.g. of solo, whose blocks are not validated by others.
+//
+// Layout: origin(20 bytes) | zeros(44 bytes) | 0xff
+func ImpersonatedSignature(origin thor.Address) []byte {
+	sig := make([]byte, 65)
+	copy(sig, origin[:])
+	sig[64] = impersonationRecoveryID
+	return sig
+}
+
+// IsImpersonated returns whether the tx is signed by an impersonated signature.
+func (t *Transaction) IsImpersonated() bool {
+	return isImpersonatedSignature(t.body.Signature)
+}
+
+// ImpersonatedSigners returns the origin claimed by the impersonated signature, and the delegator
+// recovered from the delegator signature if the tx is delegated. Nothing proves the origin, so it
+// must only be trusted where impersonation is enabled.
+func (t *Transaction) ImpersonatedSigners() (origin thor.Address, delegator *thor.Address, err error) {
+	if err := t.validateSignatureLength(); err != nil {
+		return thor.Address{}, nil, err
+	}
+	if !t.IsImpersonated() {
+		return thor.Address{}, nil, errors.New("not impersonated")
+	}
+	origin = thor.BytesToAddress(t.body.Signature[:20])
+	if !t.Features().IsDelegated() {
+		return origin, nil, nil
+	}
+	pub, err := crypto.SigToPub(t.DelegatorSigningHash(origin).Bytes(), t.body.Signature[65:])
+	if err != nil {
+		return thor.Address{}, nil, err
+	}
+	addr := thor.Address(crypto.PubkeyToAddress(*pub))
+	return origin, &addr, nil
+}
+
+// impersonatedID returns the id of the impersonated tx.
+// ID = hash(signingHash, claimed origin, 0xff), the marker keeps it apart from the id of the same tx
+// really signed by the origin.
+func (t *Transaction) impersonatedID() thor.Bytes32 {
+	return thor.Blake2b(t.SigningHash().Bytes(), t.body.Signature[:20], []byte{impersonationRecoveryID})
+}
+
+func isImpersonatedSignature(sig []byte) bool {
+	return len(sig) >= 65 &&
+		sig[64] == impersonationRecoveryID &&
+		bytes.Equal(sig[20:64], make([]byte, 44))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 The VeChainThor developers
+
+// Distributed under the GNU Lesser General Public License v3.0 software license, see the accompanying
+// file LICENSE or <https://www.gnu.org/licenses/lgpl-3.0.html>
+
+package tx
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vechain/thor/v2/thor"
+)
+
+func TestImpersonation(t *testing.T) {
+	origin := thor.BytesToAddress([]byte("origin"))
+	delegatorPK, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var features Features
+	features.SetDelegated(true)
+	newTx := func() *Transaction {
+		trx := new(Builder).Features(features).Nonce(1).Build()
+		delegatorSig, err := crypto.Sign(trx.DelegatorSigningHash(origin).Bytes(), delegatorPK)
+		require.NoError(t, err)
+		return trx.WithSignature(append(ImpersonatedSignature(origin), delegatorSig...))
+	}
+
+	// never resolved by Origin
+	trx := newTx()
+	assert.True(t, trx.IsImpersonated())
+	_, err = trx.Origin()
+	assert.Error(t, err)
+	_, err = trx.Delegator()
+	assert.Error(t, err)
+	assert.Equal(t, thor.Blake2b(trx.SigningHash().Bytes(), origin[:], []byte{0xff}), trx.ID())
+
+	got, delegator, err := trx.ImpersonatedSigners()
+	require.NoError(t, err)
+	assert.Equal(t, origin, got)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(delegatorPK.PublicKey)), *delegator)
+
+	// real signatures are not affected
+	signed, err := Sign(new(Builder).Build(), delegatorPK)
+	require.NoError(t, err)
+	assert.False(t, signed.IsImpersonated())
+	_, _, err = signed.ImpersonatedSigners()
+	assert.Error(t, err)
+	got, err = signed.Origin()
+	require.NoError(t, err)
+	assert.Equal(t, thor.Address(crypto.PubkeyToAddress(delegatorPK.PublicKey)), got)
+	assert.Equal(t, thor.Blake2b(signed.SigningHash().Bytes(), got[:]), signed.ID())
+
+	// the same tx impersonated gets another id
+	impersonated := signed.WithSignature(ImpersonatedSignature(got))
+	assert.True(t, impersonated.IsImpersonated())
+	assert.Equal(t, signed.SigningHash(), impersonated.SigningHash())
+	assert.NotEqual(t, signed.ID(), impersonated.ID())
+	assert.False(t, impersonated.ID().IsZero())
+}
@@ -88,7 +88,7 @@ func (t *Transaction) IsExpired(blockNum uint32) bool {
 
 // ID returns id of tx.
 // ID = hash(signingHash, origin).
-// It returns zero Bytes32 if origin not available.
+// It returns zero Bytes32 if origin not available, except for impersonated txs.
 func (t *Transaction) ID() (id thor.Bytes32) {
 	if cached := t.cache.id.Load(); cached != nil {
 		return cached.(thor.Bytes32)
@@ -97,6 +97,9 @@ func (t *Transaction) ID() (id thor.Bytes32) {
 
 	origin, err := t.Origin()
 	if err != nil {
+		if t.IsImpersonated() {
+			return t.impersonatedID()
+		}
 		return
 	}
 	return thor.Blake2b(t.SigningHash().Bytes(), origin[:])
@@ -19,7 +19,9 @@ var (
 )
 
 // workToGas exchange proved work to gas.
-// The decay curve follows Moore's law.
+// The decay curve follows Moore's law. Months are counted with the mainnet block interval even on
+// networks configured with another one, since the work is also evaluated by tools outside the node,
+// which only know the block number of the tx.
 func workToGas(work *big.Int, blockNum uint32) uint64 {
 	gas := new(big.Int).Div(work, workPerGas)
 	if gas.Sign() == 0 {
@@ -62,13 +62,13 @@ func (o *txObject) Payer() *thor.Address {
 	return o.payer
 }
 
-func (o *txObject) Executable(chain *chain.Chain, state *state.State, headBlock *block.Header) (bool, error) {
+func (o *txObject) Executable(chain *chain.Chain, state *state.State, headBlock *block.Header, blockInterval uint64) (bool, error) {
 	switch {
 	case o.Gas() > headBlock.GasLimit():
 		return false, errors.New("gas too large")
 	case o.IsExpired(headBlock.Number() + 1): // Check tx expiration on top of next block
 		return false, errors.New("expired")
-	case o.BlockRef().Number() > headBlock.Number()+uint32(5*60/thor.BlockInterval):
+	case o.BlockRef().Number() > headBlock.Number()+uint32(5*60/blockInterval):
 		// reject deferred tx which will be applied after 5mins
 		return false, errors.New("block ref out of schedule")
 	}
@@ -100,7 +100,7 @@ func (o *txObject) Executable(chain *chain.Chain, state *state.State, headBlock
 	checkpoint := state.NewCheckpoint()
 	defer state.RevertTo(checkpoint)
 
-	_, _, payer, prepaid, _, err := o.resolved.BuyGas(state, headBlock.Timestamp()+thor.BlockInterval)
+	_, _, payer, prepaid, _, err := o.resolved.BuyGas(state, headBlock.Timestamp()+blockInterval)
 	if err != nil {
 		return false, err
 	}
@@ -42,6 +42,7 @@ type Options struct {
 	MaxLifetime            time.Duration
 	BlocklistCacheFilePath string
 	BlocklistFetchURL      string
+	BlockInterval          uint64 // seconds between two blocks, thor.BlockInterval if zero
 }
 
 // TxEvent will be posted when tx is added or status changed.
@@ -71,6 +72,9 @@ type TxPool struct {
 // New create a new TxPool instance.
 // Shutdown is required to be called at end.
 func New(repo *chain.Repository, stater *state.Stater, options Options) *TxPool {
+	if options.BlockInterval == 0 {
+		options.BlockInterval = thor.BlockInterval
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	pool := &TxPool{
 		options: options,
@@ -105,7 +109,7 @@ func (p *TxPool) housekeeping() {
 				headSummary = newHeadSummary
 				headBlockChanged = true
 			}
-			if !isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp()) {
+			if !isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp(), p.options.BlockInterval) {
 				// skip washing txs if not synced
 				continue
 			}
@@ -237,7 +241,7 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		return badTxError{err.Error()}
 	}
 
-	if isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp()) {
+	if isChainSynced(uint64(time.Now().Unix()), headSummary.Header.Timestamp(), p.options.BlockInterval) {
 		if !localSubmitted {
 			// reject when pool size exceeds 120% of limit
 			if p.all.Len() >= p.options.Limit*12/10 {
@@ -246,7 +250,7 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		}
 
 		state := p.stater.NewState(headSummary.Root())
-		executable, err := txObj.Executable(p.repo.NewChain(headSummary.Header.ID()), state, headSummary.Header)
+		executable, err := txObj.Executable(p.repo.NewChain(headSummary.Header.ID()), state, headSummary.Header, p.options.BlockInterval)
 		if err != nil {
 			return txRejectedError{err.Error()}
 		}
@@ -264,7 +268,7 @@ func (p *TxPool) add(newTx *tx.Transaction, rejectNonExecutable bool, localSubmi
 		txObj.executable = executable
 		if err := p.all.Add(txObj, p.options.LimitPerAccount, func(payer thor.Address, needs *big.Int) error {
 			// check payer's balance
-			balance, err := state.GetEnergy(payer, headSummary.Header.Timestamp()+thor.BlockInterval)
+			balance, err := state.GetEnergy(payer, headSummary.Header.Timestamp()+p.options.BlockInterval)
 			if err != nil {
 				return err
 			}
@@ -425,7 +429,7 @@ func (p *TxPool) wash(headSummary *chain.BlockSummary) (executables tx.Transacti
 			continue
 		}
 		// settled, out of energy or dep broken
-		executable, err := txObj.Executable(chain, newState(), headSummary.Header)
+		executable, err := txObj.Executable(chain, newState(), headSummary.Header, p.options.BlockInterval)
 		if err != nil {
 			toRemove = append(toRemove, txObj)
 			logger.Trace("tx washed out", "id", txObj.ID(), "err", err)
@@ -512,10 +516,10 @@ func (p *TxPool) wash(headSummary *chain.BlockSummary) (executables tx.Transacti
 	return executables, 0, nil
 }
 
-func isChainSynced(nowTimestamp, blockTimestamp uint64) bool {
+func isChainSynced(nowTimestamp, blockTimestamp, blockInterval uint64) bool {
 	timeDiff := nowTimestamp - blockTimestamp
 	if blockTimestamp > nowTimestamp {
 		timeDiff = blockTimestamp - nowTimestamp
 	}
-	return timeDiff < thor.BlockInterval*6
+	return timeDiff < blockInterval*6
 }